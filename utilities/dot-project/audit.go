@@ -2,122 +2,387 @@ package projects
 
 import (
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"reflect"
 	"strings"
 	"time"
 )
 
+// maxAuditRedirects is the longest redirect chain the auditor follows
+const maxAuditRedirects = 10
+
+// maxProbeContent caps how much of a document content probes read
+const maxProbeContent = 1 << 20
+
 // AuditResult represents the result of checking a project's governance/security references
 type AuditResult struct {
-	ProjectSlug string       `json:"project_slug"`
-	Checks      []AuditCheck `json:"checks"`
-	PassCount   int          `json:"pass_count"`
-	FailCount   int          `json:"fail_count"`
-	SkipCount   int          `json:"skip_count"`
+	ProjectSlug    string       `json:"project_slug"`
+	Checks         []AuditCheck `json:"checks"`
+	PassCount      int          `json:"pass_count"`
+	FailCount      int          `json:"fail_count"`
+	SkipCount      int          `json:"skip_count"`
+	MovedCount     int          `json:"moved_count"`      // Passing links that redirect permanently and should be updated
+	ProbeFailCount int          `json:"probe_fail_count"` // Failed content probes on reachable links
 }
 
 // AuditCheck represents a single URL accessibility check
 type AuditCheck struct {
-	Field      string `json:"field"`
-	URL        string `json:"url"`
-	Status     string `json:"status"` // "pass", "fail", "skip"
-	StatusCode int    `json:"status_code,omitempty"`
-	Error      string `json:"error,omitempty"`
+	Field      string          `json:"field"`
+	URL        string          `json:"url"`
+	Status     string          `json:"status"` // "pass", "fail", "skip"
+	StatusCode int             `json:"status_code,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	Method     string          `json:"method,omitempty"`    // HEAD, or GET when the server rejected HEAD
+	Attempts   int             `json:"attempts,omitempty"`  // Requests sent, including retries
+	FinalURL   string          `json:"final_url,omitempty"` // Where the redirect chain ended
+	Redirects  []AuditRedirect `json:"redirects,omitempty"`
+	Redirect   string          `json:"redirect,omitempty"` // "permanent" when every hop is, otherwise "temporary"
+	Findings   []AuditFinding  `json:"findings,omitempty"` // Content probe results, when enabled
 }
 
-// AuditProject checks that all referenced URLs in a project are accessible
-func AuditProject(project Project, client *http.Client) AuditResult {
+// AuditFinding is the outcome of a content probe run on a reachable link
+type AuditFinding struct {
+	Probe   string `json:"probe"`  // e.g., "security-reporting-channel"
+	Status  string `json:"status"` // "pass", "fail"
+	Message string `json:"message"`
+}
+
+// AuditRedirect is one hop of a redirect chain
+type AuditRedirect struct {
+	StatusCode int    `json:"status_code"`
+	Location   string `json:"location"` // Absolute URL the hop redirected to
+}
+
+// Auditor checks links with a bounded pool of workers. Each URL is checked
+// once per Auditor, however many projects reference it. HEAD is tried first
+// and a ranged GET is sent when the server answers HEAD with 403 or 405.
+// Network errors, 429 and 5xx responses are retried with backoff. An Auditor
+// must not be used from several goroutines at once.
+type Auditor struct {
+	client  *http.Client
+	options FetchOptions
+	limiter *hostLimiter
+	links   map[string]AuditCheck // Checked links by URL
+
+	probeContent bool                    // Run contentProbes on reachable links
+	contents     map[string]probeContent // Fetched documents by link URL
+}
+
+// probeContent is a document fetched for content probes
+type probeContent struct {
+	Text string
+	Err  error
+}
+
+// NewAuditor creates an auditor. Redirects are followed by the auditor
+// itself, so the client's CheckRedirect policy is not used.
+func NewAuditor(client *http.Client, opts FetchOptions) *Auditor {
 	if client == nil {
 		client = &http.Client{Timeout: 10 * time.Second}
 	}
+	noRedirects := *client
+	noRedirects.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &Auditor{
+		client:  &noRedirects,
+		options: opts,
+		limiter: newHostLimiter(opts.HostInterval),
+		links:   make(map[string]AuditCheck),
 
-	result := AuditResult{
-		ProjectSlug: project.Slug,
+		contents: make(map[string]probeContent),
 	}
+}
 
-	// Collect all URLs to check
-	urlChecks := collectProjectURLs(project)
+// SetContentProbes turns content probes on or off. When on, the documents
+// behind reachable links of fields with a probe (security policy, license,
+// CODEOWNERS, governance document) are fetched and their content checked.
+func (a *Auditor) SetContentProbes(enabled bool) {
+	a.probeContent = enabled
+}
 
-	for _, check := range urlChecks {
-		if check.URL == "" {
-			check.Status = "skip"
-			result.SkipCount++
-		} else {
-			resp, err := client.Head(check.URL)
-			if err != nil {
-				check.Status = "fail"
-				check.Error = err.Error()
-				result.FailCount++
-			} else {
-				resp.Body.Close()
-				check.StatusCode = resp.StatusCode
-				if resp.StatusCode >= 200 && resp.StatusCode < 400 {
-					check.Status = "pass"
-					result.PassCount++
-				} else {
-					check.Status = "fail"
-					check.Error = fmt.Sprintf("HTTP %d", resp.StatusCode)
-					result.FailCount++
-				}
+// AuditProject checks that all referenced URLs in a project are accessible
+func AuditProject(project Project, client *http.Client) AuditResult {
+	return NewAuditor(client, DefaultFetchOptions()).AuditProject(project)
+}
+
+// AuditProject checks that all referenced URLs in a project are accessible
+func (a *Auditor) AuditProject(project Project) AuditResult {
+	return a.AuditProjects([]Project{project})[0]
+}
+
+// AuditProjects checks the referenced URLs of several projects, checking
+// links shared between projects only once. Results keep the order of projects.
+func (a *Auditor) AuditProjects(projects []Project) []AuditResult {
+	projectChecks := make([][]AuditCheck, len(projects))
+	var pending []string
+	queued := make(map[string]bool)
+	for i, project := range projects {
+		projectChecks[i] = collectProjectURLs(project)
+		for _, check := range projectChecks[i] {
+			if _, done := a.links[check.URL]; check.URL != "" && !done && !queued[check.URL] {
+				queued[check.URL] = true
+				pending = append(pending, check.URL)
 			}
 		}
-		result.Checks = append(result.Checks, check)
 	}
 
-	return result
-}
+	checked := make([]AuditCheck, len(pending))
+	forEachConcurrently(len(pending), a.options.Concurrency, func(i int) {
+		checked[i] = a.checkLink(pending[i])
+	})
+	for _, check := range checked {
+		a.links[check.URL] = check
+	}
+	if a.probeContent {
+		a.fetchProbeContent(projectChecks)
+	}
 
-// collectProjectURLs gathers all URL references from a project for checking
-func collectProjectURLs(project Project) []AuditCheck {
-	var checks []AuditCheck
+	results := make([]AuditResult, len(projects))
+	for i, project := range projects {
+		result := AuditResult{ProjectSlug: project.Slug}
+		for _, check := range projectChecks[i] {
+			if check.URL == "" {
+				check.Status = "skip"
+				result.SkipCount++
+				result.Checks = append(result.Checks, check)
+				continue
+			}
+			field := check.Field
+			check = a.links[check.URL]
+			check.Field = field
+			if check.Status == "pass" {
+				result.PassCount++
+				if check.Redirect == "permanent" {
+					result.MovedCount++
+				}
+				if probe, ok := contentProbes[field]; ok && a.probeContent {
+					finding := runContentProbe(probe, a.contents[check.URL])
+					check.Findings = []AuditFinding{finding}
+					if finding.Status == "fail" {
+						result.ProbeFailCount++
+					}
+				}
+			} else {
+				result.FailCount++
+			}
+			result.Checks = append(result.Checks, check)
+		}
+		results[i] = result
+	}
+	return results
+}
 
-	// Website
-	if project.Website != "" {
-		checks = append(checks, AuditCheck{Field: "website", URL: project.Website})
+// fetchProbeContent fetches the documents behind reachable links that have a
+// content probe and have not been fetched yet
+func (a *Auditor) fetchProbeContent(projectChecks [][]AuditCheck) {
+	var pending []AuditCheck
+	queued := make(map[string]bool)
+	for _, checks := range projectChecks {
+		for _, check := range checks {
+			if _, ok := contentProbes[check.Field]; !ok || check.URL == "" {
+				continue
+			}
+			link := a.links[check.URL]
+			if _, done := a.contents[link.URL]; link.Status != "pass" || done || queued[link.URL] {
+				continue
+			}
+			queued[link.URL] = true
+			pending = append(pending, link)
+		}
 	}
 
-	// Artwork
-	if project.Artwork != "" {
-		checks = append(checks, AuditCheck{Field: "artwork", URL: project.Artwork})
+	fetched := make([]probeContent, len(pending))
+	forEachConcurrently(len(pending), a.options.Concurrency, func(i int) {
+		fetched[i] = a.fetchDocument(pending[i])
+	})
+	for i, link := range pending {
+		a.contents[link.URL] = fetched[i]
 	}
+}
 
-	// Repositories
-	for i, repo := range project.Repositories {
-		checks = append(checks, AuditCheck{Field: fmt.Sprintf("repositories[%d]", i), URL: repo})
+// fetchDocument GETs the document a link points to, reading GitHub blob pages
+// from raw.githubusercontent.com and reducing HTML pages to their text
+func (a *Auditor) fetchDocument(link AuditCheck) probeContent {
+	target := link.URL
+	if link.FinalURL != "" {
+		target = link.FinalURL
 	}
+	target = rawContentURL(target)
 
-	// Audit report URLs
-	for i, audit := range project.Audits {
-		checks = append(checks, AuditCheck{Field: fmt.Sprintf("audits[%d].url", i), URL: audit.URL})
+	var attempts int
+	resp, body, err := a.send(http.MethodGet, target, true, &attempts)
+	if err != nil {
+		return probeContent{Err: err}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return probeContent{Err: fmt.Errorf("HTTP %d fetching %s", resp.StatusCode, target)}
 	}
+	text := string(body)
+	if strings.Contains(resp.Header.Get("Content-Type"), "html") {
+		text = htmlToText(text)
+	}
+	return probeContent{Text: text}
+}
 
-	// Security paths (if they look like URLs)
-	if project.Security != nil {
-		if project.Security.Policy != nil && isHTTPURL(project.Security.Policy.Path) {
-			checks = append(checks, AuditCheck{Field: "security.policy.path", URL: project.Security.Policy.Path})
+// checkLink checks a URL, following redirects one hop at a time so the chain
+// can be recorded
+func (a *Auditor) checkLink(rawURL string) AuditCheck {
+	check := AuditCheck{URL: rawURL}
+	current := rawURL
+	for {
+		resp, err := a.probe(current, &check)
+		if err != nil {
+			check.Status = "fail"
+			check.Error = err.Error()
+			return check
 		}
-		if project.Security.ThreatModel != nil && isHTTPURL(project.Security.ThreatModel.Path) {
-			checks = append(checks, AuditCheck{Field: "security.threat_model.path", URL: project.Security.ThreatModel.Path})
+
+		if resp.StatusCode < 300 || resp.StatusCode >= 400 {
+			check.StatusCode = resp.StatusCode
+			break
 		}
+		location := resp.Header.Get("Location")
+		if location == "" {
+			check.StatusCode = resp.StatusCode
+			check.Status = "fail"
+			check.Error = fmt.Sprintf("HTTP %d without a Location header", resp.StatusCode)
+			return check
+		}
+		next, err := url.Parse(current)
+		if err == nil {
+			next, err = next.Parse(location)
+		}
+		if err != nil {
+			check.Status = "fail"
+			check.Error = fmt.Sprintf("invalid redirect location %q: %v", location, err)
+			return check
+		}
+		check.Redirects = append(check.Redirects, AuditRedirect{StatusCode: resp.StatusCode, Location: next.String()})
+		if len(check.Redirects) > maxAuditRedirects {
+			check.Status = "fail"
+			check.Error = fmt.Sprintf("stopped after %d redirects", maxAuditRedirects)
+			return check
+		}
+		current = next.String()
 	}
 
-	// Governance paths
-	if project.Governance != nil {
-		if project.Governance.Contributing != nil && isHTTPURL(project.Governance.Contributing.Path) {
-			checks = append(checks, AuditCheck{Field: "governance.contributing.path", URL: project.Governance.Contributing.Path})
-		}
-		if project.Governance.GovernanceDoc != nil && isHTTPURL(project.Governance.GovernanceDoc.Path) {
-			checks = append(checks, AuditCheck{Field: "governance.governance_doc.path", URL: project.Governance.GovernanceDoc.Path})
+	if len(check.Redirects) > 0 {
+		check.FinalURL = current
+		check.Redirect = "permanent"
+		for _, hop := range check.Redirects {
+			if hop.StatusCode != http.StatusMovedPermanently && hop.StatusCode != http.StatusPermanentRedirect {
+				check.Redirect = "temporary"
+				break
+			}
 		}
 	}
+	if check.StatusCode >= 200 && check.StatusCode < 300 {
+		check.Status = "pass"
+	} else {
+		check.Status = "fail"
+		check.Error = fmt.Sprintf("HTTP %d", check.StatusCode)
+	}
+	return check
+}
+
+// probe requests a single URL without following redirects: HEAD first, then
+// a GET for the first byte if the server rejects HEAD
+func (a *Auditor) probe(rawURL string, check *AuditCheck) (*http.Response, error) {
+	check.Method = http.MethodHead
+	resp, _, err := a.send(http.MethodHead, rawURL, false, &check.Attempts)
+	if err != nil || (resp.StatusCode != http.StatusMethodNotAllowed && resp.StatusCode != http.StatusForbidden) {
+		return resp, err
+	}
+	check.Method = http.MethodGet
+	resp, _, err = a.send(http.MethodGet, rawURL, false, &check.Attempts)
+	return resp, err
+}
+
+// send sends a request, retrying network errors, 429 and 5xx responses with
+// backoff, and counts the requests in attempts. The response body is already
+// closed; with readBody its first maxProbeContent bytes are returned, and
+// otherwise a GET only asks for the first byte.
+func (a *Auditor) send(method, rawURL string, readBody bool, attempts *int) (*http.Response, []byte, error) {
+	host := ""
+	if parsed, err := url.Parse(rawURL); err == nil {
+		host = parsed.Host
+	}
+
+	backoff := a.options.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		a.limiter.wait(host)
+		*attempts++
+		resp, body, retryAfter, err := a.sendOnce(method, rawURL, readBody)
+		if retryAfter < 0 || attempt >= a.options.MaxRetries {
+			return resp, body, err
+		}
 
-	// Documentation paths
-	if project.Documentation != nil {
-		if project.Documentation.Readme != nil && isHTTPURL(project.Documentation.Readme.Path) {
-			checks = append(checks, AuditCheck{Field: "documentation.readme.path", URL: project.Documentation.Readme.Path})
+		delay := backoff
+		if retryAfter > delay {
+			delay = retryAfter
 		}
+		time.Sleep(delay)
+		backoff *= 2
 	}
+}
+
+// sendOnce sends a single request. retryAfter is negative when the outcome
+// is final, and otherwise the delay the server asked for (0 if none).
+func (a *Auditor) sendOnce(method, rawURL string, readBody bool) (*http.Response, []byte, time.Duration, error) {
+	req, err := http.NewRequest(method, rawURL, nil)
+	if err != nil {
+		return nil, nil, -1, err
+	}
+	if method == http.MethodGet && !readBody {
+		req.Header.Set("Range", "bytes=0-0")
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	var body []byte
+	if readBody {
+		body, err = io.ReadAll(io.LimitReader(resp.Body, maxProbeContent))
+	} else {
+		_, err = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return resp, nil, parseRetryAfter(resp.Header.Get("Retry-After")), nil
+	}
+	if readBody && err != nil {
+		return resp, nil, 0, err
+	}
+	return resp, body, -1, nil
+}
 
+// collectProjectURLs gathers all URL references from a project for checking.
+// Every field is walked, so new schema fields are covered without changes
+// here: PathRefs whose path is an HTTP URL, strings annotated with the "uri"
+// format in fieldSchemas, and HTTP URLs in fields annotated as Links (e.g., a
+// mailing list archive next to addresses). Free text such as suppression
+// reasons is not audited. Empty required URLs are kept and reported as skipped.
+func collectProjectURLs(project Project) []AuditCheck {
+	var checks []AuditCheck
+	walkPathRefs(reflect.ValueOf(project), "", fieldSchema{}, func(path string, v reflect.Value, schema fieldSchema) {
+		if ref, ok := v.Interface().(PathRef); ok {
+			// Relative paths live in a repository and are not fetched
+			if isHTTPURL(ref.Path) {
+				checks = append(checks, AuditCheck{Field: path + ".path", URL: ref.Path})
+			}
+			return
+		}
+		value := strings.TrimSpace(v.String())
+		isURIField := schema.Format == "uri" && (value != "" || schema.Required)
+		if isURIField || (schema.Links && isHTTPURL(value)) {
+			checks = append(checks, AuditCheck{Field: path, URL: value})
+		}
+	})
 	return checks
 }
 
@@ -144,13 +409,29 @@ func FormatAuditResult(result AuditResult) string {
 			icon = "SKIP"
 		}
 		b.WriteString(fmt.Sprintf("  [%s] %s: %s", icon, check.Field, check.URL))
+		if check.FinalURL != "" {
+			b.WriteString(fmt.Sprintf(" -> %s (%s redirect)", check.FinalURL, check.Redirect))
+		}
 		if check.Error != "" {
 			b.WriteString(fmt.Sprintf(" (%s)", check.Error))
 		}
 		b.WriteString("\n")
+		for _, finding := range check.Findings {
+			icon := "OK"
+			if finding.Status == "fail" {
+				icon = "FAIL"
+			}
+			b.WriteString(fmt.Sprintf("      [%s] %s: %s\n", icon, finding.Probe, finding.Message))
+		}
 	}
 
 	b.WriteString(fmt.Sprintf("\nSummary: %d passed, %d failed, %d skipped\n",
 		result.PassCount, result.FailCount, result.SkipCount))
+	if result.ProbeFailCount > 0 {
+		b.WriteString(fmt.Sprintf("%d content check(s) failed\n", result.ProbeFailCount))
+	}
+	if result.MovedCount > 0 {
+		b.WriteString(fmt.Sprintf("%d link(s) redirect permanently; update them to the final URL\n", result.MovedCount))
+	}
 	return b.String()
 }
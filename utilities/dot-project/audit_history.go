@@ -0,0 +1,320 @@
+package projects
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AuditRecord is the outcome of one audited field in one run, as stored in
+// the audit history
+type AuditRecord struct {
+	RunAt        time.Time `json:"run_at"`
+	Project      string    `json:"project"`
+	Field        string    `json:"field"`
+	URL          string    `json:"url"`
+	Status       string    `json:"status"` // Link status: "pass", "fail", "skip"
+	Error        string    `json:"error,omitempty"`
+	FailedProbes []string  `json:"failed_probes,omitempty"` // Content probes that failed on a reachable link
+}
+
+// Broken reports whether the link failed or its content did not pass a probe
+func (r AuditRecord) Broken() bool {
+	return r.Status == "fail" || len(r.FailedProbes) > 0
+}
+
+// AuditHistory is an append-only JSON-lines file of audit records. Each run
+// appends one record per audited field, so the history can be read back to
+// follow a link of a project over time.
+type AuditHistory struct {
+	Records []AuditRecord
+	path    string
+}
+
+// LoadAuditHistory reads the audit history at path. A missing file is an
+// empty history that Append creates.
+func LoadAuditHistory(path string) (*AuditHistory, error) {
+	history := &AuditHistory{path: path}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64<<10), 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var record AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid audit record: %w", path, line, err)
+		}
+		history.Records = append(history.Records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// auditRecords turns the results of a run into history records
+func auditRecords(results []AuditResult, runAt time.Time) []AuditRecord {
+	var records []AuditRecord
+	for _, result := range results {
+		for _, check := range result.Checks {
+			record := AuditRecord{
+				RunAt:   runAt,
+				Project: result.ProjectSlug,
+				Field:   check.Field,
+				URL:     check.URL,
+				Status:  check.Status,
+				Error:   check.Error,
+			}
+			for _, finding := range check.Findings {
+				if finding.Status == "fail" {
+					record.FailedProbes = append(record.FailedProbes, finding.Probe)
+				}
+			}
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// Append adds the results of a run to the history file
+func (h *AuditHistory) Append(results []AuditResult, runAt time.Time) error {
+	records := auditRecords(results, runAt)
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(f)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	h.Records = append(h.Records, records...)
+	return nil
+}
+
+// BrokenLink is a link that is broken in the current run
+type BrokenLink struct {
+	Project      string    `json:"project"`
+	Field        string    `json:"field"`
+	URL          string    `json:"url"`
+	Error        string    `json:"error,omitempty"`
+	FailedProbes []string  `json:"failed_probes,omitempty"`
+	BrokenSince  time.Time `json:"broken_since"` // First run of the current streak of failures
+	Days         int       `json:"days"`         // Days between BrokenSince and the current run
+}
+
+// FixedLink is a link that was broken in a project's previous run and
+// passes now
+type FixedLink struct {
+	Project string `json:"project"`
+	Field   string `json:"field"`
+	URL     string `json:"url"`
+}
+
+// ProjectTrend compares a project's broken links with its previous run
+type ProjectTrend struct {
+	Project  string `json:"project"`
+	Previous int    `json:"previous"`
+	Current  int    `json:"current"`
+}
+
+// AuditTrend compares a run with the audit history. Each project is compared
+// with its own previous run, so single-project and project-list runs can
+// share a history. A project's first run is its baseline: its broken links
+// are reported but are not regressions.
+type AuditTrend struct {
+	RunAt       time.Time      `json:"run_at"`
+	Regressions []BrokenLink   `json:"regressions"` // Broken now, not broken in the project's previous run
+	Broken      []BrokenLink   `json:"broken"`      // Still broken since an earlier run
+	Fixed       []FixedLink    `json:"fixed"`       // Broken in the previous run, passing now
+	Worse       []ProjectTrend `json:"worse"`       // Projects with more broken links than in their previous run
+	Baseline    []string       `json:"baseline"`    // Projects audited for the first time
+}
+
+// auditKey identifies a field of a project in the history
+type auditKey struct {
+	project, field string
+}
+
+// Trend compares the results of a run at runAt with the history. Call it
+// before appending the run.
+func (h *AuditHistory) Trend(results []AuditResult, runAt time.Time) AuditTrend {
+	trend := AuditTrend{RunAt: runAt}
+
+	// Group past records per field and find each project's previous run
+	past := make(map[auditKey][]AuditRecord)
+	previousRun := make(map[string]time.Time)
+	for _, record := range h.Records {
+		if !record.RunAt.Before(runAt) {
+			continue
+		}
+		key := auditKey{record.Project, record.Field}
+		past[key] = append(past[key], record)
+		if record.RunAt.After(previousRun[record.Project]) {
+			previousRun[record.Project] = record.RunAt
+		}
+	}
+	for _, records := range past {
+		sort.SliceStable(records, func(i, j int) bool { return records[i].RunAt.Before(records[j].RunAt) })
+	}
+
+	for _, result := range results {
+		slug := result.ProjectSlug
+		previous, seen := previousRun[slug]
+		if !seen {
+			trend.Baseline = append(trend.Baseline, slug)
+		}
+
+		current := auditRecords([]AuditResult{result}, runAt)
+		currentFields := make(map[string]bool)
+		brokenNow, brokenBefore := 0, 0
+		for _, record := range current {
+			currentFields[record.Field] = true
+			records := past[auditKey{slug, record.Field}]
+			last, inPrevious := lastRecordAt(records, previous)
+			if inPrevious && last.Broken() {
+				brokenBefore++
+			}
+
+			if !record.Broken() {
+				if inPrevious && last.Broken() {
+					trend.Fixed = append(trend.Fixed, FixedLink{Project: slug, Field: record.Field, URL: record.URL})
+				}
+				continue
+			}
+
+			brokenNow++
+			since := brokenSince(records, record.URL, runAt)
+			link := BrokenLink{
+				Project:      slug,
+				Field:        record.Field,
+				URL:          record.URL,
+				Error:        record.Error,
+				FailedProbes: record.FailedProbes,
+				BrokenSince:  since,
+				Days:         int(runAt.Sub(since).Hours() / 24),
+			}
+			if seen && !(inPrevious && last.Broken() && last.URL == record.URL) {
+				trend.Regressions = append(trend.Regressions, link)
+			} else {
+				trend.Broken = append(trend.Broken, link)
+			}
+		}
+
+		// Fields that were broken and are gone from the project count as fixed
+		for key, records := range past {
+			if key.project != slug || currentFields[key.field] {
+				continue
+			}
+			if last, ok := lastRecordAt(records, previous); ok && last.Broken() {
+				brokenBefore++
+				trend.Fixed = append(trend.Fixed, FixedLink{Project: slug, Field: key.field, URL: last.URL})
+			}
+		}
+
+		if seen && brokenNow > brokenBefore {
+			trend.Worse = append(trend.Worse, ProjectTrend{Project: slug, Previous: brokenBefore, Current: brokenNow})
+		}
+	}
+
+	sort.Slice(trend.Fixed, func(i, j int) bool {
+		if trend.Fixed[i].Project != trend.Fixed[j].Project {
+			return trend.Fixed[i].Project < trend.Fixed[j].Project
+		}
+		return trend.Fixed[i].Field < trend.Fixed[j].Field
+	})
+	return trend
+}
+
+// lastRecordAt returns the record of a run, if the field was audited in it
+func lastRecordAt(records []AuditRecord, runAt time.Time) (AuditRecord, bool) {
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].RunAt.Equal(runAt) {
+			return records[i], true
+		}
+	}
+	return AuditRecord{}, false
+}
+
+// brokenSince returns the first run of the streak of failures that ends with
+// the current run. The streak starts over when the field's URL changed.
+func brokenSince(records []AuditRecord, url string, runAt time.Time) time.Time {
+	since := runAt
+	for i := len(records) - 1; i >= 0; i-- {
+		if !records[i].Broken() || records[i].URL != url {
+			break
+		}
+		since = records[i].RunAt
+	}
+	return since
+}
+
+// FormatAuditTrend formats an audit trend as human-readable text
+func FormatAuditTrend(trend AuditTrend) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Audit Trend: %s\n", trend.RunAt.Format("2006-01-02 15:04")))
+	b.WriteString(strings.Repeat("=", 40))
+	b.WriteString("\n")
+
+	writeLinks := func(title string, links []BrokenLink, showAge bool) {
+		if len(links) == 0 {
+			return
+		}
+		b.WriteString(fmt.Sprintf("\n%s (%d):\n", title, len(links)))
+		for _, link := range links {
+			b.WriteString(fmt.Sprintf("  %s %s: %s", link.Project, link.Field, link.URL))
+			reason := link.Error
+			if len(link.FailedProbes) > 0 {
+				reason = strings.TrimPrefix(reason+"; failed "+strings.Join(link.FailedProbes, ", "), "; ")
+			}
+			if reason != "" {
+				b.WriteString(fmt.Sprintf(" (%s)", reason))
+			}
+			if showAge {
+				b.WriteString(fmt.Sprintf(", broken since %s (%d days)", link.BrokenSince.Format("2006-01-02"), link.Days))
+			}
+			b.WriteString("\n")
+		}
+	}
+	writeLinks("Newly broken", trend.Regressions, false)
+	writeLinks("Still broken", trend.Broken, true)
+
+	if len(trend.Fixed) > 0 {
+		b.WriteString(fmt.Sprintf("\nFixed since the previous run (%d):\n", len(trend.Fixed)))
+		for _, link := range trend.Fixed {
+			b.WriteString(fmt.Sprintf("  %s %s: %s\n", link.Project, link.Field, link.URL))
+		}
+	}
+	if len(trend.Worse) > 0 {
+		b.WriteString("\nProjects worse than their previous run:\n")
+		for _, p := range trend.Worse {
+			b.WriteString(fmt.Sprintf("  %s: %d -> %d broken\n", p.Project, p.Previous, p.Current))
+		}
+	}
+	if len(trend.Baseline) > 0 {
+		b.WriteString(fmt.Sprintf("\nFirst audit (baseline, no regressions reported): %s\n", strings.Join(trend.Baseline, ", ")))
+	}
+
+	b.WriteString(fmt.Sprintf("\nSummary: %d newly broken, %d still broken, %d fixed\n",
+		len(trend.Regressions), len(trend.Broken), len(trend.Fixed)))
+	return b.String()
+}
@@ -0,0 +1,159 @@
+package projects
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// auditRun builds an audit result from field=status pairs; a status of
+// "probe" is a reachable link whose content probe failed
+func auditRun(slug string, fields ...string) AuditResult {
+	result := AuditResult{ProjectSlug: slug}
+	for _, f := range fields {
+		parts := strings.SplitN(f, "=", 3)
+		check := AuditCheck{Field: parts[0], URL: "https://example.com/" + parts[0], Status: parts[1]}
+		if len(parts) == 3 {
+			check.URL = parts[2]
+		}
+		switch check.Status {
+		case "fail":
+			check.Error = "HTTP 404"
+		case "probe":
+			check.Status = "pass"
+			check.Findings = []AuditFinding{{Probe: "license-spdx", Status: "fail"}}
+		}
+		result.Checks = append(result.Checks, check)
+	}
+	return result
+}
+
+func trendFields(links []BrokenLink) string {
+	var fields []string
+	for _, l := range links {
+		fields = append(fields, l.Project+"/"+l.Field)
+	}
+	return strings.Join(fields, ",")
+}
+
+func TestAuditHistoryRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit-history.jsonl")
+	history, err := LoadAuditHistory(path)
+	if err != nil {
+		t.Fatalf("LoadAuditHistory on a missing file: %v", err)
+	}
+	if len(history.Records) != 0 {
+		t.Fatalf("expected an empty history, got %v", history.Records)
+	}
+
+	day := time.Date(2026, 1, 5, 6, 0, 0, 0, time.UTC)
+	if err := history.Append([]AuditResult{auditRun("alpha", "website=pass", "legal.license.path=probe")}, day); err != nil {
+		t.Fatal(err)
+	}
+	if err := history.Append([]AuditResult{auditRun("alpha", "website=fail")}, day.AddDate(0, 0, 7)); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if lines := strings.Count(string(data), "\n"); lines != 3 {
+		t.Errorf("expected one line per record, got %d:\n%s", lines, data)
+	}
+
+	reloaded, err := LoadAuditHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reloaded.Records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(reloaded.Records))
+	}
+	r := reloaded.Records[1]
+	if r.Project != "alpha" || r.Field != "legal.license.path" || !r.RunAt.Equal(day) || !r.Broken() || r.FailedProbes[0] != "license-spdx" {
+		t.Errorf("unexpected record: %+v", r)
+	}
+	if !reloaded.Records[2].Broken() || reloaded.Records[0].Broken() {
+		t.Errorf("unexpected broken flags: %+v", reloaded.Records)
+	}
+
+	os.WriteFile(path, append(data, []byte("{not json\n")...), 0644)
+	if _, err := LoadAuditHistory(path); err == nil || !strings.Contains(err.Error(), ":4:") {
+		t.Errorf("expected an error naming line 4, got %v", err)
+	}
+}
+
+func TestAuditTrend(t *testing.T) {
+	history := &AuditHistory{path: filepath.Join(t.TempDir(), "history.jsonl")}
+	week1 := time.Date(2026, 1, 5, 6, 0, 0, 0, time.UTC)
+	week2 := week1.AddDate(0, 0, 7)
+	week3 := week2.AddDate(0, 0, 7)
+
+	// First run: everything is a baseline
+	run1 := []AuditResult{auditRun("alpha", "website=fail", "artwork=pass", "social.x=fail", "adopters.path=pass")}
+	trend := history.Trend(run1, week1)
+	if len(trend.Regressions) != 0 || trendFields(trend.Broken) != "alpha/website,alpha/social.x" || strings.Join(trend.Baseline, ",") != "alpha" {
+		t.Errorf("first run: unexpected trend %+v", trend)
+	}
+	history.Append(run1, week1)
+
+	// Second run: artwork breaks, social.x is fixed, website stays broken,
+	// adopters' content fails, and beta is audited for the first time
+	run2 := []AuditResult{
+		auditRun("alpha", "website=fail", "artwork=fail", "social.x=pass", "adopters.path=probe"),
+		auditRun("beta", "website=fail"),
+	}
+	trend = history.Trend(run2, week2)
+	if got := trendFields(trend.Regressions); got != "alpha/artwork,alpha/adopters.path" {
+		t.Errorf("second run regressions = %s", got)
+	}
+	if got := trendFields(trend.Broken); got != "alpha/website,beta/website" {
+		t.Errorf("second run still broken = %s", got)
+	}
+	if len(trend.Fixed) != 1 || trend.Fixed[0].Field != "social.x" {
+		t.Errorf("second run fixed = %+v", trend.Fixed)
+	}
+	if len(trend.Worse) != 1 || trend.Worse[0] != (ProjectTrend{Project: "alpha", Previous: 2, Current: 3}) {
+		t.Errorf("second run worse = %+v", trend.Worse)
+	}
+	if strings.Join(trend.Baseline, ",") != "beta" {
+		t.Errorf("second run baseline = %v", trend.Baseline)
+	}
+	if trend.Broken[0].Days != 7 || !trend.Broken[0].BrokenSince.Equal(week1) {
+		t.Errorf("website should be broken since week 1, got %+v", trend.Broken[0])
+	}
+	history.Append(run2, week2)
+
+	// Third run: website moved to a new URL that is also broken, artwork
+	// disappeared from the project, beta gets a new broken link
+	run3 := []AuditResult{
+		auditRun("alpha", "website=fail=https://new.example.com", "adopters.path=probe"),
+		auditRun("beta", "website=fail", "artwork=fail"),
+	}
+	trend = history.Trend(run3, week3)
+	if got := trendFields(trend.Regressions); got != "alpha/website,beta/artwork" {
+		t.Errorf("third run regressions = %s", got)
+	}
+	if got := trendFields(trend.Broken); got != "alpha/adopters.path,beta/website" {
+		t.Errorf("third run still broken = %s", got)
+	}
+	if trend.Broken[1].Days != 7 || trend.Regressions[0].Days != 0 {
+		t.Errorf("unexpected ages: %+v %+v", trend.Broken[1], trend.Regressions[0])
+	}
+	if len(trend.Fixed) != 1 || trend.Fixed[0].Field != "artwork" {
+		t.Errorf("a removed broken field should count as fixed, got %+v", trend.Fixed)
+	}
+
+	output := FormatAuditTrend(trend)
+	for _, want := range []string{
+		"Newly broken (2):",
+		"alpha website: https://new.example.com (HTTP 404)",
+		"alpha adopters.path: https://example.com/adopters.path (failed license-spdx), broken since 2026-01-12 (7 days)",
+		"Fixed since the previous run (1):",
+		"beta: 1 -> 2 broken",
+		"Summary: 2 newly broken, 2 still broken, 1 fixed",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
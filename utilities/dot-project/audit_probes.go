@@ -0,0 +1,216 @@
+package projects
+
+import (
+	"fmt"
+	"html"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+// governanceMinWords is the length below which a governance document is
+// considered a stub
+const governanceMinWords = 150
+
+// contentProbe checks the document behind an audited link
+type contentProbe struct {
+	ID    string
+	Check func(content string) (bool, string) // Whether the content passed, and why
+}
+
+// contentProbes maps audited field paths to the probe run on their content
+var contentProbes = map[string]contentProbe{
+	"security.policy.path":           {ID: "security-reporting-channel", Check: probeSecurityPolicy},
+	"legal.license.path":             {ID: "license-spdx", Check: probeLicense},
+	"governance.codeowners.path":     {ID: "codeowners-parses", Check: probeCodeowners},
+	"governance.governance_doc.path": {ID: "governance-not-stub", Check: probeGovernanceDoc},
+}
+
+// runContentProbe runs a probe on fetched content
+func runContentProbe(probe contentProbe, content probeContent) AuditFinding {
+	finding := AuditFinding{Probe: probe.ID, Status: "fail"}
+	switch {
+	case content.Err != nil:
+		finding.Message = fmt.Sprintf("could not fetch content: %v", content.Err)
+	case strings.TrimSpace(content.Text) == "":
+		finding.Message = "document is empty"
+	default:
+		ok, message := probe.Check(content.Text)
+		if ok {
+			finding.Status = "pass"
+		}
+		finding.Message = message
+	}
+	return finding
+}
+
+var (
+	probeEmailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+	// securityReportingHints are phrases of vulnerability reporting
+	// instructions that do not involve an email address
+	securityReportingHints = []string{
+		"/security/advisories",
+		"private vulnerability reporting",
+		"report a vulnerability",
+		"security advisory",
+		"hackerone.com",
+		"bugcrowd.com",
+		"huntr.com",
+		"huntr.dev",
+	}
+)
+
+// probeSecurityPolicy passes when a security policy names a reporting
+// channel: an email address, GitHub security advisories or a bug bounty
+func probeSecurityPolicy(content string) (bool, string) {
+	for _, match := range probeEmailPattern.FindAllString(content, -1) {
+		if _, err := mail.ParseAddress(match); err == nil {
+			return true, fmt.Sprintf("reporting channel found (%s)", match)
+		}
+	}
+	lower := strings.ToLower(content)
+	for _, hint := range securityReportingHints {
+		if strings.Contains(lower, hint) {
+			return true, fmt.Sprintf("reporting channel found (%q)", hint)
+		}
+	}
+	return false, "no reporting channel found (email address, security advisory or bug bounty link)"
+}
+
+// spdxLicense identifies a license by phrases that appear in its text. All
+// phrases must match and none of the excluded ones. GNU licenses get the
+// -only or -or-later suffix their SPDX IDs require.
+type spdxLicense struct {
+	ID       string
+	Phrases  []string
+	Excludes []string
+	GNU      bool
+}
+
+// spdxLicenses lists the licenses recognised by text, most specific first
+var spdxLicenses = []spdxLicense{
+	{ID: "Apache-2.0", Phrases: []string{"apache license", "version 2.0"}},
+	{ID: "MIT", Phrases: []string{"permission is hereby granted, free of charge", "the above copyright notice and this permission notice shall be included"}},
+	{ID: "BSD-3-Clause", Phrases: []string{"redistribution and use in source and binary forms", "neither the name"}},
+	{ID: "BSD-2-Clause", Phrases: []string{"redistribution and use in source and binary forms"}, Excludes: []string{"neither the name"}},
+	{ID: "ISC", Phrases: []string{"permission to use, copy, modify, and/or distribute this software for any purpose with or without fee"}},
+	{ID: "MPL-2.0", Phrases: []string{"mozilla public license", "version 2.0"}},
+	{ID: "AGPL-3.0", Phrases: []string{"gnu affero general public license", "version 3"}, GNU: true},
+	{ID: "LGPL-3.0", Phrases: []string{"gnu lesser general public license", "version 3"}, GNU: true},
+	{ID: "LGPL-2.1", Phrases: []string{"gnu lesser general public license", "version 2.1"}, GNU: true},
+	{ID: "GPL-3.0", Phrases: []string{"gnu general public license", "version 3"}, GNU: true},
+	{ID: "GPL-2.0", Phrases: []string{"gnu general public license", "version 2"}, GNU: true},
+	{ID: "CC-BY-4.0", Phrases: []string{"creative commons", "attribution 4.0 international"}},
+}
+
+var spdxIdentifierPattern = regexp.MustCompile(`(?i)SPDX-License-Identifier:\s*([A-Za-z0-9.+-]+(?:\s+(?:OR|AND|WITH)\s+[A-Za-z0-9.+-]+)*)`)
+
+// probeLicense passes when a license file carries an SPDX identifier or its
+// text matches a known SPDX license
+func probeLicense(content string) (bool, string) {
+	if m := spdxIdentifierPattern.FindStringSubmatch(content); m != nil {
+		return true, fmt.Sprintf("SPDX identifier %s", m[1])
+	}
+	text := strings.Join(strings.Fields(strings.ToLower(content)), " ")
+	for _, license := range spdxLicenses {
+		if containsAll(text, license.Phrases) && !containsAny(text, license.Excludes) {
+			return true, fmt.Sprintf("recognised as %s", license.spdxID(text))
+		}
+	}
+	return false, "text does not match a known SPDX license"
+}
+
+// spdxID returns the license's SPDX ID for a matching text. A GNU license is
+// -or-later when the text grants "any later version"; the clause is only
+// looked for before the license's own "how to apply these terms" appendix,
+// which quotes it as an example.
+func (l spdxLicense) spdxID(text string) string {
+	if !l.GNU {
+		return l.ID
+	}
+	if i := strings.Index(text, "how to apply these terms"); i >= 0 {
+		text = text[:i]
+	}
+	if strings.Contains(text, "any later version") {
+		return l.ID + "-or-later"
+	}
+	return l.ID + "-only"
+}
+
+// probeCodeowners passes when a CODEOWNERS file has at least one rule with
+// an owner
+func probeCodeowners(content string) (bool, string) {
+	if handles := parseCodeowners(content); len(handles) > 0 {
+		return true, fmt.Sprintf("%d owner(s)", len(handles))
+	}
+	// parseCodeowners skips teams, so a team-only file needs a second look
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "@") && strings.Contains(owner, "/") {
+				return true, "team owners only"
+			}
+		}
+	}
+	return false, "no ownership rules with @owners found"
+}
+
+// probeGovernanceDoc passes when a governance document is longer than a stub
+func probeGovernanceDoc(content string) (bool, string) {
+	words := len(strings.Fields(content))
+	if words < governanceMinWords {
+		return false, fmt.Sprintf("only %d words; a governance document should have at least %d", words, governanceMinWords)
+	}
+	return true, fmt.Sprintf("%d words", words)
+}
+
+func containsAll(text string, phrases []string) bool {
+	for _, phrase := range phrases {
+		if !strings.Contains(text, phrase) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAny(text string, phrases []string) bool {
+	for _, phrase := range phrases {
+		if strings.Contains(text, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// rawContentURL maps a GitHub blob URL to the raw file, so probes see the
+// document rather than GitHub's page around it. Other URLs are returned as is.
+func rawContentURL(link string) string {
+	const prefix = "https://github.com/"
+	if !strings.HasPrefix(link, prefix) {
+		return link
+	}
+	if i := strings.IndexAny(link, "#?"); i >= 0 {
+		link = link[:i]
+	}
+	parts := strings.SplitN(strings.TrimPrefix(link, prefix), "/", 4)
+	if len(parts) < 4 || parts[2] != "blob" {
+		return link
+	}
+	return "https://raw.githubusercontent.com/" + parts[0] + "/" + parts[1] + "/" + parts[3]
+}
+
+var (
+	htmlSkippedElements = regexp.MustCompile(`(?is)<(script|style|head)\b.*?</(script|style|head)>`)
+	htmlTags            = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// htmlToText reduces an HTML page to its visible text
+func htmlToText(page string) string {
+	text := htmlSkippedElements.ReplaceAllString(page, " ")
+	text = htmlTags.ReplaceAllString(text, " ")
+	return strings.Join(strings.Fields(html.UnescapeString(text)), " ")
+}
@@ -0,0 +1,193 @@
+package projects
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const apacheLicenseText = `
+                                 Apache License
+                           Version 2.0, January 2004
+                        http://www.apache.org/licenses/
+
+   TERMS AND CONDITIONS FOR USE, REPRODUCTION, AND DISTRIBUTION
+`
+
+const mitLicenseText = `MIT License
+
+Copyright (c) 2024 Test
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+`
+
+const bsdLicenseText = `Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+* Neither the name of the copyright holder nor the names of its
+  contributors may be used to endorse or promote products.`
+
+func TestContentProbes(t *testing.T) {
+	tests := []struct {
+		name    string
+		check   func(string) (bool, string)
+		content string
+		pass    bool
+		message string
+	}{
+		{"security email", probeSecurityPolicy, "Please report vulnerabilities to security@test-project.io.", true, "security@test-project.io"},
+		{"security advisories", probeSecurityPolicy, "Use https://github.com/test/repo/security/advisories/new to report.", true, "/security/advisories"},
+		{"security bug bounty", probeSecurityPolicy, "We run a program on https://hackerone.com/test.", true, "hackerone.com"},
+		{"security generic readme", probeSecurityPolicy, "# Test Org\n\nWelcome to our organization! Check out our projects.", false, "no reporting channel"},
+
+		{"license apache", probeLicense, apacheLicenseText, true, "Apache-2.0"},
+		{"license mit", probeLicense, mitLicenseText, true, "MIT"},
+		{"license bsd-3", probeLicense, bsdLicenseText, true, "BSD-3-Clause"},
+		{"license bsd-2", probeLicense, strings.Split(bsdLicenseText, "*")[0], true, "BSD-2-Clause"},
+		{"license lgpl", probeLicense, "GNU LESSER GENERAL PUBLIC LICENSE\nVersion 3, 29 June 2007", true, "LGPL-3.0-only"},
+		{"license gpl or later", probeLicense, "This program is free software: you can redistribute it under the terms of the GNU General Public License as published by the Free Software Foundation, either version 3 of the License, or (at your option) any later version.", true, "GPL-3.0-or-later"},
+		{"license gpl appendix", probeLicense, "GNU GENERAL PUBLIC LICENSE\nVersion 2, June 1991\n...\nHow to Apply These Terms to Your New Programs\n...either version 2 of the License, or (at your option) any later version.", true, "GPL-2.0-only"},
+		{"license mpl", probeLicense, "Mozilla Public License Version 2.0\n==================================", true, "MPL-2.0"},
+		{"license mpl without version", probeLicense, "Portions use the Mozilla Public License. See release 2.0 notes.", false, "does not match"},
+		{"license identifier", probeLicense, "// SPDX-License-Identifier: Apache-2.0 OR MIT\n", true, "Apache-2.0 OR MIT"},
+		{"license unknown", probeLicense, "All rights reserved.", false, "does not match"},
+
+		{"codeowners users", probeCodeowners, "# Owners\n* @alice @bob\n/docs/ @carol\n", true, "3 owner(s)"},
+		{"codeowners teams", probeCodeowners, "* @test-org/maintainers\n", true, "team owners only"},
+		{"codeowners empty rules", probeCodeowners, "# TODO: add owners\n*\n", false, "no ownership rules"},
+
+		{"governance stub", probeGovernanceDoc, "# Governance\n\nTODO", false, "only 3 words"},
+		{"governance full", probeGovernanceDoc, strings.Repeat("maintainers decide by lazy consensus ", 40), true, "200 words"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pass, message := tt.check(tt.content)
+			if pass != tt.pass || !strings.Contains(message, tt.message) {
+				t.Errorf("got (%v, %q), want (%v, containing %q)", pass, message, tt.pass, tt.message)
+			}
+		})
+	}
+}
+
+func TestRawContentURL(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/test/repo/blob/main/SECURITY.md":          "https://raw.githubusercontent.com/test/repo/main/SECURITY.md",
+		"https://github.com/test/repo/blob/v1.0/docs/GOVERNANCE.md#x": "https://raw.githubusercontent.com/test/repo/v1.0/docs/GOVERNANCE.md",
+		"https://github.com/test/repo/tree/main/docs":                 "https://github.com/test/repo/tree/main/docs",
+		"https://test-project.io/security":                            "https://test-project.io/security",
+	}
+	for in, want := range tests {
+		if got := rawContentURL(in); got != want {
+			t.Errorf("rawContentURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	page := `<html><head><title>x</title><style>p{}</style></head><body><script>var a = "b";</script><p>Report to <a href="mailto:sec@test.io">sec@test.io</a> &amp; wait</p></body></html>`
+	if got := htmlToText(page); got != "Report to sec@test.io & wait" {
+		t.Errorf("htmlToText = %q", got)
+	}
+}
+
+func TestAuditorContentProbes(t *testing.T) {
+	var mu sync.Mutex
+	gets := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.Header.Get("Range") == "" {
+			mu.Lock()
+			gets[r.URL.Path]++
+			mu.Unlock()
+		}
+		switch r.URL.Path {
+		case "/SECURITY.md":
+			w.Write([]byte("# Test Org\n\nWelcome to our organization!"))
+		case "/security.html":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte("<html><body><p>Email <b>security@test.io</b></p></body></html>"))
+		case "/LICENSE":
+			w.Write([]byte(apacheLicenseText))
+		case "/CODEOWNERS":
+			w.Write([]byte("* @alice\n"))
+		case "/GOVERNANCE.md":
+			w.Write([]byte("# Governance\n\nTBD"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	project := validBaseProject()
+	project.MaturityLog = nil // Keep the audit off the network
+	project.Repositories = []string{server.URL + "/repo"}
+	project.Security = &SecurityConfig{Policy: &PathRef{Path: server.URL + "/SECURITY.md"}}
+	project.Legal = &LegalConfig{License: &PathRef{Path: server.URL + "/LICENSE"}}
+	project.Governance = &GovernanceConfig{
+		Codeowners:    &PathRef{Path: server.URL + "/CODEOWNERS"},
+		GovernanceDoc: &PathRef{Path: server.URL + "/GOVERNANCE.md"},
+	}
+	other := validBaseProject()
+	other.MaturityLog = nil
+	other.Slug = "other"
+	other.Repositories = nil
+	other.Security = &SecurityConfig{Policy: &PathRef{Path: server.URL + "/security.html"}}
+	other.Legal = &LegalConfig{License: &PathRef{Path: server.URL + "/LICENSE"}}
+
+	t.Run("disabled", func(t *testing.T) {
+		result := NewAuditor(server.Client(), testFetchOptions()).AuditProject(project)
+		for _, check := range result.Checks {
+			if len(check.Findings) > 0 {
+				t.Errorf("expected no content probes by default, got %+v", check)
+			}
+		}
+		if len(gets) != 0 {
+			t.Errorf("expected no documents fetched, got %v", gets)
+		}
+	})
+
+	auditor := NewAuditor(server.Client(), testFetchOptions())
+	auditor.SetContentProbes(true)
+	results := auditor.AuditProjects([]Project{project, other})
+
+	findings := make(map[string]AuditFinding)
+	for _, result := range results {
+		for _, check := range result.Checks {
+			if check.Field == "repositories[0]" && len(check.Findings) > 0 {
+				t.Errorf("fields without a probe should have no findings, got %+v", check.Findings)
+			}
+			for _, f := range check.Findings {
+				findings[result.ProjectSlug+" "+f.Probe] = f
+			}
+		}
+	}
+	want := map[string]string{
+		"test-project security-reporting-channel": "fail",
+		"test-project license-spdx":               "pass",
+		"test-project codeowners-parses":          "pass",
+		"test-project governance-not-stub":        "fail",
+		"other security-reporting-channel":        "pass",
+		"other license-spdx":                      "pass",
+	}
+	for key, status := range want {
+		if findings[key].Status != status {
+			t.Errorf("%s: got %+v, want status %s", key, findings[key], status)
+		}
+	}
+	if results[0].ProbeFailCount != 2 || results[1].ProbeFailCount != 0 {
+		t.Errorf("expected 2 and 0 failed probes, got %d and %d", results[0].ProbeFailCount, results[1].ProbeFailCount)
+	}
+	if gets["/LICENSE"] != 1 {
+		t.Errorf("a document shared by projects should be fetched once, got %d", gets["/LICENSE"])
+	}
+
+	output := FormatAuditResult(results[0])
+	if !strings.Contains(output, "[FAIL] security-reporting-channel: no reporting channel") || !strings.Contains(output, "2 content check(s) failed") {
+		t.Errorf("expected probe findings in output, got:\n%s", output)
+	}
+}
@@ -1,9 +1,11 @@
 package projects
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -40,6 +42,78 @@ func TestCollectProjectURLs(t *testing.T) {
 	}
 }
 
+func TestCollectProjectURLsCoversAllLinkFields(t *testing.T) {
+	link := func(name string) *PathRef { return &PathRef{Path: "https://example.com/" + name} }
+	project := validBaseProject()
+	project.Website = "https://test-project.io"
+	project.Social = map[string]string{"twitter": "https://twitter.com/test", "mastodon": "https://hachyderm.io/@test"}
+	project.MailingLists = []string{"test@lists.cncf.io", "https://lists.cncf.io/g/test"}
+	project.Audits = []Audit{{Type: "security"}}
+	project.Adopters = link("ADOPTERS.md")
+	project.Security = &SecurityConfig{
+		Policy:  link("SECURITY.md"),
+		Contact: &SecurityContact{Email: "security@test.io", AdvisoryURL: "https://github.com/test/repo/security/advisories/new"},
+	}
+	project.Governance = &GovernanceConfig{
+		Contributing:  &PathRef{Path: "CONTRIBUTING.md"},
+		CodeOfConduct: link("CODE_OF_CONDUCT.md"),
+		ChangeProcess: link("change-process"),
+		MaintainerLifecycle: MaintainerLifecycle{
+			OnboardingDoc:    link("onboarding"),
+			MentoringProgram: []string{"https://mentoring.example.com"},
+		},
+	}
+	project.Legal = &LegalConfig{
+		License:      link("LICENSE"),
+		IdentityType: &IdentityType{HasDCO: true, DCOURL: link("DCO")},
+	}
+	project.Documentation = &DocumentationConfig{Support: link("SUPPORT.md"), Architecture: link("arch"), API: link("api")}
+	// Free text is not audited, even when it starts with a URL
+	project.Description = "https://example.com is the project's home"
+	project.Validation = &ValidationConfig{Ignore: []RuleSuppression{{Rule: "slug-format", Reason: "https://github.com/cncf/toc/issues/1"}}}
+
+	var got []string
+	urls := make(map[string]string)
+	for _, c := range collectProjectURLs(project) {
+		got = append(got, c.Field)
+		urls[c.Field] = c.URL
+	}
+	want := []string{
+		"maturity_log[0].issue",
+		"repositories[0]",
+		"social.mastodon",
+		"social.twitter",
+		"website",
+		"mailing_lists[1]",
+		"audits[0].url",
+		"adopters.path",
+		"security.policy.path",
+		"security.contact.advisory_url",
+		"governance.code_of_conduct.path",
+		"governance.change_process.path",
+		"governance.maintainer_lifecycle.onboarding_doc.path",
+		"governance.maintainer_lifecycle.mentoring_program[0]",
+		"legal.license.path",
+		"legal.identity_type.dco_url.path",
+		"documentation.support.path",
+		"documentation.architecture.path",
+		"documentation.api.path",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("collectProjectURLs fields:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if urls["audits[0].url"] != "" {
+		t.Errorf("a missing audit URL should be collected empty and skipped, got %q", urls["audits[0].url"])
+	}
+
+	// Every PathRef holding a URL is audited, whatever field it is in
+	for _, ref := range collectPathRefs(project) {
+		if isHTTPURL(ref.Ref.Path) && urls[ref.Field+".path"] != ref.Ref.Path {
+			t.Errorf("PathRef %s (%s) is not audited", ref.Field, ref.Ref.Path)
+		}
+	}
+}
+
 func TestAuditProject(t *testing.T) {
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -52,6 +126,7 @@ func TestAuditProject(t *testing.T) {
 	defer server.Close()
 
 	project := validBaseProject()
+	project.MaturityLog[0].Issue = server.URL + "/issue"
 	project.Website = server.URL + "/ok"
 	project.Repositories = []string{server.URL + "/repo"}
 	project.Artwork = server.URL + "/not-found"
@@ -87,3 +162,207 @@ func TestFormatAuditResult(t *testing.T) {
 		t.Error("expected summary counts in output")
 	}
 }
+
+func TestAuditorHeadFallback(t *testing.T) {
+	var mu sync.Mutex
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			switch r.URL.Path {
+			case "/no-head":
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			case "/forbidden-head", "/forbidden":
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		mu.Lock()
+		ranges = append(ranges, r.Header.Get("Range"))
+		mu.Unlock()
+		if r.URL.Path == "/forbidden" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusPartialContent)
+	}))
+	defer server.Close()
+
+	project := validBaseProject()
+	project.MaturityLog = nil // Keep the audit off the network
+	project.Website = server.URL + "/no-head"
+	project.Artwork = server.URL + "/forbidden-head"
+	project.Repositories = []string{server.URL + "/ok", server.URL + "/forbidden"}
+
+	result := NewAuditor(server.Client(), testFetchOptions()).AuditProject(project)
+	want := map[string]string{
+		"website":         "pass GET 206",
+		"artwork":         "pass GET 206",
+		"repositories[0]": "pass HEAD 200",
+		"repositories[1]": "fail GET 403",
+	}
+	for _, check := range result.Checks {
+		got := fmt.Sprintf("%s %s %d", check.Status, check.Method, check.StatusCode)
+		if w, ok := want[check.Field]; ok && got != w {
+			t.Errorf("%s: got %q, want %q", check.Field, got, w)
+		}
+	}
+	if result.PassCount != 3 || result.FailCount != 1 {
+		t.Errorf("expected 3 passed and 1 failed, got %+v", result)
+	}
+	for _, r := range ranges {
+		if r != "bytes=0-0" {
+			t.Errorf("fallback GET should ask for the first byte only, got Range %q", r)
+		}
+	}
+}
+
+func TestAuditorRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/moved":
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		case "/temporary":
+			http.Redirect(w, r, "/new", http.StatusFound)
+		case "/chain":
+			http.Redirect(w, r, "/temporary", http.StatusPermanentRedirect)
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		case "/nowhere":
+			w.WriteHeader(http.StatusFound) // No Location header
+		case "/new":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	project := validBaseProject()
+	project.MaturityLog = nil // Keep the audit off the network
+	project.Website = server.URL + "/moved"
+	project.Artwork = server.URL + "/temporary"
+	project.Repositories = []string{server.URL + "/chain", server.URL + "/loop", server.URL + "/nowhere"}
+
+	result := NewAuditor(server.Client(), testFetchOptions()).AuditProject(project)
+	checks := make(map[string]AuditCheck)
+	for _, check := range result.Checks {
+		checks[check.Field] = check
+	}
+
+	if c := checks["website"]; c.Status != "pass" || c.Redirect != "permanent" || c.FinalURL != server.URL+"/new" || len(c.Redirects) != 1 {
+		t.Errorf("unexpected permanent redirect check: %+v", c)
+	}
+	if c := checks["artwork"]; c.Status != "pass" || c.Redirect != "temporary" || c.FinalURL != server.URL+"/new" {
+		t.Errorf("unexpected temporary redirect check: %+v", c)
+	}
+	// A chain is only permanent when every hop is
+	c := checks["repositories[0]"]
+	if c.Redirect != "temporary" || len(c.Redirects) != 2 ||
+		c.Redirects[0].StatusCode != http.StatusPermanentRedirect || c.Redirects[0].Location != server.URL+"/temporary" ||
+		c.Redirects[1].StatusCode != http.StatusFound || c.Redirects[1].Location != server.URL+"/new" {
+		t.Errorf("unexpected redirect chain: %+v", c)
+	}
+	if c := checks["repositories[1]"]; c.Status != "fail" || !strings.Contains(c.Error, "redirects") {
+		t.Errorf("expected a redirect loop to fail, got %+v", c)
+	}
+	if c := checks["repositories[2]"]; c.Status != "fail" || !strings.Contains(c.Error, "without a Location header") {
+		t.Errorf("expected a redirect without a location to fail, got %+v", c)
+	}
+	if result.MovedCount != 1 {
+		t.Errorf("expected 1 permanently redirected link, got %d", result.MovedCount)
+	}
+
+	output := FormatAuditResult(result)
+	if !strings.Contains(output, "-> "+server.URL+"/new (permanent redirect)") || !strings.Contains(output, "1 link(s) redirect permanently") {
+		t.Errorf("expected redirects in output, got:\n%s", output)
+	}
+}
+
+func TestAuditorRetries(t *testing.T) {
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		n := requests[r.URL.Path]
+		mu.Unlock()
+		switch {
+		case r.URL.Path == "/flaky" && n <= 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.URL.Path == "/limited" && n == 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case r.URL.Path == "/down":
+			w.WriteHeader(http.StatusBadGateway)
+		case r.URL.Path == "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	project := validBaseProject()
+	project.MaturityLog = nil // Keep the audit off the network
+	project.Website = server.URL + "/flaky"
+	project.Artwork = server.URL + "/limited"
+	project.Repositories = []string{server.URL + "/down", server.URL + "/missing"}
+
+	result := NewAuditor(server.Client(), testFetchOptions()).AuditProject(project)
+	want := map[string]string{
+		"website":         "pass 3",
+		"artwork":         "pass 2",
+		"repositories[0]": "fail 4", // MaxRetries 3
+		"repositories[1]": "fail 1", // 404 is not retried
+	}
+	for _, check := range result.Checks {
+		got := fmt.Sprintf("%s %d", check.Status, check.Attempts)
+		if got != want[check.Field] {
+			t.Errorf("%s: got %q, want %q (%+v)", check.Field, got, want[check.Field], check)
+		}
+	}
+}
+
+func TestAuditorDeduplicatesLinks(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	first := validBaseProject()
+	first.MaturityLog = nil
+	first.Slug = "first"
+	first.Website = server.URL + "/shared"
+	first.Repositories = []string{server.URL + "/first"}
+	second := validBaseProject()
+	second.MaturityLog = nil
+	second.Slug = "second"
+	second.Website = server.URL + "/shared"
+	second.Repositories = []string{server.URL + "/second"}
+
+	auditor := NewAuditor(server.Client(), testFetchOptions())
+	results := auditor.AuditProjects([]Project{first, second})
+	if len(results) != 2 || results[0].ProjectSlug != "first" || results[1].ProjectSlug != "second" {
+		t.Fatalf("results should keep project order, got %+v", results)
+	}
+	if results[0].PassCount != 2 || results[1].PassCount != 2 {
+		t.Errorf("expected every link to pass, got %+v", results)
+	}
+	if requests != 3 {
+		t.Errorf("expected the shared link to be requested once (3 requests), got %d", requests)
+	}
+
+	// Links already checked by this auditor are not requested again
+	auditor.AuditProject(first)
+	if requests != 3 {
+		t.Errorf("expected no new requests, got %d", requests)
+	}
+}
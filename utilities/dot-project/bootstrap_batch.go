@@ -0,0 +1,617 @@
+package projects
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BootstrapOptions selects and locates the data sources of a bootstrap
+type BootstrapOptions struct {
+	SkipLandscape  bool
+	SkipCLOMonitor bool
+	SkipGitHub     bool
+	LandscapeURL   string            // Overrides the landscape.yml URL (use "" for default)
+	CLOMonitorURL  string            // Overrides the CLOMonitor API URL (use "" for default)
+	GitHubAPIURL   string            // Overrides the GitHub API URL (use "" for default)
+	Forge          string            // Forge hosting the repositories: github (default), gitlab or gitea
+	ForgeURL       string            // GitLab or Gitea instance URL (use "" for gitlab.com or codeberg.org)
+	Extra          []BootstrapSource // Looked up by repository after the merge
+}
+
+// BootstrapSlug derives a project slug from its name: lowercase, with runs
+// of spaces and hyphens turned into single hyphens and other characters
+// dropped
+func BootstrapSlug(name string) string {
+	slug := strings.ToLower(strings.ReplaceAll(name, " ", "-"))
+	slug = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return -1
+	}, slug)
+	for strings.Contains(slug, "--") {
+		slug = strings.ReplaceAll(slug, "--", "-")
+	}
+	return strings.Trim(slug, "-")
+}
+
+// BootstrapProject fetches a project from the landscape, CLOMonitor and its
+// forge (GitHub unless opts.Forge is set) and merges what was found, then
+// adds what the extra sources find by repository. Sources that fail are
+// reported in the returned errors and left out of the merge. logf receives
+// progress messages. The result is nil only when opts.Forge names no known
+// forge.
+func BootstrapProject(cfg BootstrapConfig, client *http.Client, opts BootstrapOptions, logf func(format string, args ...interface{})) (*BootstrapResult, []error) {
+	forgeURL := opts.ForgeURL
+	if opts.Forge == "" || opts.Forge == "github" {
+		forgeURL = opts.GitHubAPIURL
+	}
+	forge, err := NewForge(opts.Forge, client, forgeURL, cfg.GitHubToken)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	var errs []error
+	name := cfg.ProjectName
+	if name == "" {
+		name = cfg.GitHubOrg
+	}
+	org, repo := cfg.GitHubOrg, cfg.GitHubRepo
+	if repo == "" && org != "" {
+		repo = org // Common pattern: org name == primary repo name
+	}
+	slug := BootstrapSlug(name)
+
+	var landscapeData *LandscapeData
+	if !opts.SkipLandscape {
+		logf("Fetching from CNCF landscape...")
+		var err error
+		landscapeData, err = FetchFromLandscape(name, client, opts.LandscapeURL)
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("landscape: %w", err))
+		case landscapeData != nil:
+			logf("Found in landscape: %s (maturity: %s, category: %s / %s)",
+				landscapeData.Name, landscapeData.Maturity, landscapeData.Category, landscapeData.Subcategory)
+		default:
+			logf("Not found in landscape")
+		}
+	}
+
+	var cloProject *CLOMonitorProject
+	if !opts.SkipCLOMonitor {
+		logf("Fetching from CLOMonitor...")
+		var err error
+		cloProject, err = FetchFromCLOMonitor(name, client, opts.CLOMonitorURL)
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("CLOMonitor: %w", err))
+		case cloProject != nil:
+			var score float64
+			if cloProject.Score != nil {
+				score = cloProject.Score.Global
+			}
+			logf("Found on CLOMonitor: %s (maturity: %s, score: %.0f)",
+				cloProject.DisplayName, cloProject.Maturity, score)
+		default:
+			logf("Not found on CLOMonitor")
+		}
+	}
+
+	var ghData *GitHubData
+	if !opts.SkipGitHub && org != "" {
+		logf("Fetching from %s: %s/%s...", forge.Title(), org, repo)
+		var err error
+		ghData, err = fetchFromForge(forge, org, repo)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", forge.Title(), err))
+			ghData = nil
+		} else {
+			logf("Found on %s: %s", forge.Title(), ghData.Repo.FullName)
+			if len(ghData.Maintainers) > 0 {
+				logf("Discovered %d maintainer(s) from governance files", len(ghData.Maintainers))
+			}
+		}
+	}
+
+	// Search for the TOC/sandbox onboarding issue if the landscape has no URL.
+	// The TOC is on GitHub, so this needs a GitHub token.
+	var tocURL string
+	if !opts.SkipGitHub && forge.Name() == "github" && cfg.GitHubToken != "" && (landscapeData == nil || landscapeData.AnnualReviewURL == "") {
+		logf("Searching for TOC/sandbox onboarding issue...")
+		var err error
+		tocURL, err = SearchTOCIssues(name, org, cfg.GitHubToken, client, opts.GitHubAPIURL)
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("TOC issue search: %w", err))
+		case tocURL != "":
+			logf("Found TOC/onboarding issue: %s", tocURL)
+		default:
+			logf("No TOC/onboarding issue found")
+		}
+	}
+
+	logf("Merging data sources...")
+	result := MergeBootstrapData(slug, landscapeData, cloProject, ghData)
+
+	// Apply TOC issue URL from search if not already set by landscape
+	if result.TOCIssueURL == "" && tocURL != "" {
+		result.TOCIssueURL = tocURL
+		result.Sources["toc_issue_url"] = "github_search"
+		removeTODO(result, "Add maturity_log entry with TOC issue URL")
+	}
+
+	if forge.Name() != "github" {
+		result.Forge = forge.Name()
+		result.ForgeURL = opts.ForgeURL
+	}
+
+	// Ensure org/repo are set even if the forge fetch was skipped
+	if result.GitHubOrg == "" && org != "" {
+		result.GitHubOrg = org
+	}
+	if result.GitHubRepo == "" && repo != "" {
+		result.GitHubRepo = repo
+	}
+
+	// Extra sources are independent lookups, so they run at once; their
+	// findings are applied in the order the sources were given
+	if len(opts.Extra) > 0 {
+		target := bootstrapTarget(result)
+		findings := make([]*SourceFindings, len(opts.Extra))
+		lookupErrs := make([]error, len(opts.Extra))
+		forEachConcurrently(len(opts.Extra), len(opts.Extra), func(i int) {
+			findings[i], lookupErrs[i] = opts.Extra[i].Lookup(target, client)
+		})
+		for i, source := range opts.Extra {
+			switch {
+			case lookupErrs[i] != nil:
+				errs = append(errs, fmt.Errorf("%s: %w", source.Name(), lookupErrs[i]))
+			case findings[i] != nil:
+				applySourceFindings(result, source.Name(), findings[i])
+				logf("Found on %s: %s", source.Name(), findings[i])
+			}
+		}
+		if len(result.PackageManagers) > 0 {
+			removeTODO(result, "Add package_managers if distributed via registries")
+		}
+	}
+	return result, errs
+}
+
+// BootstrapHTTPCache is an http.RoundTripper shared by the workers of a batch
+// bootstrap. Successful and 404 GET responses are kept for the run, so the
+// landscape.yml and the CLOMonitor project list are fetched once, and
+// concurrent requests for the same URL wait for a single fetch. It also
+// notices when GitHub starts rate limiting.
+type BootstrapHTTPCache struct {
+	base        http.RoundTripper
+	mu          sync.Mutex
+	entries     map[string]*cachedHTTPResponse
+	hits        int
+	misses      int
+	rateLimited bool
+}
+
+// cachedHTTPResponse is a fetched (or in-flight) response
+type cachedHTTPResponse struct {
+	done   chan struct{}
+	status int
+	header http.Header
+	body   []byte
+	err    error
+}
+
+// NewBootstrapHTTPCache wraps a transport (nil for http.DefaultTransport)
+func NewBootstrapHTTPCache(base http.RoundTripper) *BootstrapHTTPCache {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &BootstrapHTTPCache{base: base, entries: make(map[string]*cachedHTTPResponse)}
+}
+
+// RoundTrip implements http.RoundTripper
+func (c *BootstrapHTTPCache) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return c.base.RoundTrip(req)
+	}
+	key := req.Header.Get("Accept") + " " + req.URL.String()
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok {
+		c.hits++
+		c.mu.Unlock()
+		<-entry.done
+	} else {
+		c.misses++
+		entry = &cachedHTTPResponse{done: make(chan struct{})}
+		c.entries[key] = entry
+		c.mu.Unlock()
+		c.fetch(req, key, entry)
+	}
+
+	if entry.err != nil {
+		return nil, entry.err
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", entry.status, http.StatusText(entry.status)),
+		StatusCode:    entry.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        entry.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(entry.body)),
+		ContentLength: int64(len(entry.body)),
+		Request:       req,
+	}, nil
+}
+
+// fetch fills an entry; responses that may change on retry are dropped from
+// the cache once the callers waiting for them are served
+func (c *BootstrapHTTPCache) fetch(req *http.Request, key string, entry *cachedHTTPResponse) {
+	defer close(entry.done)
+	resp, err := c.base.RoundTrip(req)
+	if err == nil {
+		entry.status, entry.header = resp.StatusCode, resp.Header
+		entry.body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+	entry.err = err
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err == nil && isRateLimited(resp) {
+		c.rateLimited = true
+	}
+	if err != nil || (entry.status != http.StatusOK && entry.status != http.StatusNotFound) {
+		delete(c.entries, key)
+	}
+}
+
+// isRateLimited reports whether a response is a GitHub rate limit
+func isRateLimited(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0")
+}
+
+// RateLimited reports whether any response so far was a rate limit
+func (c *BootstrapHTTPCache) RateLimited() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rateLimited
+}
+
+// Stats returns how many requests were served from the cache and fetched
+func (c *BootstrapHTTPCache) Stats() (hits, misses int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+// BatchProject is a project to bootstrap in a batch
+type BatchProject struct {
+	Org  string `json:"org" yaml:"org"`
+	Name string `json:"name" yaml:"name"`
+	Repo string `json:"repo,omitempty" yaml:"repo,omitempty"`
+}
+
+// Slug returns the slug the project is bootstrapped under
+func (p BatchProject) Slug() string {
+	if p.Name != "" {
+		return BootstrapSlug(p.Name)
+	}
+	return BootstrapSlug(p.Org)
+}
+
+// LoadBatchFile reads a batch file: one "org|name|repo" line per project, as
+// used by scripts/provision.sh. name and repo are optional; blank lines and
+// "#" comments are ignored.
+func LoadBatchFile(path string) ([]BatchProject, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch file: %w", err)
+	}
+	defer f.Close()
+
+	var batch []BatchProject
+	seen := make(map[string]int)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, "|")
+		for len(fields) < 3 {
+			fields = append(fields, "")
+		}
+		project := BatchProject{Org: strings.TrimSpace(fields[0]), Name: strings.TrimSpace(fields[1]), Repo: strings.TrimSpace(fields[2])}
+		if project.Org == "" && project.Name == "" {
+			return nil, fmt.Errorf("%s:%d: org or name is required", path, line)
+		}
+		slug := project.Slug()
+		if first, ok := seen[slug]; ok {
+			return nil, fmt.Errorf("%s:%d: project %s is already listed on line %d", path, line, slug, first)
+		}
+		seen[slug] = line
+		batch = append(batch, project)
+	}
+	return batch, scanner.Err()
+}
+
+// BatchOptions controls a batch bootstrap
+type BatchOptions struct {
+	OutputDir    string // Each project is written to OutputDir/<slug>
+	ManifestPath string // Progress manifest (default: OutputDir/bootstrap-progress.json)
+	Concurrency  int    // Projects bootstrapped at once
+	GitHubToken  string
+	Sources      BootstrapOptions
+	AllowPartial bool // Write scaffolds even when a source failed
+}
+
+// Batch progress statuses
+const (
+	BatchPending = "pending"
+	BatchDone    = "done"
+	BatchFailed  = "failed"
+)
+
+// BatchProgress is the state of one project in a batch manifest
+type BatchProgress struct {
+	BatchProject `yaml:",inline"`
+	Slug         string   `json:"slug" yaml:"slug"`
+	Status       string   `json:"status" yaml:"status"` // "pending", "done" or "failed"
+	Dir          string   `json:"dir,omitempty" yaml:"dir,omitempty"`
+	Errors       []string `json:"errors,omitempty" yaml:"errors,omitempty"`
+	TODOs        []string `json:"todos,omitempty" yaml:"todos,omitempty"`
+	// Conflicts and LowConfidence are the fields the sources disagreed on and
+	// the sources matched by name with a low score
+	Conflicts     []FieldConflict `json:"conflicts,omitempty" yaml:"conflicts,omitempty"`
+	LowConfidence []SourceMatch   `json:"low_confidence,omitempty" yaml:"low_confidence,omitempty"`
+	Attempts      int             `json:"attempts" yaml:"attempts"`
+	UpdatedAt     time.Time       `json:"updated_at" yaml:"updated_at"`
+}
+
+// BatchManifest records the progress of a batch bootstrap, so an interrupted,
+// failed or rate-limited run can be resumed: projects that are done are
+// skipped, the others are retried.
+type BatchManifest struct {
+	Projects []*BatchProgress `json:"projects" yaml:"projects"`
+	path     string
+	mu       sync.Mutex
+}
+
+// LoadBatchManifest reads a manifest; a missing file is an empty manifest
+func LoadBatchManifest(path string) (*BatchManifest, error) {
+	m := &BatchManifest{path: path}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// progress returns the entry of a project, adding a pending one if needed
+func (m *BatchManifest) progress(project BatchProject) *BatchProgress {
+	slug := project.Slug()
+	for _, p := range m.Projects {
+		if p.Slug == slug {
+			p.BatchProject = project
+			return p
+		}
+	}
+	p := &BatchProgress{BatchProject: project, Slug: slug, Status: BatchPending}
+	m.Projects = append(m.Projects, p)
+	return p
+}
+
+// update replaces a project's entry. Entries are only written through update
+// while workers run, under the lock save marshals the manifest with.
+func (m *BatchManifest) update(p *BatchProgress, updated BatchProgress) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	*p = updated
+}
+
+// save writes the manifest through a temporary file, so an interrupted run
+// never leaves it half written
+func (m *BatchManifest) save() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := m.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, m.path)
+}
+
+// Count returns how many projects have a status
+func (m *BatchManifest) Count(status string) int {
+	n := 0
+	for _, p := range m.Projects {
+		if p.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// RunBootstrapBatch bootstraps a list of projects with bounded concurrency,
+// sharing one HTTP cache. Each scaffold is written to its own directory, and
+// the manifest is saved after every project. Projects already done in the
+// manifest are skipped. Once GitHub rate limits the run, projects not yet
+// started are left pending for the next run.
+func RunBootstrapBatch(batch []BatchProject, opts BatchOptions, logf func(format string, args ...interface{})) (*BatchManifest, error) {
+	if _, err := NewForge(opts.Sources.Forge, nil, opts.Sources.ForgeURL, ""); err != nil {
+		return nil, err
+	}
+	if opts.ManifestPath == "" {
+		opts.ManifestPath = filepath.Join(opts.OutputDir, "bootstrap-progress.json")
+	}
+	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+		return nil, err
+	}
+	manifest, err := LoadBatchManifest(opts.ManifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var queue []*BatchProgress
+	for _, project := range batch {
+		p := manifest.progress(project)
+		if p.Status == BatchDone {
+			logf("%s: already done, skipping", p.Slug)
+			continue
+		}
+		queue = append(queue, p)
+	}
+	if err := manifest.save(); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	cache := NewBootstrapHTTPCache(nil)
+	client := &http.Client{Timeout: 60 * time.Second, Transport: cache}
+	var saveErr error
+	var saveOnce sync.Once
+	forEachConcurrently(len(queue), opts.Concurrency, func(i int) {
+		p := queue[i]
+		if cache.RateLimited() {
+			logf("%s: not started, rate limited", p.Slug)
+			return
+		}
+		manifest.update(p, bootstrapBatchProject(*p, client, cache, opts, logf))
+		if err := manifest.save(); err != nil {
+			saveOnce.Do(func() { saveErr = err })
+		}
+	})
+	if saveErr != nil {
+		return manifest, fmt.Errorf("failed to write manifest: %w", saveErr)
+	}
+
+	hits, misses := cache.Stats()
+	logf("HTTP cache: %d requests served from cache, %d fetched", hits, misses)
+	if cache.RateLimited() {
+		logf("GitHub rate limit reached; run again later to resume")
+	}
+	return manifest, nil
+}
+
+// bootstrapBatchProject bootstraps one project and returns its progress with
+// the outcome recorded. It works on a copy, since other workers save the
+// manifest meanwhile. The scaffold is written to a temporary directory that
+// is renamed into place, so a project directory is either complete or
+// absent. Some lookups treat a rate limit as "not found", so a project that
+// ran into one is retried.
+func bootstrapBatchProject(p BatchProgress, client *http.Client, cache *BootstrapHTTPCache, opts BatchOptions, logf func(format string, args ...interface{})) BatchProgress {
+	prefixed := func(format string, args ...interface{}) {
+		logf(p.Slug+": "+format, args...)
+	}
+	cfg := BootstrapConfig{ProjectName: p.Name, GitHubOrg: p.Org, GitHubRepo: p.Repo, GitHubToken: opts.GitHubToken}
+	result, errs := BootstrapProject(cfg, client, opts.Sources, prefixed)
+
+	p.Attempts++
+	p.UpdatedAt = time.Now().UTC()
+	p.Errors = nil
+	for _, err := range errs {
+		p.Errors = append(p.Errors, err.Error())
+	}
+	p.TODOs = result.TODOs
+	p.Conflicts = result.Conflicts
+	p.LowConfidence = result.LowConfidenceMatches()
+	if cache.RateLimited() {
+		p.Status = BatchFailed
+		p.Errors = append(p.Errors, "rate limited; run again later to retry")
+		prefixed("failed: rate limited")
+		return p
+	}
+	if len(errs) > 0 && !opts.AllowPartial {
+		p.Status = BatchFailed
+		prefixed("failed: %s", strings.Join(p.Errors, "; "))
+		return p
+	}
+
+	dir := filepath.Join(opts.OutputDir, p.Slug)
+	if _, err := os.Stat(dir); err == nil {
+		p.Status = BatchFailed
+		p.Errors = append(p.Errors, fmt.Sprintf("%s already exists; remove it to bootstrap the project again", dir))
+		prefixed("failed: %s already exists", dir)
+		return p
+	}
+	tmp, err := os.MkdirTemp(opts.OutputDir, "."+p.Slug+"-")
+	if err == nil {
+		if err = WriteScaffold(tmp, result); err == nil {
+			err = os.Rename(tmp, dir)
+		}
+		if err != nil {
+			os.RemoveAll(tmp)
+		}
+	}
+	if err != nil {
+		p.Status = BatchFailed
+		p.Errors = append(p.Errors, err.Error())
+		prefixed("failed: %v", err)
+		return p
+	}
+	p.Status = BatchDone
+	p.Dir = dir
+	prefixed("written to %s (%d TODOs, %d to review)", dir, len(p.TODOs), p.reviewCount())
+	return p
+}
+
+// reviewCount is the number of conflicts and low-confidence matches to review
+func (p *BatchProgress) reviewCount() int {
+	return len(p.Conflicts) + len(p.LowConfidence)
+}
+
+// FormatBatchSummary lists every project of a batch with its status, TODO
+// count and review count, most TODOs first
+func FormatBatchSummary(m *BatchManifest) string {
+	progress := append([]*BatchProgress(nil), m.Projects...)
+	sort.SliceStable(progress, func(i, j int) bool {
+		if progress[i].Status != progress[j].Status {
+			return progress[i].Status < progress[j].Status
+		}
+		return len(progress[i].TODOs) > len(progress[j].TODOs)
+	})
+
+	var b strings.Builder
+	b.WriteString("Bootstrap Batch Summary\n")
+	b.WriteString("=======================\n\n")
+	b.WriteString(fmt.Sprintf("%-32s %-8s %5s %6s  %s\n", "PROJECT", "STATUS", "TODOS", "REVIEW", "DETAILS"))
+	todos, reviews := 0, 0
+	for _, p := range progress {
+		details := p.Dir
+		if p.Status != BatchDone {
+			details = strings.Join(p.Errors, "; ")
+		}
+		b.WriteString(fmt.Sprintf("%-32s %-8s %5d %6d  %s\n", p.Slug, p.Status, len(p.TODOs), p.reviewCount(), details))
+		if p.Status == BatchDone {
+			todos += len(p.TODOs)
+			reviews += p.reviewCount()
+		}
+	}
+	b.WriteString(fmt.Sprintf("\nSummary: %d projects, %d done, %d failed, %d pending, %d TODOs and %d conflicts or low-confidence matches in written scaffolds\n",
+		len(m.Projects), m.Count(BatchDone), m.Count(BatchFailed), m.Count(BatchPending), todos, reviews))
+	return b.String()
+}
@@ -0,0 +1,273 @@
+package projects
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeBootstrapSources serves a landscape, CLOMonitor and GitHub for batch
+// bootstraps, counting requests by path
+type fakeBootstrapSources struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests map[string]int
+	broken   map[string]int // Repo paths failing with the given status
+}
+
+func newFakeBootstrapSources(t *testing.T) *fakeBootstrapSources {
+	f := &fakeBootstrapSources{requests: make(map[string]int), broken: make(map[string]int)}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.requests[r.URL.Path]++
+		status := f.broken[r.URL.Path]
+		f.mu.Unlock()
+
+		switch {
+		case status == http.StatusForbidden:
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(status)
+		case status != 0:
+			w.WriteHeader(status)
+		case r.URL.Path == "/landscape.yml":
+			w.Write([]byte(`landscape:
+  - category:
+    name: Provisioning
+    subcategories:
+      - subcategory:
+        name: Security & Compliance
+        items:
+          - item:
+            name: Alpha
+            homepage_url: https://alpha.io
+            repo_url: https://github.com/alpha/alpha
+            project: sandbox
+`))
+		case r.URL.Path == "/api/projects/search":
+			json.NewEncoder(w).Encode([]CLOMonitorProject{{Name: "beta", DisplayName: "Beta", Foundation: "cncf", Maturity: "incubating"}})
+		case strings.Count(r.URL.Path, "/") == 3 && strings.HasPrefix(r.URL.Path, "/repos/"):
+			parts := strings.Split(r.URL.Path, "/")
+			json.NewEncoder(w).Encode(GitHubRepoData{Name: parts[3], FullName: parts[2] + "/" + parts[3], Description: "The " + parts[3] + " project"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeBootstrapSources) count(path string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests[path]
+}
+
+func (f *fakeBootstrapSources) options(dir string) BatchOptions {
+	return BatchOptions{
+		OutputDir:   dir,
+		Concurrency: 3,
+		Sources: BootstrapOptions{
+			LandscapeURL:  f.URL + "/landscape.yml",
+			CLOMonitorURL: f.URL,
+			GitHubAPIURL:  f.URL,
+		},
+	}
+}
+
+func TestLoadBatchFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "batch.txt")
+	writeFile(t, path, "# org|name|repo\n\nalpha|Alpha|\nbeta-org|Beta|beta\ngamma\n")
+	batch, err := LoadBatchFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []BatchProject{{Org: "alpha", Name: "Alpha"}, {Org: "beta-org", Name: "Beta", Repo: "beta"}, {Org: "gamma"}}
+	if len(batch) != len(want) {
+		t.Fatalf("got %+v, want %+v", batch, want)
+	}
+	for i := range want {
+		if batch[i] != want[i] {
+			t.Errorf("line %d: got %+v, want %+v", i, batch[i], want[i])
+		}
+	}
+	if batch[2].Slug() != "gamma" {
+		t.Errorf("expected a slug from the org, got %q", batch[2].Slug())
+	}
+
+	writeFile(t, path, "alpha|Alpha\nother|alpha\n")
+	if _, err := LoadBatchFile(path); err == nil || !strings.Contains(err.Error(), "already listed on line 1") {
+		t.Errorf("expected a duplicate project error, got %v", err)
+	}
+}
+
+func TestRunBootstrapBatch(t *testing.T) {
+	sources := newFakeBootstrapSources(t)
+	sources.broken["/repos/gamma/gamma"] = http.StatusInternalServerError
+	dir := t.TempDir()
+	batch := []BatchProject{{Org: "alpha", Name: "Alpha"}, {Org: "beta", Name: "Beta"}, {Org: "gamma", Name: "Gamma"}}
+
+	manifest, err := RunBootstrapBatch(batch, sources.options(dir), t.Logf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Count(BatchDone) != 2 || manifest.Count(BatchFailed) != 1 {
+		t.Fatalf("expected 2 done and 1 failed, got %s", FormatBatchSummary(manifest))
+	}
+	// The landscape and CLOMonitor lists are shared by every project
+	if sources.count("/landscape.yml") != 1 || sources.count("/api/projects/search") != 1 {
+		t.Errorf("expected shared fetches, got %d landscape and %d CLOMonitor requests",
+			sources.count("/landscape.yml"), sources.count("/api/projects/search"))
+	}
+	for _, slug := range []string{"alpha", "beta"} {
+		if _, err := os.Stat(filepath.Join(dir, slug, "project.yaml")); err != nil {
+			t.Errorf("expected a scaffold for %s: %v", slug, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "gamma")); !os.IsNotExist(err) {
+		t.Errorf("a failed project should not be written, got %v", err)
+	}
+	summary := FormatBatchSummary(manifest)
+	for _, want := range []string{"gamma", "GitHub: GitHub API returned HTTP 500", "2 done, 1 failed"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("expected %q in summary:\n%s", want, summary)
+		}
+	}
+
+	// Resuming retries only the failed project
+	delete(sources.broken, "/repos/gamma/gamma")
+	manifest, err = RunBootstrapBatch(batch, sources.options(dir), t.Logf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Count(BatchDone) != 3 {
+		t.Fatalf("expected every project done, got %s", FormatBatchSummary(manifest))
+	}
+	if sources.count("/repos/alpha/alpha") != 1 || sources.count("/repos/gamma/gamma") != 2 {
+		t.Errorf("expected only gamma to be fetched again, got alpha %d and gamma %d",
+			sources.count("/repos/alpha/alpha"), sources.count("/repos/gamma/gamma"))
+	}
+	for _, p := range manifest.Projects {
+		if p.Slug == "gamma" && (p.Attempts != 2 || len(p.Errors) != 0 || len(p.TODOs) == 0) {
+			t.Errorf("unexpected gamma progress %+v", p)
+		}
+	}
+
+	// The manifest is kept on disk between runs
+	saved, err := LoadBatchManifest(filepath.Join(dir, "bootstrap-progress.json"))
+	if err != nil || saved.Count(BatchDone) != 3 {
+		t.Errorf("expected the saved manifest to match, got %+v, %v", saved, err)
+	}
+}
+
+func TestRunBootstrapBatch_RateLimited(t *testing.T) {
+	sources := newFakeBootstrapSources(t)
+	sources.broken["/repos/alpha/alpha"] = http.StatusForbidden
+	dir := t.TempDir()
+	opts := sources.options(dir)
+	opts.Concurrency = 1
+	batch := []BatchProject{{Org: "alpha", Name: "Alpha"}, {Org: "beta", Name: "Beta"}}
+
+	manifest, err := RunBootstrapBatch(batch, opts, t.Logf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Count(BatchFailed) != 1 || manifest.Count(BatchPending) != 1 {
+		t.Fatalf("expected the rest of the batch left pending, got %s", FormatBatchSummary(manifest))
+	}
+	if sources.count("/repos/beta/beta") != 0 {
+		t.Error("no project should start after a rate limit")
+	}
+	if !strings.Contains(FormatBatchSummary(manifest), "rate limited") {
+		t.Errorf("expected the rate limit in the summary:\n%s", FormatBatchSummary(manifest))
+	}
+}
+
+func TestRunBootstrapBatch_UnknownForge(t *testing.T) {
+	sources := newFakeBootstrapSources(t)
+	opts := sources.options(t.TempDir())
+	opts.Sources.Forge = "svn"
+
+	_, err := RunBootstrapBatch([]BatchProject{{Org: "alpha"}}, opts, t.Logf)
+	if err == nil || !strings.Contains(err.Error(), `unknown forge "svn"`) {
+		t.Errorf("expected an unknown forge error, got %v", err)
+	}
+	if sources.count("/repos/alpha/alpha") != 0 {
+		t.Error("no project should be fetched with an unknown forge")
+	}
+}
+
+func TestRunBootstrapBatch_ExistingDirectory(t *testing.T) {
+	sources := newFakeBootstrapSources(t)
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "alpha"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, "alpha", "project.yaml"), "name: Alpha\n")
+
+	manifest, err := RunBootstrapBatch([]BatchProject{{Org: "alpha", Name: "Alpha"}}, sources.options(dir), t.Logf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Count(BatchFailed) != 1 || !strings.Contains(FormatBatchSummary(manifest), "already exists") {
+		t.Errorf("expected an existing directory to be left alone, got %s", FormatBatchSummary(manifest))
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "alpha", "project.yaml"))
+	if string(data) != "name: Alpha\n" {
+		t.Errorf("existing project.yaml was overwritten: %s", data)
+	}
+}
+
+func TestBootstrapHTTPCache(t *testing.T) {
+	sources := newFakeBootstrapSources(t)
+	sources.broken["/repos/flaky/flaky"] = http.StatusBadGateway
+	cache := NewBootstrapHTTPCache(nil)
+	client := &http.Client{Transport: cache}
+
+	for i := 0; i < 2; i++ {
+		for _, path := range []string{"/landscape.yml", "/missing", "/repos/flaky/flaky"} {
+			resp, err := client.Get(sources.URL + path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+		}
+	}
+	// Successful and not found responses are cached; server errors are retried
+	if sources.count("/landscape.yml") != 1 || sources.count("/missing") != 1 || sources.count("/repos/flaky/flaky") != 2 {
+		t.Errorf("unexpected request counts %v", sources.requests)
+	}
+	if hits, misses := cache.Stats(); hits != 2 || misses != 4 {
+		t.Errorf("got %d hits and %d misses, want 2 and 4", hits, misses)
+	}
+	if cache.RateLimited() {
+		t.Error("a server error is not a rate limit")
+	}
+
+	resp, err := client.Get(sources.URL + "/landscape.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if body, err := io.ReadAll(resp.Body); err != nil || !strings.Contains(string(body), "name: Alpha") {
+		t.Errorf("cached body not served: %q, %v", body, err)
+	}
+}
+
+func TestBootstrapSlug(t *testing.T) {
+	for name, want := range map[string]string{
+		"Kubernetes":        "kubernetes",
+		"Open Policy Agent": "open-policy-agent",
+		"  Foo -- Bar!  ":   "foo-bar",
+	} {
+		if got := BootstrapSlug(name); got != want {
+			t.Errorf("BootstrapSlug(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package projects
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// trustedMatchScore is the name match score from which a source's values
+// are trusted. Values from a worse match only win a field when no trusted
+// source has a value for it.
+const trustedMatchScore = 0.8
+
+// unconflictedFields are fields whose candidates always differ between
+// sources, so disagreement is not reported. Logos are hosted per source.
+var unconflictedFields = map[string]bool{"artwork": true}
+
+// matchConfidence turns a name match into the confidence of a source's
+// values. Data not found by a name search (a zero score) is trusted.
+func matchConfidence(score float64, exactSlug bool) float64 {
+	if exactSlug || score == 0 {
+		return 1.0
+	}
+	return score
+}
+
+// chooseField picks the value of a field from the candidates, given in
+// source priority order. Trusted candidates win in that order; the others
+// follow by confidence. Every candidate is kept on the result, and the field
+// is reported as a conflict when the candidates disagree.
+func chooseField(result *BootstrapResult, field string, candidates ...FieldCandidate) (FieldCandidate, bool) {
+	var offered []FieldCandidate
+	for _, c := range candidates {
+		if strings.TrimSpace(c.Value) != "" {
+			offered = append(offered, c)
+		}
+	}
+	if len(offered) == 0 {
+		return FieldCandidate{}, false
+	}
+	sort.SliceStable(offered, func(i, j int) bool {
+		iTrusted, jTrusted := offered[i].Confidence >= trustedMatchScore, offered[j].Confidence >= trustedMatchScore
+		if iTrusted != jTrusted {
+			return iTrusted
+		}
+		return !iTrusted && offered[i].Confidence > offered[j].Confidence
+	})
+
+	chosen := offered[0]
+	if result.Candidates == nil {
+		result.Candidates = make(map[string][]FieldCandidate)
+	}
+	result.Candidates[field] = offered
+	result.Sources[field] = chosen.Source
+	if !unconflictedFields[field] {
+		for _, c := range offered[1:] {
+			if !sameCandidateValue(field, chosen.Value, c.Value) {
+				result.Conflicts = append(result.Conflicts, FieldConflict{Field: field, Value: chosen, Candidates: offered})
+				break
+			}
+		}
+	}
+	return chosen, true
+}
+
+// sameCandidateValue reports whether two candidates agree. URLs match
+// regardless of scheme, "www." and trailing slashes; names match by slug;
+// repository lists match when they share a repository.
+func sameCandidateValue(field, a, b string) bool {
+	switch field {
+	case "name":
+		return BootstrapSlug(a) == BootstrapSlug(b)
+	case "repositories":
+		for _, x := range strings.Split(a, ", ") {
+			for _, y := range strings.Split(b, ", ") {
+				if normalizeCandidateValue(x) == normalizeCandidateValue(y) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return normalizeCandidateValue(a) == normalizeCandidateValue(b)
+}
+
+// normalizeCandidateValue lowercases a value and strips URL noise
+func normalizeCandidateValue(v string) string {
+	v = strings.ToLower(strings.TrimSpace(v))
+	for _, prefix := range []string{"https://", "http://", "www."} {
+		v = strings.TrimPrefix(v, prefix)
+	}
+	return strings.TrimSuffix(v, "/")
+}
+
+// LowConfidenceMatches returns the sources matched by a name search with a
+// score too low to trust
+func (r *BootstrapResult) LowConfidenceMatches() []SourceMatch {
+	var low []SourceMatch
+	for _, m := range r.Matches {
+		if matchConfidence(m.Score, m.ExactSlug) < trustedMatchScore {
+			low = append(low, m)
+		}
+	}
+	return low
+}
+
+// ReviewNotes describes low-confidence matches and conflicting fields, one
+// line each, for the scaffold's comments and the bootstrap output
+func (r *BootstrapResult) ReviewNotes() []string {
+	var notes []string
+	for _, m := range r.LowConfidenceMatches() {
+		notes = append(notes, fmt.Sprintf("LOW-CONFIDENCE MATCH: %s matched %q (score %.2f); check it is this project", m.Source, m.Matched, m.Score))
+	}
+	for _, c := range r.Conflicts {
+		var others []string
+		for _, candidate := range c.Candidates {
+			if candidate != c.Value {
+				others = append(others, describeCandidate(candidate))
+			}
+		}
+		notes = append(notes, fmt.Sprintf("CONFLICT %s: using %s; also %s", c.Field, describeCandidate(c.Value), strings.Join(others, ", ")))
+	}
+	return notes
+}
+
+// describeCandidate renders a candidate on one line, shortening long values
+func describeCandidate(c FieldCandidate) string {
+	value := strings.Join(strings.Fields(c.Value), " ")
+	if len(value) > 60 {
+		value = value[:57] + "..."
+	}
+	return fmt.Sprintf("%q (%s, %.2f)", value, c.Source, c.Confidence)
+}
+
+// BootstrapReport lists where each scaffold field came from, every candidate
+// the sources offered, and the conflicts between them
+type BootstrapReport struct {
+	Slug       string                      `json:"slug" yaml:"slug"`
+	Name       string                      `json:"name" yaml:"name"`
+	Sources    map[string]string           `json:"sources" yaml:"sources"`
+	Matches    []SourceMatch               `json:"matches" yaml:"matches"`
+	Candidates map[string][]FieldCandidate `json:"candidates" yaml:"candidates"`
+	Conflicts  []FieldConflict             `json:"conflicts" yaml:"conflicts"`
+}
+
+// Report returns the source report of a bootstrap result
+func (r *BootstrapResult) Report() BootstrapReport {
+	report := BootstrapReport{
+		Slug:       r.Slug,
+		Name:       r.Name,
+		Sources:    r.Sources,
+		Matches:    r.Matches,
+		Candidates: r.Candidates,
+		Conflicts:  r.Conflicts,
+	}
+	if report.Matches == nil {
+		report.Matches = []SourceMatch{}
+	}
+	if report.Candidates == nil {
+		report.Candidates = map[string][]FieldCandidate{}
+	}
+	if report.Conflicts == nil {
+		report.Conflicts = []FieldConflict{}
+	}
+	return report
+}
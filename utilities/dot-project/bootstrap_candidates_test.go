@@ -0,0 +1,203 @@
+package projects
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMergeBootstrapData_Candidates(t *testing.T) {
+	landscape := &LandscapeData{
+		Name:           "Test Project",
+		Description:    "A test project",
+		HomepageURL:    "https://test-project.io/",
+		RepoURL:        "https://github.com/test-org/test-project",
+		Maturity:       "incubating",
+		MatchScore:     1.0,
+		ExactSlugMatch: true,
+	}
+	clomonitor := &CLOMonitorProject{
+		Name:        "test-project",
+		DisplayName: "Test Project",
+		HomeURL:     "http://www.test-project.io",
+		Maturity:    "sandbox",
+		Repositories: []CLOMonitorRepo{
+			{URL: "https://github.com/test-org/test-project"},
+			{URL: "https://github.com/test-org/website"},
+		},
+		MatchScore:     1.0,
+		ExactSlugMatch: true,
+	}
+	github := &GitHubData{Repo: &GitHubRepoData{Name: "test-project", Description: "A test project", Homepage: "https://other.example.com"}}
+
+	result := mergeBootstrapData("test-project", landscape, clomonitor, github)
+
+	// Priority order still decides between trusted sources
+	if result.Website != "https://test-project.io/" || result.Sources["website"] != "landscape" {
+		t.Errorf("website = %q from %q, want landscape's", result.Website, result.Sources["website"])
+	}
+	if got := result.Candidates["website"]; len(got) != 3 || got[2].Source != "github" {
+		t.Errorf("expected every website candidate to be kept, got %+v", got)
+	}
+
+	// URLs equal but for scheme, "www." and a trailing slash agree; the
+	// GitHub homepage and the CLOMonitor maturity do not
+	conflicts := map[string]FieldConflict{}
+	for _, c := range result.Conflicts {
+		conflicts[c.Field] = c
+	}
+	if len(conflicts) != 2 {
+		t.Errorf("expected website and maturity conflicts, got %+v", result.Conflicts)
+	}
+	if c, ok := conflicts["maturity"]; !ok || c.Value.Value != "incubating" || len(c.Candidates) != 2 {
+		t.Errorf("unexpected maturity conflict %+v", c)
+	}
+	if _, ok := conflicts["website"]; !ok {
+		t.Error("expected the GitHub homepage to conflict")
+	}
+	// Repository lists sharing a repository, and descriptions equal but for
+	// case, are not conflicts
+	for _, field := range []string{"repositories", "description", "name"} {
+		if _, ok := conflicts[field]; ok {
+			t.Errorf("unexpected %s conflict: %+v", field, conflicts[field])
+		}
+	}
+	if len(result.LowConfidenceMatches()) != 0 {
+		t.Errorf("exact slug matches are trusted, got %+v", result.LowConfidenceMatches())
+	}
+}
+
+func TestMergeBootstrapData_LowConfidence(t *testing.T) {
+	// A poor landscape match must not override the trusted GitHub data
+	landscape := &LandscapeData{
+		Name:        "Argo Workflows",
+		Description: "Workflow engine for Kubernetes",
+		HomepageURL: "https://argoproj.github.io/workflows",
+		MatchScore:  0.4,
+	}
+	github := &GitHubData{Repo: &GitHubRepoData{Name: "argo-events", Description: "Event-driven automation", Homepage: "https://argoproj.github.io/events"}}
+
+	result := mergeBootstrapData("argo-events", landscape, nil, github)
+	if result.Description != "Event-driven automation" || result.Sources["description"] != "github" {
+		t.Errorf("description = %q from %q, want GitHub's", result.Description, result.Sources["description"])
+	}
+	if result.Website != "https://argoproj.github.io/events" {
+		t.Errorf("website = %q, want GitHub's", result.Website)
+	}
+	// The low-confidence source still fills fields nobody else has
+	if result.Name != "Argo Workflows" || result.Sources["name"] != "landscape" {
+		t.Errorf("name = %q from %q, want landscape's", result.Name, result.Sources["name"])
+	}
+	low := result.LowConfidenceMatches()
+	if len(low) != 1 || low[0].Source != "landscape" || low[0].Matched != "Argo Workflows" {
+		t.Errorf("expected the landscape match to be low confidence, got %+v", low)
+	}
+
+	notes := strings.Join(result.ReviewNotes(), "\n")
+	for _, want := range []string{
+		`LOW-CONFIDENCE MATCH: landscape matched "Argo Workflows" (score 0.40)`,
+		`CONFLICT description: using "Event-driven automation" (github, 1.00); also "Workflow engine for Kubernetes" (landscape, 0.40)`,
+	} {
+		if !strings.Contains(notes, want) {
+			t.Errorf("expected note %q in:\n%s", want, notes)
+		}
+	}
+}
+
+func TestGenerateProjectYAML_ReviewNotes(t *testing.T) {
+	result := mergeBootstrapData("test-project",
+		&LandscapeData{Name: "Test Project", Description: "Line one\nline: two", Maturity: "sandbox", MatchScore: 0.5},
+		&CLOMonitorProject{DisplayName: "Test Project", Description: "Other", Maturity: "graduated", MatchScore: 0.9},
+		nil)
+
+	out, err := GenerateProjectYAML(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`# REVIEW: LOW-CONFIDENCE MATCH: landscape matched "Test Project" (score 0.50)`,
+		`# REVIEW: CONFLICT maturity: using "graduated" (clomonitor, 0.90); also "sandbox" (landscape, 0.50)`,
+		`# REVIEW: CONFLICT description: using "Other" (clomonitor, 0.90); also "Line one line: two" (landscape, 0.50)`,
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("expected %q in project.yaml:\n%s", want, out)
+		}
+	}
+	var project Project
+	if err := yaml.Unmarshal(out, &project); err != nil {
+		t.Fatalf("project.yaml with review notes is not valid YAML: %v\n%s", err, out)
+	}
+}
+
+func TestBootstrapReport(t *testing.T) {
+	result := mergeBootstrapData("test-project", nil, nil, nil)
+	data, err := json.Marshal(result.Report())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Empty lists are reported as such, not as null
+	if !strings.Contains(string(data), `"matches":[]`) || !strings.Contains(string(data), `"conflicts":[]`) {
+		t.Errorf("unexpected empty report: %s", data)
+	}
+
+	result = mergeBootstrapData("test-project",
+		&LandscapeData{Name: "Test Project", Maturity: "sandbox", MatchScore: 1.0},
+		&CLOMonitorProject{DisplayName: "Test Project", Maturity: "incubating", MatchScore: 1.0}, nil)
+	var report BootstrapReport
+	data, _ = json.Marshal(result.Report())
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Conflicts) != 1 || report.Conflicts[0].Field != "maturity" || len(report.Candidates["maturity"]) != 2 || len(report.Matches) != 2 {
+		t.Errorf("unexpected report %s", data)
+	}
+}
+
+func TestFetch_MatchScores(t *testing.T) {
+	t.Run("CLOMonitor exact slug", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode([]CLOMonitorProject{
+				{Name: "argo", DisplayName: "Argo", Foundation: "cncf"},
+				{Name: "argo-cd", DisplayName: "Argo CD", Foundation: "cncf"},
+			})
+		}))
+		defer server.Close()
+
+		got, err := fetchFromCLOMonitor("argo-cd", server.Client(), server.URL)
+		if err != nil || got == nil {
+			t.Fatalf("fetchFromCLOMonitor() = %v, %v", got, err)
+		}
+		if got.Name != "argo-cd" || !got.ExactSlugMatch || got.MatchScore != 1.0 {
+			t.Errorf("expected an exact slug match on argo-cd, got %+v", got)
+		}
+	})
+
+	t.Run("landscape fuzzy", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`landscape:
+  - category:
+    name: App Definition and Development
+    subcategories:
+      - subcategory:
+        name: Continuous Integration & Delivery
+        items:
+          - item:
+            name: Argo Workflows
+            project: graduated
+`))
+		}))
+		defer server.Close()
+
+		got, err := fetchFromLandscape("Argo", server.Client(), server.URL)
+		if err != nil || got == nil {
+			t.Fatalf("fetchFromLandscape() = %v, %v", got, err)
+		}
+		if got.ExactSlugMatch || got.MatchScore <= 0 || got.MatchScore >= trustedMatchScore {
+			t.Errorf("expected a low-confidence fuzzy match, got score %v exact %v", got.MatchScore, got.ExactSlugMatch)
+		}
+	})
+}
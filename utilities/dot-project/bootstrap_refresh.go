@@ -0,0 +1,537 @@
+package projects
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// bootstrapBaseDir holds project.yaml and maintainers.yaml as bootstrap last
+// generated them. A refresh uses them as the base of its three-way merge, so
+// it can tell fields edited by hand from fields the sources changed.
+const bootstrapBaseDir = ".bootstrap"
+
+// RefreshChange is a field a refresh updates with newly discovered data
+type RefreshChange struct {
+	File        string `json:"file" yaml:"file"`
+	FieldChange `yaml:",inline"`
+}
+
+// RefreshConflict is a field changed both by hand and by the sources. The
+// existing value is kept.
+type RefreshConflict struct {
+	File   string `json:"file" yaml:"file"`
+	Path   string `json:"path" yaml:"path"`
+	Ours   string `json:"ours,omitempty" yaml:"ours,omitempty"`     // Kept
+	Theirs string `json:"theirs,omitempty" yaml:"theirs,omitempty"` // Discovered, not applied
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// RefreshResult is the outcome of refreshing a bootstrapped project
+type RefreshResult struct {
+	Changes   []RefreshChange   `json:"changes" yaml:"changes"`
+	Conflicts []RefreshConflict `json:"conflicts,omitempty" yaml:"conflicts,omitempty"`
+	// Patch is a unified diff against the directory, for `git apply`. It also
+	// moves the merge base in .bootstrap/ to the newly generated files.
+	Patch string `json:"patch,omitempty" yaml:"patch,omitempty"`
+}
+
+// RefreshScaffold merges newly bootstrapped data into the project.yaml and
+// maintainers.yaml of an existing scaffold. The merge works on YAML nodes
+// against the files last generated (kept in .bootstrap/): fields the sources
+// changed are updated, fields edited by hand are kept, and comments survive.
+// Nothing is written; the result carries a patch to review and apply.
+// Without a merge base, only fields missing from the files are added.
+func RefreshScaffold(dir string, result *BootstrapResult) (*RefreshResult, error) {
+	refresh := &RefreshResult{Changes: []RefreshChange{}}
+	if result.AcceptedDate.IsZero() {
+		// An unknown acceptance date is generated as the current time; keep
+		// the one generated before so it does not show up as a change
+		previous := *result
+		previous.AcceptedDate = previousAcceptedDate(dir)
+		result = &previous
+	}
+	generators := []struct {
+		name     string
+		generate func(*BootstrapResult) ([]byte, error)
+	}{
+		{"project.yaml", GenerateProjectYAML},
+		{"maintainers.yaml", GenerateMaintainersYAML},
+	}
+
+	var patch strings.Builder
+	for _, g := range generators {
+		ours, err := os.ReadFile(filepath.Join(dir, g.name))
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s not found in %s; bootstrap the project before refreshing it", g.name, dir)
+		}
+		if err != nil {
+			return nil, err
+		}
+		theirs, err := g.generate(result)
+		if err != nil {
+			return nil, fmt.Errorf("generating %s: %w", g.name, err)
+		}
+		basePath := filepath.Join(bootstrapBaseDir, g.name)
+		base, err := os.ReadFile(filepath.Join(dir, basePath))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+
+		merger := &yamlMerger{file: g.name, hasBase: base != nil}
+		merged, err := merger.mergeDocuments(base, ours, theirs)
+		if err != nil {
+			return nil, err
+		}
+		refresh.Changes = append(refresh.Changes, merger.changes...)
+		refresh.Conflicts = append(refresh.Conflicts, merger.conflicts...)
+
+		patch.WriteString(unifiedDiff(g.name, ours, merged, false))
+		patch.WriteString(unifiedDiff(filepath.ToSlash(basePath), base, theirs, base == nil))
+	}
+	refresh.Patch = patch.String()
+	return refresh, nil
+}
+
+// previousAcceptedDate returns the first maturity_log date of the merge base,
+// or of project.yaml when there is none
+func previousAcceptedDate(dir string) time.Time {
+	for _, path := range []string{filepath.Join(dir, bootstrapBaseDir, "project.yaml"), filepath.Join(dir, "project.yaml")} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var project Project
+		if err := yaml.Unmarshal(data, &project); err == nil && len(project.MaturityLog) > 0 {
+			return project.MaturityLog[0].Date
+		}
+	}
+	return time.Time{}
+}
+
+// yamlMerger three-way merges YAML documents, editing "ours" in place
+type yamlMerger struct {
+	file      string
+	hasBase   bool
+	changes   []RefreshChange
+	conflicts []RefreshConflict
+}
+
+// mergeDocuments merges the sources' changes between base and theirs into
+// ours. Ours is returned unchanged when nothing applies.
+func (m *yamlMerger) mergeDocuments(base, ours, theirs []byte) ([]byte, error) {
+	parse := func(name string, data []byte) (*yaml.Node, error) {
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse %s %s: %w", name, m.file, err)
+		}
+		if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s %s must be a YAML mapping", name, m.file)
+		}
+		return &doc, nil
+	}
+	oursDoc, err := parse("existing", ours)
+	if err != nil {
+		return nil, err
+	}
+	theirsDoc, err := parse("generated", theirs)
+	if err != nil {
+		return nil, err
+	}
+	var baseRoot *yaml.Node
+	if base != nil {
+		baseDoc, err := parse("base", base)
+		if err != nil {
+			return nil, err
+		}
+		baseRoot = baseDoc.Content[0]
+	}
+
+	m.mergeMapping("", baseRoot, oursDoc.Content[0], theirsDoc.Content[0])
+	if len(m.changes) == 0 {
+		return ours, nil
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(oursDoc); err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", m.file, err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", m.file, err)
+	}
+	return restoreBlankLines(ours, buf.Bytes()), nil
+}
+
+// merge returns the node to keep at path: ours, possibly edited, a node taken
+// from theirs, or nil to remove the field. Mappings and lists are merged item
+// by item, so comments inside them survive; other values are taken from
+// theirs only when they were not edited by hand.
+func (m *yamlMerger) merge(path string, base, ours, theirs *yaml.Node) *yaml.Node {
+	if yamlNodesEqual(ours, theirs) {
+		return ours
+	}
+	if ours != nil && theirs != nil && ours.Kind == theirs.Kind {
+		switch {
+		case ours.Kind == yaml.MappingNode:
+			m.mergeMapping(path, kindOrNil(base, yaml.MappingNode), ours, theirs)
+			return ours
+		case ours.Kind == yaml.SequenceNode && isScalarSequence(ours) && isScalarSequence(theirs):
+			m.mergeScalarSequence(path, kindOrNil(base, yaml.SequenceNode), ours, theirs)
+			return ours
+		case ours.Kind == yaml.SequenceNode && len(ours.Content) == len(theirs.Content):
+			base = kindOrNil(base, yaml.SequenceNode)
+			for i := range ours.Content {
+				var baseItem *yaml.Node
+				if base != nil && i < len(base.Content) {
+					baseItem = base.Content[i]
+				}
+				if merged := m.merge(fmt.Sprintf("%s[%d]", path, i), baseItem, ours.Content[i], theirs.Content[i]); merged != nil {
+					ours.Content[i] = merged
+				}
+			}
+			return ours
+		}
+	}
+
+	switch {
+	case m.hasBase && yamlNodesEqual(base, theirs):
+		return ours // Only edited by hand
+	case m.hasBase && yamlNodesEqual(base, ours):
+		return m.take(path, ours, theirs) // Only changed by the sources
+	case !m.hasBase && ours == nil:
+		return m.take(path, ours, theirs)
+	case !m.hasBase && theirs == nil:
+		return ours
+	}
+
+	reason := "changed by hand and by the sources"
+	switch {
+	case !m.hasBase:
+		reason = "differs and there is no merge base"
+	case ours == nil:
+		reason = "removed by hand, changed by the sources"
+	case theirs == nil:
+		reason = "changed by hand, no longer generated"
+	}
+	m.conflicts = append(m.conflicts, RefreshConflict{File: m.file, Path: path, Ours: yamlNodeSummary(ours), Theirs: yamlNodeSummary(theirs), Reason: reason})
+	return ours
+}
+
+// take replaces ours with theirs, keeping the comments of ours
+func (m *yamlMerger) take(path string, ours, theirs *yaml.Node) *yaml.Node {
+	change := RefreshChange{File: m.file, FieldChange: FieldChange{Path: path, Old: yamlNodeSummary(ours), New: yamlNodeSummary(theirs)}}
+	switch {
+	case theirs == nil:
+		change.Kind = ChangeRemoved
+	case ours == nil:
+		change.Kind = ChangeAdded
+	default:
+		change.Kind = ChangeModified
+	}
+	m.changes = append(m.changes, change)
+
+	if ours == nil || theirs == nil {
+		return theirs
+	}
+	if ours.Kind == yaml.ScalarNode && theirs.Kind == yaml.ScalarNode {
+		ours.Value, ours.Tag, ours.Style = theirs.Value, theirs.Tag, theirs.Style
+		return ours
+	}
+	if ours.HeadComment != "" {
+		theirs.HeadComment = ours.HeadComment
+	}
+	if ours.LineComment != "" {
+		theirs.LineComment = ours.LineComment
+	}
+	return theirs
+}
+
+// mergeMapping merges theirs into the mapping ours. New keys are inserted
+// after the key that precedes them in theirs.
+func (m *yamlMerger) mergeMapping(path string, base, ours, theirs *yaml.Node) {
+	for i := 0; i+1 < len(ours.Content); {
+		key := ours.Content[i].Value
+		merged := m.merge(joinDiffPath(path, key), mappingValue(base, key), ours.Content[i+1], mappingValue(theirs, key))
+		if merged == nil {
+			ours.Content = append(ours.Content[:i], ours.Content[i+2:]...)
+			continue
+		}
+		ours.Content[i+1] = merged
+		i += 2
+	}
+
+	previous := ""
+	for i := 0; i+1 < len(theirs.Content); i += 2 {
+		key := theirs.Content[i].Value
+		if mappingValue(ours, key) != nil {
+			previous = key
+			continue
+		}
+		merged := m.merge(joinDiffPath(path, key), mappingValue(base, key), nil, theirs.Content[i+1])
+		if merged == nil {
+			continue
+		}
+		at := len(ours.Content)
+		for j := 0; j+1 < len(ours.Content); j += 2 {
+			if previous != "" && ours.Content[j].Value == previous {
+				at = j + 2
+			}
+		}
+		if previous == "" {
+			at = 0
+		}
+		entry := []*yaml.Node{theirs.Content[i], merged}
+		ours.Content = append(ours.Content[:at], append(entry, ours.Content[at:]...)...)
+		previous = key
+	}
+}
+
+// mergeScalarSequence merges lists of scalars, such as repositories or team
+// members, as sets: items the sources added are appended and items they
+// dropped are removed, while items added by hand stay
+func (m *yamlMerger) mergeScalarSequence(path string, base, ours, theirs *yaml.Node) {
+	inBase, inTheirs, inOurs := scalarSet(base), scalarSet(theirs), scalarSet(ours)
+	var kept []*yaml.Node
+	for _, item := range ours.Content {
+		if inBase[item.Value] && !inTheirs[item.Value] {
+			m.changes = append(m.changes, RefreshChange{File: m.file, FieldChange: FieldChange{Path: path, Kind: ChangeRemoved, Old: item.Value}})
+			continue
+		}
+		kept = append(kept, item)
+	}
+	for _, item := range theirs.Content {
+		if !inBase[item.Value] && !inOurs[item.Value] {
+			m.changes = append(m.changes, RefreshChange{File: m.file, FieldChange: FieldChange{Path: path, Kind: ChangeAdded, New: item.Value}})
+			kept = append(kept, item)
+		}
+	}
+	ours.Content = kept
+}
+
+// yamlNodesEqual compares two nodes by value, ignoring comments and style
+func yamlNodesEqual(a, b *yaml.Node) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if a.Kind != b.Kind || len(a.Content) != len(b.Content) {
+		return false
+	}
+	if a.Kind == yaml.ScalarNode {
+		return a.Value == b.Value && a.ShortTag() == b.ShortTag()
+	}
+	for i := range a.Content {
+		if !yamlNodesEqual(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// yamlNodeSummary renders a node for change and conflict reports
+func yamlNodeSummary(n *yaml.Node) string {
+	switch {
+	case n == nil:
+		return ""
+	case n.Kind == yaml.ScalarNode:
+		return n.Value
+	case n.Kind == yaml.SequenceNode && isScalarSequence(n):
+		values := make([]string, len(n.Content))
+		for i, item := range n.Content {
+			values[i] = item.Value
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	case n.Kind == yaml.SequenceNode:
+		return fmt.Sprintf("(%d items)", len(n.Content))
+	default:
+		return fmt.Sprintf("(%d fields)", len(n.Content)/2)
+	}
+}
+
+// mappingValue returns the value of a key in a mapping node, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil {
+		return nil
+	}
+	return childNode(mapping, key)
+}
+
+// kindOrNil returns n when it is of the given kind
+func kindOrNil(n *yaml.Node, kind yaml.Kind) *yaml.Node {
+	if n != nil && n.Kind == kind {
+		return n
+	}
+	return nil
+}
+
+// isScalarSequence reports whether every item of a sequence is a scalar
+func isScalarSequence(n *yaml.Node) bool {
+	for _, item := range n.Content {
+		if item.Kind != yaml.ScalarNode {
+			return false
+		}
+	}
+	return true
+}
+
+// scalarSet returns the values of a scalar sequence
+func scalarSet(n *yaml.Node) map[string]bool {
+	set := make(map[string]bool)
+	if n != nil {
+		for _, item := range n.Content {
+			set[item.Value] = true
+		}
+	}
+	return set
+}
+
+// restoreBlankLines puts back the blank lines the YAML encoder drops: a line
+// that followed blank lines in the original is preceded by as many again,
+// and trailing blank lines are kept
+func restoreBlankLines(original, encoded []byte) []byte {
+	afterBlank := make(map[string][]int)
+	blanks := 0
+	for _, line := range strings.Split(string(original), "\n") {
+		if strings.TrimSpace(line) == "" {
+			blanks++
+			continue
+		}
+		if blanks > 0 {
+			afterBlank[line] = append(afterBlank[line], blanks)
+		}
+		blanks = 0
+	}
+
+	var out []string
+	for _, line := range strings.Split(strings.TrimRight(string(encoded), "\n"), "\n") {
+		if counts := afterBlank[line]; len(counts) > 0 {
+			afterBlank[line] = counts[1:]
+			have := 0
+			for i := len(out) - 1; i >= 0 && strings.TrimSpace(out[i]) == ""; i-- {
+				have++
+			}
+			for ; have < counts[0] && len(out) > 0; have++ {
+				out = append(out, "")
+			}
+		}
+		out = append(out, line)
+	}
+	// blanks counted the newline ending the file as an empty last line
+	for i := 1; i < blanks; i++ {
+		out = append(out, "")
+	}
+	return []byte(strings.Join(out, "\n") + "\n")
+}
+
+// unifiedDiff renders the change from old to new as a unified diff of the
+// file at path, with three lines of context. created marks a new file.
+func unifiedDiff(path string, old, new []byte, created bool) string {
+	if bytes.Equal(old, new) && !created {
+		return ""
+	}
+	a, b := diffLines(old), diffLines(new)
+
+	// Longest common subsequence, then an edit script
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	type edit struct {
+		op   byte // ' ', '-' or '+'
+		line string
+		i, j int // Positions in a and b before the edit
+	}
+	var edits []edit
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			edits = append(edits, edit{' ', a[i], i, j})
+			i, j = i+1, j+1
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			edits = append(edits, edit{'-', a[i], i, j})
+			i++
+		default:
+			edits = append(edits, edit{'+', b[j], i, j})
+			j++
+		}
+	}
+
+	var out strings.Builder
+	from := "a/" + path
+	if created {
+		from = "/dev/null"
+	}
+	out.WriteString(fmt.Sprintf("--- %s\n+++ b/%s\n", from, path))
+	const context = 3
+	for start := 0; start < len(edits); {
+		// Find the next change and the end of its hunk
+		first := start
+		for first < len(edits) && edits[first].op == ' ' {
+			first++
+		}
+		if first == len(edits) {
+			break
+		}
+		last := first
+		for k := first; k < len(edits); k++ {
+			if edits[k].op != ' ' {
+				last = k
+			} else if k-last > 2*context {
+				break
+			}
+		}
+		lo, hi := max(first-context, start), min(last+context+1, len(edits))
+
+		var body strings.Builder
+		oldCount, newCount := 0, 0
+		for _, e := range edits[lo:hi] {
+			body.WriteString(string(e.op) + e.line + "\n")
+			if e.op != '+' {
+				oldCount++
+			}
+			if e.op != '-' {
+				newCount++
+			}
+		}
+		oldStart, newStart := edits[lo].i+1, edits[lo].j+1
+		if oldCount == 0 {
+			oldStart--
+		}
+		if newCount == 0 {
+			newStart--
+		}
+		out.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount))
+		out.WriteString(body.String())
+		start = hi
+	}
+	return out.String()
+}
+
+// diffLines splits content into lines without their newlines. A last line
+// without a newline carries the marker patch tools expect after it.
+func diffLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if !bytes.HasSuffix(data, []byte("\n")) {
+		lines[len(lines)-1] += "\n\\ No newline at end of file"
+	}
+	return lines
+}
@@ -0,0 +1,188 @@
+package projects
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// refreshFixture is a bootstrap result before the sources changed
+func refreshFixture() *BootstrapResult {
+	return &BootstrapResult{
+		Slug:          "test-project",
+		Name:          "Test Project",
+		Description:   "A test project",
+		GitHubOrg:     "test-org",
+		GitHubRepo:    "test-project",
+		MaturityPhase: "sandbox",
+		Repositories:  []string{"https://github.com/test-org/test-project"},
+		Maintainers:   []string{"alice", "bob"},
+		Sources:       map[string]string{},
+	}
+}
+
+// editFile replaces text in a file, failing when it is missing
+func editFile(t *testing.T, path, old, new string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), old) {
+		t.Fatalf("%s does not contain %q", path, old)
+	}
+	writeFile(t, path, strings.Replace(string(data), old, new, 1))
+}
+
+func TestRefreshScaffold(t *testing.T) {
+	dir := t.TempDir()
+	if err := WriteScaffold(dir, refreshFixture()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Edits by hand: a new description with a comment, and a reviewer team
+	projectFile := filepath.Join(dir, "project.yaml")
+	// An unknown acceptance date was generated as the time of the bootstrap
+	for _, path := range []string{projectFile, filepath.Join(dir, bootstrapBaseDir, "project.yaml")} {
+		data, _ := os.ReadFile(path)
+		writeFile(t, path, regexp.MustCompile(`date: "[^"]*"`).ReplaceAllString(string(data), `date: "2020-01-01T00:00:00Z"`))
+	}
+	editFile(t, projectFile, `description: "A test project"`, "# Reviewed by the TOC\ndescription: \"Our own words\"")
+	editFile(t, filepath.Join(dir, "maintainers.yaml"), "          - bob\n", "          - bob\n          - carol # joined by hand\n")
+
+	// The sources moved on: a new maturity, description, website and maintainer
+	updated := refreshFixture()
+	updated.MaturityPhase = "incubating"
+	updated.Description = "A better description"
+	updated.Website = "https://test-project.io"
+	updated.Maintainers = []string{"alice", "dave"}
+	before, _ := os.ReadFile(projectFile)
+
+	refresh, err := RefreshScaffold(dir, updated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after, _ := os.ReadFile(projectFile); string(after) != string(before) {
+		t.Error("refresh must not write project.yaml")
+	}
+	if strings.Contains(refresh.Patch, "+    date:") {
+		t.Errorf("an unknown acceptance date should not change:\n%s", refresh.Patch)
+	}
+
+	var changes []string
+	for _, c := range refresh.Changes {
+		changes = append(changes, c.File+" "+c.String())
+	}
+	for _, want := range []string{
+		"project.yaml ~ maturity_log[0].phase: sandbox -> incubating",
+		"project.yaml + website: https://test-project.io",
+		"maintainers.yaml - maintainers[0].teams[0].members: bob",
+		"maintainers.yaml + maintainers[0].teams[0].members: dave",
+	} {
+		if !containsFold(changes, want) {
+			t.Errorf("expected change %q, got %v", want, changes)
+		}
+	}
+	if len(refresh.Conflicts) != 1 || refresh.Conflicts[0].Path != "description" || refresh.Conflicts[0].Ours != "Our own words" {
+		t.Errorf("expected the description to conflict, got %+v", refresh.Conflicts)
+	}
+
+	// The patch applies cleanly and keeps edits and comments
+	if out, err := applyPatch(t, dir, refresh.Patch); err != nil {
+		t.Fatalf("patch does not apply: %v\n%s\n%s", err, out, refresh.Patch)
+	}
+	merged, _ := os.ReadFile(projectFile)
+	for _, want := range []string{`phase: "incubating"`, `website: "https://test-project.io"`, "# Reviewed by the TOC\ndescription: \"Our own words\"", "# .project metadata for Test Project"} {
+		if !strings.Contains(string(merged), want) {
+			t.Errorf("expected %q in merged project.yaml:\n%s", want, merged)
+		}
+	}
+	var project Project
+	if err := yaml.Unmarshal(merged, &project); err != nil || project.Website != "https://test-project.io" {
+		t.Errorf("merged project.yaml does not parse: %v", err)
+	}
+	maintainers, _ := os.ReadFile(filepath.Join(dir, "maintainers.yaml"))
+	if !strings.Contains(string(maintainers), "- carol # joined by hand\n") || !strings.Contains(string(maintainers), "- dave") || strings.Contains(string(maintainers), "bob") {
+		t.Errorf("unexpected merged maintainers.yaml:\n%s", maintainers)
+	}
+
+	// The patch moved the merge base, so a second refresh has nothing to do
+	again, err := RefreshScaffold(dir, updated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Patch != "" || len(again.Changes) != 0 || len(again.Conflicts) != 0 {
+		t.Errorf("expected nothing to refresh, got %+v", again)
+	}
+}
+
+func TestRefreshScaffold_NoBase(t *testing.T) {
+	dir := t.TempDir()
+	if err := WriteScaffold(dir, refreshFixture()); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(dir, bootstrapBaseDir)); err != nil {
+		t.Fatal(err)
+	}
+
+	updated := refreshFixture()
+	updated.MaturityPhase = "incubating"
+	updated.Website = "https://test-project.io"
+	refresh, err := RefreshScaffold(dir, updated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Without a base, existing values win and only new fields are added
+	if len(refresh.Changes) != 1 || refresh.Changes[0].Path != "website" {
+		t.Errorf("expected only website added, got %+v", refresh.Changes)
+	}
+	if len(refresh.Conflicts) != 1 || refresh.Conflicts[0].Reason != "differs and there is no merge base" {
+		t.Errorf("expected the maturity to be reported, got %+v", refresh.Conflicts)
+	}
+	if !strings.Contains(refresh.Patch, "--- /dev/null\n+++ b/.bootstrap/project.yaml\n") {
+		t.Errorf("expected the patch to create the merge base:\n%s", refresh.Patch)
+	}
+	if out, err := applyPatch(t, dir, refresh.Patch); err != nil {
+		t.Fatalf("patch does not apply: %v\n%s", err, out)
+	}
+}
+
+func TestRefreshScaffold_NotBootstrapped(t *testing.T) {
+	if _, err := RefreshScaffold(t.TempDir(), refreshFixture()); err == nil || !strings.Contains(err.Error(), "bootstrap the project before refreshing") {
+		t.Errorf("expected an error for a missing project.yaml, got %v", err)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	old := []byte("a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n")
+	new := []byte("a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\n")
+	want := "--- a/f.txt\n+++ b/f.txt\n" +
+		"@@ -1,5 +1,5 @@\n a\n-b\n+B\n c\n d\n e\n" +
+		"@@ -8,3 +8,4 @@\n h\n i\n j\n+k\n"
+	if got := unifiedDiff("f.txt", old, new, false); got != want {
+		t.Errorf("unexpected diff:\n%s\nwant:\n%s", got, want)
+	}
+	if got := unifiedDiff("f.txt", old, old, false); got != "" {
+		t.Errorf("expected no diff for equal content, got %q", got)
+	}
+	if got := unifiedDiff("f.txt", []byte("a"), []byte("a\n"), false); !strings.Contains(got, "-a\n\\ No newline at end of file\n+a\n") {
+		t.Errorf("expected a missing newline to be marked, got %q", got)
+	}
+}
+
+// applyPatch applies a patch to a directory with git apply
+func applyPatch(t *testing.T, dir, patch string) ([]byte, error) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	cmd := exec.Command("git", "apply", "-")
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(patch)
+	return cmd.CombinedOutput()
+}
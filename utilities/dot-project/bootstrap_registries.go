@@ -0,0 +1,555 @@
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	defaultScorecardURL   = "https://api.securityscorecards.dev"
+	defaultArtifactHubURL = "https://artifacthub.io"
+	defaultNPMURL         = "https://registry.npmjs.org"
+	defaultPyPIURL        = "https://pypi.org"
+	defaultCratesURL      = "https://crates.io"
+	defaultDockerHubURL   = "https://hub.docker.com"
+	defaultGHCRURL        = "https://ghcr.io"
+	scorecardViewerURL    = "https://scorecard.dev/viewer/?uri="
+
+	// registryUserAgent identifies the bootstrap to registries that require
+	// a user agent, such as crates.io
+	registryUserAgent = "cncf-dot-project-bootstrap (https://github.com/cncf/automation)"
+)
+
+// BootstrapSource is an additional data source, looked up by the project's
+// repository once the landscape, CLOMonitor and GitHub data are merged.
+// Lookup returns nil findings when the source knows nothing about the
+// project, and an error only when it could not answer.
+type BootstrapSource interface {
+	Name() string
+	Lookup(target BootstrapTarget, client *http.Client) (*SourceFindings, error)
+}
+
+// BootstrapTarget is what additional sources look a project up by
+type BootstrapTarget struct {
+	Slug    string
+	Name    string
+	Org     string // GitHub organization
+	Repo    string // Primary repository name
+	RepoURL string // Primary repository URL
+}
+
+// SourceFindings is what an additional source found about a project
+type SourceFindings struct {
+	PackageManagers map[string]string // Registry name to package identifier
+	Unverified      map[string]bool   // Registries matched by name alone, with no link back to the repository
+	Audits          []Audit           // Proposed audits entries
+	Scorecard       *ScorecardResult
+}
+
+// String summarizes findings for progress messages
+func (f *SourceFindings) String() string {
+	var parts []string
+	for _, registry := range sortedKeys(f.PackageManagers) {
+		part := registry + " " + f.PackageManagers[registry]
+		if f.Unverified[registry] {
+			part += " (unverified)"
+		}
+		parts = append(parts, part)
+	}
+	if f.Scorecard != nil {
+		parts = append(parts, fmt.Sprintf("Scorecard %.1f/10", f.Scorecard.Score))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ScorecardResult is an OpenSSF Scorecard run of the primary repository
+type ScorecardResult struct {
+	Date   string           `json:"date" yaml:"date"`
+	Score  float64          `json:"score" yaml:"score"`
+	Checks []ScorecardCheck `json:"checks,omitempty" yaml:"checks,omitempty"`
+}
+
+// ScorecardCheck is the outcome of one Scorecard check (-1 when inconclusive)
+type ScorecardCheck struct {
+	Name   string `json:"name" yaml:"name"`
+	Score  int    `json:"score" yaml:"score"`
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// BootstrapSourceNames lists the additional sources NewBootstrapSources knows
+var BootstrapSourceNames = []string{"scorecard", "artifacthub", "npm", "pypi", "crates", "docker", "ghcr"}
+
+// NewBootstrapSources creates additional sources by name, with their default
+// base URLs
+func NewBootstrapSources(names []string) ([]BootstrapSource, error) {
+	var sources []BootstrapSource
+	for _, name := range names {
+		switch strings.TrimSpace(name) {
+		case "scorecard":
+			sources = append(sources, &ScorecardSource{})
+		case "artifacthub":
+			sources = append(sources, &ArtifactHubSource{})
+		case "npm":
+			sources = append(sources, &NPMSource{})
+		case "pypi":
+			sources = append(sources, &PyPISource{})
+		case "crates":
+			sources = append(sources, &CratesSource{})
+		case "docker":
+			sources = append(sources, &DockerHubSource{})
+		case "ghcr":
+			sources = append(sources, &GHCRSource{})
+		case "":
+		default:
+			return nil, fmt.Errorf("unknown bootstrap source %q (known: %s)", name, strings.Join(BootstrapSourceNames, ", "))
+		}
+	}
+	return sources, nil
+}
+
+// bootstrapTarget describes a merged result to the additional sources
+func bootstrapTarget(result *BootstrapResult) BootstrapTarget {
+	target := BootstrapTarget{Slug: result.Slug, Name: result.Name, Org: result.GitHubOrg, Repo: result.GitHubRepo}
+	if target.Org != "" && target.Repo != "" {
+		target.RepoURL = result.RepoURL()
+	} else if len(result.Repositories) > 0 {
+		target.RepoURL = result.Repositories[0]
+	}
+	return target
+}
+
+// packageNames are the names a project is likely published under
+func (t BootstrapTarget) packageNames() []string {
+	var names []string
+	for _, name := range []string{t.Repo, t.Slug} {
+		if name != "" && !containsFold(names, name) {
+			names = append(names, strings.ToLower(name))
+		}
+	}
+	return names
+}
+
+// unverifiedConfidence is the confidence of a registry package matched by
+// name alone, below trustedMatchScore so that linked packages win
+const unverifiedConfidence = 0.5
+
+// applySourceFindings merges what an additional source found into a result.
+// Packages matched by name alone are kept with a TODO to confirm them.
+func applySourceFindings(result *BootstrapResult, source string, findings *SourceFindings) {
+	for _, registry := range sortedKeys(findings.PackageManagers) {
+		id := findings.PackageManagers[registry]
+		field := "package_managers." + registry
+		confidence := 1.0
+		if findings.Unverified[registry] {
+			confidence = unverifiedConfidence
+		}
+		if c, ok := chooseField(result, field, append(result.Candidates[field], FieldCandidate{Source: source, Value: id, Confidence: confidence})...); ok {
+			if todo := fmt.Sprintf("Confirm %s %q (matched by name, not by a link to the repository)", field, c.Value); c.Confidence < trustedMatchScore && !containsFold(result.TODOs, todo) {
+				result.TODOs = append(result.TODOs, todo)
+			}
+			if result.PackageManagers == nil {
+				result.PackageManagers = make(map[string]string)
+			}
+			result.PackageManagers[registry] = c.Value
+		}
+	}
+	if len(findings.Audits) > 0 {
+		result.Audits = append(result.Audits, findings.Audits...)
+		result.Sources["audits"] = source
+	}
+	if findings.Scorecard != nil {
+		result.Scorecard = findings.Scorecard
+		result.Sources["scorecard"] = source
+	}
+}
+
+// removeTODO drops a TODO that a later source resolved
+func removeTODO(result *BootstrapResult, todo string) {
+	var kept []string
+	for _, t := range result.TODOs {
+		if t != todo {
+			kept = append(kept, t)
+		}
+	}
+	result.TODOs = kept
+}
+
+// repositoryKey reduces the spellings of a repository URL found in registry
+// metadata ("git+https://github.com/org/repo.git", "git@github.com:org/repo",
+// "github:org/repo", ".../org/repo/tree/main") to "github.com/org/repo"
+func repositoryKey(repoURL string) string {
+	s := strings.ToLower(strings.TrimSpace(repoURL))
+	s = strings.TrimPrefix(s, "git+")
+	if strings.HasPrefix(s, "github:") {
+		s = "github.com/" + strings.TrimPrefix(s, "github:")
+	}
+	for _, prefix := range []string{"https://", "http://", "git://", "ssh://", "git@", "www."} {
+		s = strings.TrimPrefix(s, prefix)
+	}
+	if host, path, ok := strings.Cut(s, ":"); ok && !strings.Contains(host, "/") {
+		s = host + "/" + path
+	}
+	parts := strings.Split(strings.Trim(s, "/"), "/")
+	if len(parts) < 3 || parts[1] == "" || parts[2] == "" {
+		return ""
+	}
+	return strings.Join([]string{parts[0], parts[1], strings.TrimSuffix(parts[2], ".git")}, "/")
+}
+
+// pointsAtRepository reports whether any of the URLs names the target's
+// repository, which ties a registry package to the project
+func pointsAtRepository(target BootstrapTarget, urls ...string) bool {
+	want := repositoryKey(target.RepoURL)
+	if want == "" {
+		return false
+	}
+	for _, u := range urls {
+		if repositoryKey(u) == want {
+			return true
+		}
+	}
+	return false
+}
+
+// ownedByOrg reports whether a URL is under the org: a repository or page of
+// the org on a forge, or its GitHub Pages site
+func ownedByOrg(org, u string) bool {
+	parsed, err := url.Parse(strings.TrimSpace(u))
+	if err != nil || org == "" {
+		return false
+	}
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	if host == strings.ToLower(org)+".github.io" {
+		return true
+	}
+	owner, _, _ := strings.Cut(strings.Trim(parsed.Path, "/"), "/")
+	return (host == "github.com" || host == "gitlab.com") && strings.EqualFold(owner, org)
+}
+
+// mentionsRepository reports whether free text, such as an image's README,
+// links the target's repository
+func mentionsRepository(target BootstrapTarget, texts ...string) bool {
+	want := repositoryKey(target.RepoURL)
+	if want == "" {
+		return false
+	}
+	for _, text := range texts {
+		for _, field := range strings.FieldsFunc(text, func(r rune) bool {
+			return unicode.IsSpace(r) || strings.ContainsRune("()<>[]\"'`", r)
+		}) {
+			if field = strings.TrimRight(field, ".,;:!"); strings.Contains(field, "/") && repositoryKey(field) == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sourceBaseURL returns a source's base URL, or its default
+func sourceBaseURL(baseURL, fallback string) string {
+	if baseURL == "" {
+		baseURL = fallback
+	}
+	return strings.TrimSuffix(baseURL, "/")
+}
+
+// ScorecardSource reads the OpenSSF Scorecard results of the primary
+// repository and proposes them as an audits entry
+type ScorecardSource struct {
+	BaseURL string // Overrides the Scorecard API URL (use "" for default)
+}
+
+// Name implements BootstrapSource
+func (s *ScorecardSource) Name() string { return "scorecard" }
+
+// Lookup implements BootstrapSource
+func (s *ScorecardSource) Lookup(target BootstrapTarget, client *http.Client) (*SourceFindings, error) {
+	key := repositoryKey(target.RepoURL)
+	if key == "" {
+		return nil, nil
+	}
+	var result ScorecardResult
+	found, err := getJSON(client, "OpenSSF Scorecard", sourceBaseURL(s.BaseURL, defaultScorecardURL)+"/projects/"+key, nil, &result)
+	if err != nil || !found {
+		return nil, err
+	}
+	findings := &SourceFindings{Scorecard: &result}
+	if date, ok := parseScorecardDate(result.Date); ok {
+		findings.Audits = []Audit{{Date: date, Type: "openssf-scorecard", URL: scorecardViewerURL + key}}
+	}
+	return findings, nil
+}
+
+// parseScorecardDate reads the date of a Scorecard run, which is either a
+// day or a timestamp
+func parseScorecardDate(s string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// artifactHubKinds maps Artifact Hub repository kinds to package_managers
+// keys. Other kinds (policies, plugins, ...) are not package managers.
+var artifactHubKinds = map[int]string{0: "helm", 3: "olm"}
+
+// ArtifactHubSource finds the project's Helm charts and operators on
+// Artifact Hub. A package must carry the repository or slug name and be
+// published from the project's repository, or by the project's organization
+// (an unverified match).
+type ArtifactHubSource struct {
+	BaseURL string // Overrides the Artifact Hub URL (use "" for default)
+}
+
+// Name implements BootstrapSource
+func (s *ArtifactHubSource) Name() string { return "artifacthub" }
+
+// artifactHubSearch is the part of an Artifact Hub package search the
+// source reads
+type artifactHubSearch struct {
+	Packages []struct {
+		Name           string `json:"name"`
+		NormalizedName string `json:"normalized_name"`
+		Repository     struct {
+			Kind             int    `json:"kind"`
+			Name             string `json:"name"`
+			URL              string `json:"url"`
+			OrganizationName string `json:"organization_name"`
+		} `json:"repository"`
+	} `json:"packages"`
+}
+
+// Lookup implements BootstrapSource
+func (s *ArtifactHubSource) Lookup(target BootstrapTarget, client *http.Client) (*SourceFindings, error) {
+	names := target.packageNames()
+	if target.Org == "" || len(names) == 0 {
+		return nil, nil
+	}
+	packageManagers := make(map[string]string)
+	unverified := make(map[string]bool)
+	for _, name := range names {
+		var search artifactHubSearch
+		endpoint := fmt.Sprintf("%s/api/v1/packages/search?ts_query_web=%s&limit=60", sourceBaseURL(s.BaseURL, defaultArtifactHubURL), url.QueryEscape(name))
+		if _, err := getJSON(client, "Artifact Hub", endpoint, nil, &search); err != nil {
+			return nil, err
+		}
+		for _, p := range search.Packages {
+			kind, ok := artifactHubKinds[p.Repository.Kind]
+			if !ok || packageManagers[kind] != "" || !strings.EqualFold(p.NormalizedName, name) {
+				continue
+			}
+			switch {
+			case pointsAtRepository(target, p.Repository.URL):
+			case strings.EqualFold(p.Repository.OrganizationName, target.Org) || ownedByOrg(target.Org, p.Repository.URL):
+				unverified[kind] = true
+			default:
+				continue
+			}
+			packageManagers[kind] = p.Repository.Name + "/" + p.Name
+		}
+	}
+	if len(packageManagers) == 0 {
+		return nil, nil
+	}
+	return &SourceFindings{PackageManagers: packageManagers, Unverified: unverified}, nil
+}
+
+// NPMSource finds the project on the npm registry: a package named after
+// the repository, the slug or @org/repo whose repository is the project's
+type NPMSource struct {
+	BaseURL string // Overrides the npm registry URL (use "" for default)
+}
+
+// Name implements BootstrapSource
+func (s *NPMSource) Name() string { return "npm" }
+
+// Lookup implements BootstrapSource
+func (s *NPMSource) Lookup(target BootstrapTarget, client *http.Client) (*SourceFindings, error) {
+	names := target.packageNames()
+	if target.Org != "" && target.Repo != "" {
+		names = append(names, strings.ToLower("@"+target.Org+"/"+target.Repo))
+	}
+	for _, name := range names {
+		var pkg struct {
+			Name       string          `json:"name"`
+			Homepage   string          `json:"homepage"`
+			Repository json.RawMessage `json:"repository"` // A URL, or an object with one
+		}
+		found, err := getJSON(client, "npm", sourceBaseURL(s.BaseURL, defaultNPMURL)+"/"+url.PathEscape(name), nil, &pkg)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		var repository struct {
+			URL string `json:"url"`
+		}
+		if err := json.Unmarshal(pkg.Repository, &repository); err != nil {
+			json.Unmarshal(pkg.Repository, &repository.URL)
+		}
+		if pointsAtRepository(target, repository.URL, pkg.Homepage) {
+			return &SourceFindings{PackageManagers: map[string]string{"npm": pkg.Name}}, nil
+		}
+	}
+	return nil, nil
+}
+
+// PyPISource finds the project on PyPI: a package named after the
+// repository or the slug whose project URLs include the project's repository
+type PyPISource struct {
+	BaseURL string // Overrides the PyPI URL (use "" for default)
+}
+
+// Name implements BootstrapSource
+func (s *PyPISource) Name() string { return "pypi" }
+
+// Lookup implements BootstrapSource
+func (s *PyPISource) Lookup(target BootstrapTarget, client *http.Client) (*SourceFindings, error) {
+	for _, name := range target.packageNames() {
+		var pkg struct {
+			Info struct {
+				Name        string            `json:"name"`
+				HomePage    string            `json:"home_page"`
+				ProjectURLs map[string]string `json:"project_urls"`
+			} `json:"info"`
+		}
+		found, err := getJSON(client, "PyPI", sourceBaseURL(s.BaseURL, defaultPyPIURL)+"/pypi/"+url.PathEscape(name)+"/json", nil, &pkg)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		urls := []string{pkg.Info.HomePage}
+		for _, u := range pkg.Info.ProjectURLs {
+			urls = append(urls, u)
+		}
+		if pointsAtRepository(target, urls...) {
+			return &SourceFindings{PackageManagers: map[string]string{"pypi": pkg.Info.Name}}, nil
+		}
+	}
+	return nil, nil
+}
+
+// CratesSource finds the project on crates.io: a crate named after the
+// repository or the slug whose repository is the project's
+type CratesSource struct {
+	BaseURL string // Overrides the crates.io URL (use "" for default)
+}
+
+// Name implements BootstrapSource
+func (s *CratesSource) Name() string { return "crates" }
+
+// Lookup implements BootstrapSource
+func (s *CratesSource) Lookup(target BootstrapTarget, client *http.Client) (*SourceFindings, error) {
+	header := http.Header{"User-Agent": {registryUserAgent}}
+	for _, name := range target.packageNames() {
+		var pkg struct {
+			Crate struct {
+				Name       string `json:"name"`
+				Homepage   string `json:"homepage"`
+				Repository string `json:"repository"`
+			} `json:"crate"`
+		}
+		found, err := getJSON(client, "crates.io", sourceBaseURL(s.BaseURL, defaultCratesURL)+"/api/v1/crates/"+url.PathEscape(name), header, &pkg)
+		if err != nil {
+			return nil, err
+		}
+		if found && pointsAtRepository(target, pkg.Crate.Repository, pkg.Crate.Homepage) {
+			return &SourceFindings{PackageManagers: map[string]string{"crates": pkg.Crate.Name}}, nil
+		}
+	}
+	return nil, nil
+}
+
+// DockerHubSource finds the project's image in the org's namespace on Docker
+// Hub. Images carry no link to their source, so an image whose description
+// does not mention the repository is an unverified match.
+type DockerHubSource struct {
+	BaseURL string // Overrides the Docker Hub URL (use "" for default)
+}
+
+// Name implements BootstrapSource
+func (s *DockerHubSource) Name() string { return "docker" }
+
+// Lookup implements BootstrapSource
+func (s *DockerHubSource) Lookup(target BootstrapTarget, client *http.Client) (*SourceFindings, error) {
+	if target.Org == "" {
+		return nil, nil
+	}
+	namespace := strings.ToLower(target.Org)
+	for _, name := range target.packageNames() {
+		var image struct {
+			Description     string `json:"description"`
+			FullDescription string `json:"full_description"`
+		}
+		found, err := getJSON(client, "Docker Hub", fmt.Sprintf("%s/v2/repositories/%s/%s/", sourceBaseURL(s.BaseURL, defaultDockerHubURL), url.PathEscape(namespace), url.PathEscape(name)), nil, &image)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			linked := mentionsRepository(target, image.Description, image.FullDescription)
+			return &SourceFindings{PackageManagers: map[string]string{"docker": namespace + "/" + name}, Unverified: map[string]bool{"docker": !linked}}, nil
+		}
+	}
+	return nil, nil
+}
+
+// GHCRSource finds the project's image on the GitHub Container Registry,
+// where namespaces are GitHub owners. Public images are checked with an
+// anonymous pull token. The owner is the org, not necessarily the
+// repository, so matches are unverified.
+type GHCRSource struct {
+	BaseURL string // Overrides the ghcr.io URL (use "" for default)
+}
+
+// Name implements BootstrapSource
+func (s *GHCRSource) Name() string { return "ghcr" }
+
+// Lookup implements BootstrapSource
+func (s *GHCRSource) Lookup(target BootstrapTarget, client *http.Client) (*SourceFindings, error) {
+	if target.Org == "" {
+		return nil, nil
+	}
+	base := sourceBaseURL(s.BaseURL, defaultGHCRURL)
+	for _, name := range target.packageNames() {
+		image := strings.ToLower(target.Org) + "/" + name
+		var token struct {
+			Token string `json:"token"`
+		}
+		found, err := getJSON(client, "GHCR", base+"/token?scope="+url.QueryEscape("repository:"+image+":pull"), nil, &token)
+		if err != nil {
+			return nil, err
+		}
+		if !found || token.Token == "" {
+			continue
+		}
+		req, err := http.NewRequest(http.MethodGet, base+"/v2/"+image+"/tags/list", nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token.Token)
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		resp.Body.Close()
+		// Missing and private images are refused rather than not found
+		switch resp.StatusCode {
+		case http.StatusOK:
+			return &SourceFindings{PackageManagers: map[string]string{"ghcr": "ghcr.io/" + image}, Unverified: map[string]bool{"ghcr": true}}, nil
+		case http.StatusNotFound, http.StatusUnauthorized, http.StatusForbidden:
+		default:
+			return nil, fmt.Errorf("HTTP %d from %s", resp.StatusCode, req.URL)
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,265 @@
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// newFakeRegistries serves the registry, Artifact Hub and Scorecard APIs for
+// github.com/test-org/test-project
+func newFakeRegistries(t *testing.T) *httptest.Server {
+	t.Helper()
+	respond := func(w http.ResponseWriter, body interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(body)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects/github.com/test-org/test-project", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, map[string]interface{}{
+			"date":   "2026-09-01",
+			"score":  7.4,
+			"checks": []map[string]interface{}{{"name": "Code-Review", "score": 10, "reason": "all changes reviewed"}},
+		})
+	})
+	mux.HandleFunc("/api/v1/packages/search", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, map[string]interface{}{"packages": []map[string]interface{}{
+			// Same name, another publisher
+			{"name": "test-project", "normalized_name": "test-project", "repository": map[string]interface{}{"kind": 0, "name": "mirror", "url": "https://charts.example.com", "organization_name": "someone-else"}},
+			// Merely mentions the org in its URL
+			{"name": "test-project", "normalized_name": "test-project", "repository": map[string]interface{}{"kind": 0, "name": "fans", "url": "https://github.com/test-org-fans/charts", "organization_name": ""}},
+			// Published by the org (unverified) and from the repository itself
+			{"name": "test-project", "normalized_name": "test-project", "repository": map[string]interface{}{"kind": 0, "name": "test-charts", "url": "https://test-org.github.io/charts", "organization_name": ""}},
+			{"name": "test-project", "normalized_name": "test-project", "repository": map[string]interface{}{"kind": 3, "name": "test-operator", "url": "https://github.com/test-org/test-project", "organization_name": ""}},
+			// Policies are not package managers
+			{"name": "test-project", "normalized_name": "test-project", "repository": map[string]interface{}{"kind": 2, "name": "policies", "organization_name": "test-org"}},
+		}})
+	})
+	// npm: the bare name belongs to another project, the scoped one is ours
+	mux.HandleFunc("/test-project", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, map[string]interface{}{"name": "test-project", "repository": "github:unrelated/test-project"})
+	})
+	mux.HandleFunc("/@test-org%2Ftest-project", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, map[string]interface{}{"name": "@test-org/test-project", "repository": map[string]string{"type": "git", "url": "git+https://github.com/test-org/test-project.git"}})
+	})
+	mux.HandleFunc("/pypi/test-project/json", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, map[string]interface{}{"info": map[string]interface{}{"name": "test-project", "project_urls": map[string]string{"Source": "https://github.com/Test-Org/test-project/tree/main"}}})
+	})
+	mux.HandleFunc("/api/v1/crates/test-project", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != registryUserAgent {
+			http.Error(w, "user agent required", http.StatusForbidden)
+			return
+		}
+		respond(w, map[string]interface{}{"crate": map[string]string{"name": "test-project", "repository": "https://github.com/test-org/test-project"}})
+	})
+	mux.HandleFunc("/v2/repositories/test-org/test-project/", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, map[string]string{"name": "test-project", "namespace": "test-org", "full_description": "Source: [GitHub](https://github.com/test-org/test-project)."})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, map[string]string{"token": "anonymous:" + r.URL.Query().Get("scope")})
+	})
+	mux.HandleFunc("/v2/test-org/test-project/tags/list", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer anonymous:repository:test-org/test-project:pull" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		respond(w, map[string]interface{}{"tags": []string{"v1.0.0"}})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// fakeRegistrySources are every extra source, pointed at a fake server
+func fakeRegistrySources(baseURL string) []BootstrapSource {
+	return []BootstrapSource{
+		&ScorecardSource{BaseURL: baseURL},
+		&ArtifactHubSource{BaseURL: baseURL},
+		&NPMSource{BaseURL: baseURL},
+		&PyPISource{BaseURL: baseURL},
+		&CratesSource{BaseURL: baseURL},
+		&DockerHubSource{BaseURL: baseURL},
+		&GHCRSource{BaseURL: baseURL},
+	}
+}
+
+func TestBootstrapSources(t *testing.T) {
+	server := newFakeRegistries(t)
+	target := BootstrapTarget{Slug: "test-project", Org: "test-org", Repo: "test-project", RepoURL: "https://github.com/test-org/test-project"}
+
+	want := map[string]map[string]string{
+		"artifacthub": {"helm": "test-charts/test-project", "olm": "test-operator/test-project"},
+		"npm":         {"npm": "@test-org/test-project"},
+		"pypi":        {"pypi": "test-project"},
+		"crates":      {"crates": "test-project"},
+		"docker":      {"docker": "test-org/test-project"},
+		"ghcr":        {"ghcr": "ghcr.io/test-org/test-project"},
+	}
+	// Matched by the org alone rather than a link to the repository
+	unverified := map[string]bool{"helm": true, "ghcr": true}
+	for _, source := range fakeRegistrySources(server.URL) {
+		t.Run(source.Name(), func(t *testing.T) {
+			findings, err := source.Lookup(target, server.Client())
+			if err != nil || findings == nil {
+				t.Fatalf("Lookup() = %v, %v", findings, err)
+			}
+			if source.Name() == "scorecard" {
+				if findings.Scorecard == nil || findings.Scorecard.Score != 7.4 || len(findings.Scorecard.Checks) != 1 {
+					t.Errorf("unexpected Scorecard result %+v", findings.Scorecard)
+				}
+				if len(findings.Audits) != 1 || findings.Audits[0].Type != "openssf-scorecard" ||
+					findings.Audits[0].URL != "https://scorecard.dev/viewer/?uri=github.com/test-org/test-project" ||
+					findings.Audits[0].Date.Format("2006-01-02") != "2026-09-01" {
+					t.Errorf("unexpected proposed audits %+v", findings.Audits)
+				}
+				return
+			}
+			if len(findings.PackageManagers) != len(want[source.Name()]) {
+				t.Errorf("PackageManagers = %v, want %v", findings.PackageManagers, want[source.Name()])
+			}
+			for registry, id := range want[source.Name()] {
+				if findings.PackageManagers[registry] != id {
+					t.Errorf("PackageManagers[%s] = %q, want %q", registry, findings.PackageManagers[registry], id)
+				}
+				if findings.Unverified[registry] != unverified[registry] {
+					t.Errorf("Unverified[%s] = %v, want %v", registry, findings.Unverified[registry], unverified[registry])
+				}
+			}
+		})
+	}
+
+	// A project the sources do not know is not found, without an error
+	other := BootstrapTarget{Slug: "other", Org: "other-org", Repo: "other", RepoURL: "https://github.com/other-org/other"}
+	for _, source := range fakeRegistrySources(server.URL) {
+		if findings, err := source.Lookup(other, server.Client()); err != nil || findings != nil {
+			t.Errorf("%s: expected nothing for an unknown project, got %+v, %v", source.Name(), findings, err)
+		}
+	}
+}
+
+func TestDockerHubSourceUnlinked(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"name": "test-project", "full_description": "Mirror of github.com/test-org/other-project"})
+	}))
+	defer server.Close()
+
+	target := BootstrapTarget{Slug: "test-project", Org: "test-org", Repo: "test-project", RepoURL: "https://github.com/test-org/test-project"}
+	findings, err := (&DockerHubSource{BaseURL: server.URL}).Lookup(target, server.Client())
+	if err != nil || findings == nil || findings.PackageManagers["docker"] != "test-org/test-project" || !findings.Unverified["docker"] {
+		t.Fatalf("expected an unverified image, got %+v, %v", findings, err)
+	}
+	if got := findings.String(); got != "docker test-org/test-project (unverified)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestBootstrapSources_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	target := BootstrapTarget{Slug: "test-project", Org: "test-org", Repo: "test-project", RepoURL: "https://github.com/test-org/test-project"}
+	for _, source := range fakeRegistrySources(server.URL) {
+		if _, err := source.Lookup(target, server.Client()); err == nil || !strings.Contains(err.Error(), "HTTP 503") {
+			t.Errorf("%s: expected an HTTP 503 error, got %v", source.Name(), err)
+		}
+	}
+}
+
+func TestBootstrapProject_ExtraSources(t *testing.T) {
+	server := newFakeRegistries(t)
+	cfg := BootstrapConfig{ProjectName: "Test Project", GitHubOrg: "test-org", GitHubRepo: "test-project"}
+	opts := BootstrapOptions{SkipLandscape: true, SkipCLOMonitor: true, SkipGitHub: true, Extra: fakeRegistrySources(server.URL)}
+
+	result, errs := BootstrapProject(cfg, server.Client(), opts, t.Logf)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(result.PackageManagers) != 7 || result.Sources["package_managers.npm"] != "npm" || result.Sources["audits"] != "scorecard" {
+		t.Errorf("unexpected package managers %v from %v", result.PackageManagers, result.Sources)
+	}
+	if containsFold(result.TODOs, "Add package_managers if distributed via registries") {
+		t.Error("expected the package_managers TODO to be resolved")
+	}
+	for registry, want := range map[string]bool{"helm": true, "ghcr": true, "docker": false, "npm": false} {
+		field := "package_managers." + registry
+		todo := fmt.Sprintf("Confirm %s %q (matched by name, not by a link to the repository)", field, result.PackageManagers[registry])
+		if containsFold(result.TODOs, todo) != want {
+			t.Errorf("expected TODO %q: %v, got %v", todo, want, result.TODOs)
+		}
+		if c := result.Candidates[field]; len(c) != 1 || (c[0].Confidence < trustedMatchScore) != want {
+			t.Errorf("unexpected %s candidates %+v", field, c)
+		}
+	}
+
+	out, err := GenerateProjectYAML(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"package_managers:\n  crates: \"test-project\" # AUTO-DETECTED — please verify\n",
+		"  ghcr: \"ghcr.io/test-org/test-project\"",
+		"audits:\n  - date: \"2026-09-01T00:00:00Z\"\n    type: \"openssf-scorecard\"\n",
+		"# OpenSSF Scorecard: 7.4/10 (2026-09-01)",
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("expected %q in project.yaml:\n%s", want, out)
+		}
+	}
+	var project Project
+	if err := yaml.Unmarshal(out, &project); err != nil {
+		t.Fatalf("project.yaml does not parse: %v", err)
+	}
+	if project.PackageManagers["helm"] != "test-charts/test-project" || len(project.Audits) != 1 {
+		t.Errorf("unexpected parsed project %+v %+v", project.PackageManagers, project.Audits)
+	}
+
+	// A failing source is reported under its name and the others still apply
+	opts.Extra = append(opts.Extra, &NPMSource{BaseURL: "http://127.0.0.1:0"})
+	result, errs = BootstrapProject(cfg, server.Client(), opts, t.Logf)
+	if len(errs) != 1 || !strings.HasPrefix(errs[0].Error(), "npm: ") || result.PackageManagers["pypi"] == "" {
+		t.Errorf("expected one npm error and the other findings, got %v %v", errs, result.PackageManagers)
+	}
+}
+
+func TestNewBootstrapSources(t *testing.T) {
+	sources, err := NewBootstrapSources(BootstrapSourceNames)
+	if err != nil || len(sources) != len(BootstrapSourceNames) {
+		t.Fatalf("NewBootstrapSources() = %d sources, %v", len(sources), err)
+	}
+	for i, source := range sources {
+		if source.Name() != BootstrapSourceNames[i] {
+			t.Errorf("source %d is %q, want %q", i, source.Name(), BootstrapSourceNames[i])
+		}
+	}
+	if sources, err := NewBootstrapSources([]string{""}); err != nil || len(sources) != 0 {
+		t.Errorf("expected no sources for an empty list, got %d, %v", len(sources), err)
+	}
+	if _, err := NewBootstrapSources([]string{"maven"}); err == nil || !strings.Contains(err.Error(), `unknown bootstrap source "maven"`) {
+		t.Errorf("expected an unknown source error, got %v", err)
+	}
+}
+
+func TestRepositoryKey(t *testing.T) {
+	for _, tt := range []struct{ in, want string }{
+		{"https://github.com/test-org/test-project", "github.com/test-org/test-project"},
+		{"git+https://github.com/Test-Org/test-project.git", "github.com/test-org/test-project"},
+		{"git@github.com:test-org/test-project.git", "github.com/test-org/test-project"},
+		{"ssh://git@github.com/test-org/test-project", "github.com/test-org/test-project"},
+		{"github:test-org/test-project", "github.com/test-org/test-project"},
+		{"https://www.github.com/test-org/test-project/tree/main/sdk", "github.com/test-org/test-project"},
+		{"https://gitlab.com/group/project/", "gitlab.com/group/project"},
+		{"https://test-project.io", ""},
+		{"", ""},
+	} {
+		if got := repositoryKey(tt.in); got != tt.want {
+			t.Errorf("repositoryKey(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -11,13 +11,15 @@ import (
 )
 
 // projectYAMLTemplate is the template for generating project.yaml.
-// It produces valid YAML with TODO comments for fields that need manual input.
+// It produces valid YAML with TODO comments for fields that need manual input
+// and REVIEW comments for uncertain matches and conflicting sources.
 const projectYAMLTemplate = `# .project metadata for {{ .Name }}
 # Documentation: https://github.com/cncf/automation/tree/main/utilities/dot-project
 {{ range .TODOs }}
-# TODO: {{ . }}{{ end }}
+# TODO: {{ . }}{{ end }}{{ range .ReviewNotes }}
+# REVIEW: {{ . }}{{ end }}
 
-schema_version: "1.0.0"
+schema_version: "{{ schemaVersion }}"
 slug: "{{ .Slug }}"
 name: "{{ .Name }}"
 description: "{{ .Description }}"
@@ -35,7 +37,7 @@ maturity_log:
 repositories:{{ if .Repositories }}{{ range .Repositories }}
   - "{{ . }}"{{ end }}{{ else }}
   # TODO: Add repository URLs
-  - "https://github.com/{{ .GitHubOrg }}/{{ or .GitHubRepo .Slug }}"{{ end }}
+  - "{{ .RepoURL }}"{{ end }}
 {{ if .Website }}
 website: "{{ .Website }}"{{ else }}
 # TODO: Add project website
@@ -44,35 +46,44 @@ website: "{{ .Website }}"{{ else }}
 artwork: "{{ if .Artwork }}{{ .Artwork }}{{ else }}{{ artworkURL .Slug }}{{ end }}"
 {{ if .HasAdopters }}
 adopters:
-  path: "{{ githubFileURL .GitHubOrg (or .GitHubRepo .Slug) "" "ADOPTERS.md" }}"{{ else }}
+  path: "{{ .FileURL "ADOPTERS.md" }}"{{ else }}
 # TODO: Add ADOPTERS.md if your project tracks adopters
 # adopters:
-#   path: "{{ githubFileURL .GitHubOrg (or .GitHubRepo .Slug) "" "ADOPTERS.md" }}"{{ end }}
+#   path: "{{ .FileURL "ADOPTERS.md" }}"{{ end }}
 
+{{ if .PackageManagers }}
+package_managers:{{ range $registry, $id := .PackageManagers }}
+  {{ $registry }}: "{{ $id }}" # AUTO-DETECTED — please verify{{ end }}{{ else }}
 # TODO: Add package manager identifiers if your project is distributed via registries
 # package_managers:
-#   docker: "{{ .GitHubOrg }}/{{ or .GitHubRepo .Slug }}"
-{{ if .Social }}
+#   docker: "{{ .GitHubOrg }}/{{ or .GitHubRepo .Slug }}"{{ end }}
+{{ if .Audits }}
+audits:{{ range .Audits }}
+  - date: "{{ formatTime .Date }}"
+    type: "{{ .Type }}"
+    url: "{{ .URL }}" # AUTO-DETECTED — please verify{{ end }}
+{{ end }}{{ if .Social }}
 social:{{ range $platform, $url := .Social }}
   {{ $platform }}: "{{ $url }}"{{ end }}{{ end }}
 
 security:
   policy:
-    path: "{{ if .SecurityPolicyURL }}{{ .SecurityPolicyURL }}{{ else }}{{ githubFileURL .GitHubOrg (or .GitHubRepo .Slug) "" "SECURITY.md" }}{{ end }}"{{ if .SecurityContactURL }}
-  contact:
-    advisory_url: "{{ .SecurityContactURL }}"{{ else }}
-  contact:
-    advisory_url: "{{ githubAdvisoryURL .GitHubOrg (or .GitHubRepo .Slug) }}"{{ end }}
+    path: "{{ if .SecurityPolicyURL }}{{ .SecurityPolicyURL }}{{ else }}{{ .FileURL "SECURITY.md" }}{{ end }}"
+  contact:{{ if .SecurityContactURL }}
+    advisory_url: "{{ .SecurityContactURL }}"{{ else if .AdvisoryURL }}
+    advisory_url: "{{ .AdvisoryURL }}"{{ else }}
+    # TODO: Set a security contact email (the repository has no private vulnerability reporting)
+    email: "security@example.org"{{ end }}
 
 governance:
   contributing:
-    path: "{{ if .ContributingURL }}{{ .ContributingURL }}{{ else }}{{ githubFileURL .GitHubOrg (or .GitHubRepo .Slug) "" "CONTRIBUTING.md" }}{{ end }}"
+    path: "{{ if .ContributingURL }}{{ .ContributingURL }}{{ else }}{{ .FileURL "CONTRIBUTING.md" }}{{ end }}"
   code_of_conduct:
     path: "{{ if .CodeOfConductURL }}{{ .CodeOfConductURL }}{{ else }}https://github.com/cncf/foundation/blob/main/code-of-conduct.md{{ end }}"
 
 legal:
   license:
-    path: "{{ if .LicenseURL }}{{ .LicenseURL }}{{ else }}{{ githubFileURL .GitHubOrg (or .GitHubRepo .Slug) "" "LICENSE" }}{{ end }}"
+    path: "{{ if .LicenseURL }}{{ .LicenseURL }}{{ else }}{{ .FileURL "LICENSE" }}{{ end }}"
   identity_type:
 {{ if isAutoDetected .Sources "identity_type" }}    has_dco: {{ .HasDCO }} # AUTO-DETECTED — please verify
     has_cla: {{ .HasCLA }} # AUTO-DETECTED — please verify{{ else }}    has_dco: true
@@ -82,7 +93,7 @@ legal:
 {{ if .HasReadme }}
 documentation:
   readme:
-    path: "{{ githubFileURL .GitHubOrg (or .GitHubRepo .Slug) "" "README.md" }}"{{ end }}
+    path: "{{ .FileURL "README.md" }}"{{ end }}
 {{ if and .LandscapeCategory .LandscapeSubcategory }}
 landscape:
   category: "{{ .LandscapeCategory }}"
@@ -90,6 +101,8 @@ landscape:
 {{ if .CLOMonitorScore }}
 # CLOMonitor Score: {{ printf "%.0f" .CLOMonitorScore.Global }}/100
 # Documentation: {{ printf "%.0f" .CLOMonitorScore.Documentation }} | License: {{ printf "%.0f" .CLOMonitorScore.License }} | Best Practices: {{ printf "%.0f" .CLOMonitorScore.BestPractices }} | Security: {{ printf "%.0f" .CLOMonitorScore.Security }}{{ end }}
+{{ if .Scorecard }}
+# OpenSSF Scorecard: {{ printf "%.1f" .Scorecard.Score }}/10 ({{ .Scorecard.Date }}){{ end }}
 `
 
 // maintainersYAMLTemplate is the template for generating maintainers.yaml.
@@ -116,7 +129,7 @@ maintainers:
 // readmeTemplate generates the README.md for the .project directory.
 const readmeTemplate = `# {{ .Name }} ` + "`.project`" + ` Directory
 
-This directory contains the [CNCF ` + "`.project`" + ` metadata](https://github.com/cncf/automation/tree/main/utilities/dot-project) for the [{{ .Name }}]({{ or .Website (printf "https://github.com/%s/%s" .GitHubOrg (or .GitHubRepo .Slug)) }}) project.
+This directory contains the [CNCF ` + "`.project`" + ` metadata](https://github.com/cncf/automation/tree/main/utilities/dot-project) for the [{{ .Name }}]({{ or .Website .RepoURL }}) project.
 
 For documentation on the ` + "`.project`" + ` directory structure, schema, and tooling, see the [CNCF Automation repository](https://github.com/cncf/automation).
 `
@@ -128,11 +141,11 @@ const securityMDTemplate = `# Security Policy
 
 The {{ .Name }} maintainers take security seriously. We appreciate your efforts to responsibly disclose your findings.
 
-**Please do not report security vulnerabilities through public GitHub issues.**
+**Please do not report security vulnerabilities through public {{ .ForgeTitle }} issues.**
 
-Instead, please report them through our [private vulnerability reporting]({{ githubAdvisoryURL .GitHubOrg (or .GitHubRepo .Slug) }}) form.
+Instead, please report them {{ if .AdvisoryURL }}through our [private vulnerability reporting]({{ .AdvisoryURL }}) form{{ else }}by email to the security contact listed in project.yaml{{ end }}.
 
-For more details, see the [{{ .Name }} security policy]({{ githubFileURL .GitHubOrg (or .GitHubRepo .Slug) "" "SECURITY.md" }}).
+For more details, see the [{{ .Name }} security policy]({{ .FileURL "SECURITY.md" }}).
 `
 
 // codeownersTemplate generates the CODEOWNERS file.
@@ -219,6 +232,7 @@ jobs:
 
 // templateFuncs provides helper functions for templates.
 var templateFuncs = template.FuncMap{
+	"schemaVersion": LatestSchemaVersion,
 	"formatTime": func(t time.Time) string {
 		if t.IsZero() {
 			return time.Now().Format("2006-01-02T15:04:05Z")
@@ -231,21 +245,6 @@ var templateFuncs = template.FuncMap{
 		}
 		return b
 	},
-	"githubFileURL": func(org, repo, branch, path string) string {
-		if org == "" || repo == "" {
-			return path // fallback to relative if no org/repo
-		}
-		if branch == "" {
-			branch = "main"
-		}
-		return fmt.Sprintf("https://github.com/%s/%s/blob/%s/%s", org, repo, branch, path)
-	},
-	"githubAdvisoryURL": func(org, repo string) string {
-		if org == "" || repo == "" {
-			return ""
-		}
-		return fmt.Sprintf("https://github.com/%s/%s/security/advisories/new", org, repo)
-	},
 	"artworkURL": func(slug string) string {
 		return fmt.Sprintf("https://github.com/cncf/artwork/tree/master/projects/%s", slug)
 	},
@@ -258,6 +257,50 @@ var templateFuncs = template.FuncMap{
 	},
 }
 
+// forge returns the forge hosting the project's repository
+func (r *BootstrapResult) forge() Forge {
+	forge, err := NewForge(r.Forge, nil, r.ForgeURL, "")
+	if err != nil {
+		return NewGitHubForge(nil, "", "")
+	}
+	return forge
+}
+
+// ForgeTitle is the display name of the forge hosting the repository
+func (r *BootstrapResult) ForgeTitle() string { return r.forge().Title() }
+
+// repo is the primary repository's name, defaulting to the slug
+func (r *BootstrapResult) repo() string {
+	if r.GitHubRepo != "" {
+		return r.GitHubRepo
+	}
+	return r.Slug
+}
+
+// RepoURL is the web URL of the primary repository
+func (r *BootstrapResult) RepoURL() string {
+	return r.forge().RepoURL(r.GitHubOrg, r.repo())
+}
+
+// FileURL is the web URL of a file of the primary repository's main branch,
+// or the relative path when the org is unknown
+func (r *BootstrapResult) FileURL(path string) string {
+	if r.GitHubOrg == "" {
+		return path
+	}
+	return r.forge().FileURL(r.GitHubOrg, r.repo(), "main", path)
+}
+
+// AdvisoryURL is where vulnerabilities in the primary repository are
+// reported privately; "" when the org is unknown or the forge has no
+// private reporting
+func (r *BootstrapResult) AdvisoryURL() string {
+	if r.GitHubOrg == "" {
+		return ""
+	}
+	return r.forge().AdvisoryURL(r.GitHubOrg, r.repo())
+}
+
 // GenerateProjectYAML produces the project.yaml content from a BootstrapResult.
 func GenerateProjectYAML(result *BootstrapResult) ([]byte, error) {
 	tmpl, err := template.New("project").Funcs(templateFuncs).Parse(projectYAMLTemplate)
@@ -318,8 +361,9 @@ func GenerateMaintainersYAML(result *BootstrapResult) ([]byte, error) {
 }
 
 // WriteScaffold writes the complete .project scaffold (8 files) to the
-// specified directory. It will not overwrite existing project.yaml or
-// maintainers.yaml files.
+// specified directory, with copies of project.yaml and maintainers.yaml in
+// .bootstrap/ for RefreshScaffold. It will not overwrite existing
+// project.yaml or maintainers.yaml files.
 func WriteScaffold(dir string, result *BootstrapResult) error {
 	// Helper: generate content from a Go template string
 	tmplGen := func(tmplName, tmplContent string) func() ([]byte, error) {
@@ -361,6 +405,9 @@ func WriteScaffold(dir string, result *BootstrapResult) error {
 		{".gitignore", staticGen(gitignoreContent)},
 		{".github/workflows/validate.yaml", staticGen(validateWorkflowContent)},
 		{".github/workflows/update-landscape.yml", staticGen(updateLandscapeWorkflowContent)},
+		// Merge base for a later refresh
+		{bootstrapBaseDir + "/project.yaml", func() ([]byte, error) { return GenerateProjectYAML(result) }},
+		{bootstrapBaseDir + "/maintainers.yaml", func() ([]byte, error) { return GenerateMaintainersYAML(result) }},
 	}
 
 	// Conditional: SECURITY.md — skip if an existing security policy was discovered
@@ -62,6 +62,16 @@ func fetchFromCLOMonitor(name string, client *http.Client, baseURL string) (*CLO
 		return nil, nil
 	}
 
+	// An exact slug match on the CLOMonitor name wins over fuzzy matching
+	slug := BootstrapSlug(name)
+	for i, p := range cncfProjects {
+		if slug != "" && (p.Name == slug || BootstrapSlug(p.DisplayName) == slug) {
+			cncfProjects[i].MatchScore = 1.0
+			cncfProjects[i].ExactSlugMatch = true
+			return &cncfProjects[i], nil
+		}
+	}
+
 	// Fuzzy match by display name
 	var candidates []string
 	for _, p := range cncfProjects {
@@ -75,6 +85,7 @@ func fetchFromCLOMonitor(name string, client *http.Client, baseURL string) (*CLO
 
 	for i, p := range cncfProjects {
 		if p.DisplayName == best {
+			cncfProjects[i].MatchScore = score
 			return &cncfProjects[i], nil
 		}
 	}
@@ -168,8 +179,18 @@ func fetchFromLandscape(name string, client *http.Client, baseURL string) (*Land
 		return nil, nil
 	}
 
-	// Fuzzy match by name
-	best, score := fuzzyMatch(name, names)
+	// An exact slug match wins over fuzzy matching
+	best, score, exactSlug := "", 0.0, false
+	slug := BootstrapSlug(name)
+	for _, candidate := range names {
+		if slug != "" && BootstrapSlug(candidate) == slug {
+			best, score, exactSlug = candidate, 1.0, true
+			break
+		}
+	}
+	if !exactSlug {
+		best, score = fuzzyMatch(name, names)
+	}
 	if score == 0 {
 		return nil, nil
 	}
@@ -204,6 +225,8 @@ func fetchFromLandscape(name string, client *http.Client, baseURL string) (*Land
 				AcceptedDate:      acceptedDate,
 				AnnualReviewURL:   annualReviewURL,
 				PackageManagerURL: packageManagerURL,
+				MatchScore:        score,
+				ExactSlugMatch:    exactSlug,
 			}, nil
 		}
 	}
@@ -216,8 +239,10 @@ func FetchFromLandscape(name string, client *http.Client, baseURL string) (*Land
 	return fetchFromLandscape(name, client, baseURL)
 }
 
-// GitHubData holds all data fetched from the GitHub API for a single repo.
+// GitHubData holds all data fetched from the GitHub API (or another forge)
+// for a single repo.
 type GitHubData struct {
+	Forge       string                  `json:"forge,omitempty"` // Forge name; "" means GitHub
 	Repo        *GitHubRepoData         `json:"repo"`
 	Org         *GitHubOrgData          `json:"org"`
 	Community   *GitHubCommunityProfile `json:"community"`
@@ -240,62 +265,26 @@ type GitHubData struct {
 // discovers CODEOWNERS/OWNERS/MAINTAINERS files from the GitHub API.
 // baseURL overrides the GitHub API URL (use "" for default).
 func fetchFromGitHub(org, repo, token string, client *http.Client, baseURL string) (*GitHubData, error) {
-	if baseURL == "" {
-		baseURL = defaultGitHubAPIURL
-	}
-
-	result := &GitHubData{}
+	return fetchFromForge(NewGitHubForge(client, baseURL, token), org, repo)
+}
 
-	// Helper to make authenticated requests
-	doGet := func(path string) (*http.Response, error) {
-		req, err := http.NewRequest("GET", baseURL+path, nil)
-		if err != nil {
-			return nil, err
-		}
-		if token != "" {
-			req.Header.Set("Authorization", "token "+token)
-		}
-		req.Header.Set("Accept", "application/vnd.github.v3+json")
-		return client.Do(req)
-	}
+// fetchFromForge fetches repository, organization and community files, and
+// discovers CODEOWNERS/OWNERS/MAINTAINERS files, from a forge
+func fetchFromForge(forge Forge, org, repo string) (*GitHubData, error) {
+	result := &GitHubData{Forge: forge.Name()}
 
-	// Fetch repo data
-	resp, err := doGet(fmt.Sprintf("/repos/%s/%s", org, repo))
+	repoData, err := forge.Repository(org, repo)
 	if err != nil {
-		return nil, fmt.Errorf("GitHub repo request failed: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GitHub repo returned HTTP %d for %s/%s", resp.StatusCode, org, repo)
-	}
-
-	var repoData GitHubRepoData
-	if err := json.NewDecoder(resp.Body).Decode(&repoData); err != nil {
-		return nil, fmt.Errorf("parsing GitHub repo response: %w", err)
-	}
-	result.Repo = &repoData
+	result.Repo = repoData
 
-	// Fetch org data (non-fatal if fails)
-	if resp, err := doGet(fmt.Sprintf("/orgs/%s", org)); err == nil {
-		defer resp.Body.Close()
-		if resp.StatusCode == http.StatusOK {
-			var orgData GitHubOrgData
-			if err := json.NewDecoder(resp.Body).Decode(&orgData); err == nil {
-				result.Org = &orgData
-			}
-		}
+	// Org data and community files are non-fatal if they fail
+	if orgData, err := forge.Owner(org); err == nil {
+		result.Org = orgData
 	}
-
-	// Fetch community profile (non-fatal if fails)
-	if resp, err := doGet(fmt.Sprintf("/repos/%s/%s/community/profile", org, repo)); err == nil {
-		defer resp.Body.Close()
-		if resp.StatusCode == http.StatusOK {
-			var community GitHubCommunityProfile
-			if err := json.NewDecoder(resp.Body).Decode(&community); err == nil {
-				result.Community = &community
-			}
-		}
+	if community, err := forge.CommunityFiles(org, repo); err == nil {
+		result.Community = community
 	}
 
 	// Extract file URLs from community profile
@@ -311,11 +300,11 @@ func fetchFromGitHub(org, repo, token string, client *http.Client, baseURL strin
 		}
 	}
 
-	// Discover governance files from repo root, .github/ dir, and org .github repo
-	discoverGovernanceFiles(result, org, repo, doGet, client)
+	// Discover governance files from the forge's governance locations
+	discoverGovernanceFiles(result, forge, org, repo)
 
-	// Detect DCO/CLA from commit messages and .github config files
-	hasDCO, hasCLA, _ := detectDCOCLA(org, repo, token, client, baseURL)
+	// Detect DCO/CLA from commit messages and forge config files
+	hasDCO, hasCLA, _ := detectForgeDCOCLA(forge, org, repo)
 	result.HasDCO = hasDCO
 	result.HasCLA = hasCLA
 
@@ -374,36 +363,20 @@ var governanceFiles = []governanceFile{
 	},
 }
 
-// discoverGovernanceFiles looks for CODEOWNERS, OWNERS, MAINTAINERS in the repo root,
-// .github/ subdirectory, and the org-level .github repo.
-func discoverGovernanceFiles(result *GitHubData, org, repo string, doGet func(string) (*http.Response, error), client *http.Client) {
-	// Locations to search, in order of priority
-	contentPaths := []string{
-		fmt.Sprintf("/repos/%s/%s/contents/", org, repo),
-		fmt.Sprintf("/repos/%s/%s/contents/.github", org, repo),
-		fmt.Sprintf("/repos/%s/.github/contents/", org),
-	}
-
-	for _, contentPath := range contentPaths {
-		resp, err := doGet(contentPath)
-		if err != nil || resp.StatusCode != http.StatusOK {
-			if resp != nil {
-				resp.Body.Close()
-			}
-			continue
-		}
-
-		var entries []GitHubContentEntry
-		if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
-			resp.Body.Close()
+// discoverGovernanceFiles looks for CODEOWNERS, OWNERS, MAINTAINERS in the
+// forge's governance locations: for GitHub, the repo root, .github/
+// subdirectory, and the org-level .github repo.
+func discoverGovernanceFiles(result *GitHubData, forge Forge, org, repo string) {
+	for _, location := range forge.GovernanceLocations(org, repo) {
+		entries, err := forge.ListDir(location.Owner, location.Repo, location.Dir)
+		if err != nil {
 			continue
 		}
-		resp.Body.Close()
 
 		for _, entry := range entries {
 			for _, gf := range governanceFiles {
 				if strings.EqualFold(entry.Name, gf.name) && entry.Type == "file" && entry.DownloadURL != "" {
-					content, err := fetchFileContent(client, entry.DownloadURL)
+					content, err := forge.ReadFile(entry)
 					if err == nil && content != "" {
 						gf.parseFunc(result, content, entry.HTMLURL)
 					}
@@ -483,6 +456,10 @@ type LandscapeData struct {
 	AcceptedDate      string `json:"accepted_date,omitempty"`
 	AnnualReviewURL   string `json:"annual_review_url,omitempty"`
 	PackageManagerURL string `json:"package_manager_url,omitempty"`
+
+	// How the project was matched by name
+	MatchScore     float64 `json:"match_score,omitempty"`
+	ExactSlugMatch bool    `json:"exact_slug_match,omitempty"`
 }
 
 // FetchFromCLOMonitor is the exported wrapper for fetchFromCLOMonitor.
@@ -559,67 +536,39 @@ func fuzzyMatch(query string, candidates []string) (best string, score float64)
 // .github/ directory for CLA config files.
 // Returns (hasDCO, hasCLA, error). Errors are non-fatal; returns (false, false, nil) on failure.
 func detectDCOCLA(org, repo, token string, client *http.Client, baseURL string) (bool, bool, error) {
-	if baseURL == "" {
-		baseURL = defaultGitHubAPIURL
-	}
-
-	doGet := func(path string) (*http.Response, error) {
-		req, err := http.NewRequest("GET", baseURL+path, nil)
-		if err != nil {
-			return nil, err
-		}
-		if token != "" {
-			req.Header.Set("Authorization", "token "+token)
-		}
-		req.Header.Set("Accept", "application/vnd.github.v3+json")
-		return client.Do(req)
-	}
+	return detectForgeDCOCLA(NewGitHubForge(client, baseURL, token), org, repo)
+}
 
+// detectForgeDCOCLA is detectDCOCLA for any forge, looking for CLA config
+// files in the forge's config directory
+func detectForgeDCOCLA(forge Forge, org, repo string) (bool, bool, error) {
 	var hasDCO, hasCLA bool
 
 	// Check recent commits for DCO (Signed-off-by)
-	resp, err := doGet(fmt.Sprintf("/repos/%s/%s/commits?per_page=20", org, repo))
-	if err == nil && resp.StatusCode == http.StatusOK {
-		var commits []struct {
-			Commit struct {
-				Message string `json:"message"`
-			} `json:"commit"`
-		}
-		if err := json.NewDecoder(resp.Body).Decode(&commits); err == nil {
-			signedCount := 0
-			for _, c := range commits {
-				if strings.Contains(c.Commit.Message, "Signed-off-by:") {
-					signedCount++
-				}
-			}
-			// If >50% of commits have Signed-off-by, likely using DCO
-			if len(commits) > 0 && float64(signedCount)/float64(len(commits)) > 0.5 {
-				hasDCO = true
+	if messages, err := forge.CommitMessages(org, repo, 20); err == nil {
+		signedCount := 0
+		for _, message := range messages {
+			if strings.Contains(message, "Signed-off-by:") {
+				signedCount++
 			}
 		}
-		resp.Body.Close()
-	} else if resp != nil {
-		resp.Body.Close()
+		// If >50% of commits have Signed-off-by, likely using DCO
+		if len(messages) > 0 && float64(signedCount)/float64(len(messages)) > 0.5 {
+			hasDCO = true
+		}
 	}
 
-	// Check for CLA config files in .github/
+	// Check for CLA config files in the config directory
 	claFiles := []string{"cla.yml", "cla.yaml", ".clabot", "clabot.config"}
-	resp, err = doGet(fmt.Sprintf("/repos/%s/%s/contents/.github", org, repo))
-	if err == nil && resp.StatusCode == http.StatusOK {
-		var entries []GitHubContentEntry
-		if err := json.NewDecoder(resp.Body).Decode(&entries); err == nil {
-			for _, entry := range entries {
-				for _, claFile := range claFiles {
-					if strings.EqualFold(entry.Name, claFile) {
-						hasCLA = true
-						break
-					}
+	if entries, err := forge.ListDir(org, repo, forge.ConfigDir()); err == nil {
+		for _, entry := range entries {
+			for _, claFile := range claFiles {
+				if strings.EqualFold(entry.Name, claFile) {
+					hasCLA = true
+					break
 				}
 			}
 		}
-		resp.Body.Close()
-	} else if resp != nil {
-		resp.Body.Close()
 	}
 
 	return hasDCO, hasCLA, nil
@@ -690,7 +639,12 @@ func mergeBootstrapData(slug string, landscape *LandscapeData, clomonitor *CLOMo
 		Sources: make(map[string]string),
 	}
 
-	// GitHub context for scaffold generation (org/repo)
+	// Forge context for scaffold generation (org/repo). The forge's name
+	// labels the values it offers.
+	forgeSource := "github"
+	if github != nil && github.Forge != "" {
+		forgeSource = github.Forge
+	}
 	if github != nil && github.Org != nil {
 		result.GitHubOrg = github.Org.Login
 	}
@@ -698,97 +652,130 @@ func mergeBootstrapData(slug string, landscape *LandscapeData, clomonitor *CLOMo
 		result.GitHubRepo = github.Repo.Name
 	}
 
-	// Name: landscape > clomonitor > github > slug
-	if landscape != nil && landscape.Name != "" {
-		result.Name = landscape.Name
-		result.Sources["name"] = "landscape"
-	} else if clomonitor != nil && clomonitor.DisplayName != "" {
-		result.Name = clomonitor.DisplayName
-		result.Sources["name"] = "clomonitor"
-	} else if github != nil && github.Repo != nil && github.Repo.Name != "" {
-		result.Name = github.Repo.Name
-		result.Sources["name"] = "github"
+	// Each source's values are as trustworthy as its match on the project name
+	var landscapeConfidence, clomonitorConfidence float64
+	if landscape != nil {
+		landscapeConfidence = matchConfidence(landscape.MatchScore, landscape.ExactSlugMatch)
+		result.Matches = append(result.Matches, SourceMatch{Source: "landscape", Matched: landscape.Name, Score: landscape.MatchScore, ExactSlug: landscape.ExactSlugMatch})
+	}
+	if clomonitor != nil {
+		clomonitorConfidence = matchConfidence(clomonitor.MatchScore, clomonitor.ExactSlugMatch)
+		result.Matches = append(result.Matches, SourceMatch{Source: "clomonitor", Matched: clomonitor.DisplayName, Score: clomonitor.MatchScore, ExactSlug: clomonitor.ExactSlugMatch})
+	}
+	fromLandscape := func(value func(*LandscapeData) string) FieldCandidate {
+		if landscape == nil {
+			return FieldCandidate{}
+		}
+		return FieldCandidate{Source: "landscape", Value: value(landscape), Confidence: landscapeConfidence}
+	}
+	fromCLOMonitor := func(value func(*CLOMonitorProject) string) FieldCandidate {
+		if clomonitor == nil {
+			return FieldCandidate{}
+		}
+		return FieldCandidate{Source: "clomonitor", Value: value(clomonitor), Confidence: clomonitorConfidence}
+	}
+	// GitHub is looked up by the given org and repo, not by name
+	fromGitHub := func(value func(*GitHubData) string) FieldCandidate {
+		if github == nil {
+			return FieldCandidate{}
+		}
+		return FieldCandidate{Source: forgeSource, Value: value(github), Confidence: 1.0}
+	}
+	githubRepo := func(value func(*GitHubRepoData) string) func(*GitHubData) string {
+		return func(g *GitHubData) string {
+			if g.Repo == nil {
+				return ""
+			}
+			return value(g.Repo)
+		}
+	}
+
+	// Name: landscape > clomonitor > github > slug. Repository names often
+	// differ from project names, so GitHub only fills a missing name.
+	if c, ok := chooseField(result, "name",
+		fromLandscape(func(l *LandscapeData) string { return l.Name }),
+		fromCLOMonitor(func(c *CLOMonitorProject) string { return c.DisplayName })); ok {
+		result.Name = c.Value
+	} else if c, ok := chooseField(result, "name", fromGitHub(githubRepo(func(r *GitHubRepoData) string { return r.Name }))); ok {
+		result.Name = c.Value
 	} else {
 		result.Name = slug
 		result.Sources["name"] = "slug"
 	}
 
 	// Description: landscape > clomonitor > github
-	if landscape != nil && landscape.Description != "" {
-		result.Description = landscape.Description
-		result.Sources["description"] = "landscape"
-	} else if clomonitor != nil && clomonitor.Description != "" {
-		result.Description = clomonitor.Description
-		result.Sources["description"] = "clomonitor"
-	} else if github != nil && github.Repo != nil && github.Repo.Description != "" {
-		result.Description = github.Repo.Description
-		result.Sources["description"] = "github"
+	if c, ok := chooseField(result, "description",
+		fromLandscape(func(l *LandscapeData) string { return l.Description }),
+		fromCLOMonitor(func(c *CLOMonitorProject) string { return c.Description }),
+		fromGitHub(githubRepo(func(r *GitHubRepoData) string { return r.Description }))); ok {
+		result.Description = c.Value
 	}
 
 	// Website: landscape > clomonitor > github repo homepage
-	if landscape != nil && landscape.HomepageURL != "" {
-		result.Website = landscape.HomepageURL
-		result.Sources["website"] = "landscape"
-	} else if clomonitor != nil && clomonitor.HomeURL != "" {
-		result.Website = clomonitor.HomeURL
-		result.Sources["website"] = "clomonitor"
-	} else if github != nil && github.Repo != nil && github.Repo.Homepage != "" {
-		result.Website = github.Repo.Homepage
-		result.Sources["website"] = "github"
+	if c, ok := chooseField(result, "website",
+		fromLandscape(func(l *LandscapeData) string { return l.HomepageURL }),
+		fromCLOMonitor(func(c *CLOMonitorProject) string { return c.HomeURL }),
+		fromGitHub(githubRepo(func(r *GitHubRepoData) string { return r.Homepage }))); ok {
+		result.Website = c.Value
 	}
 
 	// Repositories: landscape repo > clomonitor repos > github repo URL
-	if landscape != nil && landscape.RepoURL != "" {
-		result.Repositories = []string{landscape.RepoURL}
-		result.Sources["repositories"] = "landscape"
-	} else if clomonitor != nil && len(clomonitor.Repositories) > 0 {
-		for _, r := range clomonitor.Repositories {
-			if r.URL != "" {
-				result.Repositories = append(result.Repositories, r.URL)
+	if c, ok := chooseField(result, "repositories",
+		fromLandscape(func(l *LandscapeData) string { return l.RepoURL }),
+		fromCLOMonitor(func(c *CLOMonitorProject) string {
+			var urls []string
+			for _, r := range c.Repositories {
+				if r.URL != "" {
+					urls = append(urls, r.URL)
+				}
 			}
-		}
-		result.Sources["repositories"] = "clomonitor"
-	} else if github != nil && github.Repo != nil && github.Repo.HTMLURL != "" {
-		result.Repositories = []string{github.Repo.HTMLURL}
-		result.Sources["repositories"] = "github"
+			return strings.Join(urls, ", ")
+		}),
+		fromGitHub(githubRepo(func(r *GitHubRepoData) string { return r.HTMLURL }))); ok {
+		result.Repositories = strings.Split(c.Value, ", ")
 	}
 
 	// Maturity: landscape > clomonitor
-	if landscape != nil && landscape.Maturity != "" {
-		result.MaturityPhase = landscape.Maturity
-		result.Sources["maturity"] = "landscape"
-	} else if clomonitor != nil && clomonitor.Maturity != "" {
-		result.MaturityPhase = clomonitor.Maturity
-		result.Sources["maturity"] = "clomonitor"
+	if c, ok := chooseField(result, "maturity",
+		fromLandscape(func(l *LandscapeData) string { return l.Maturity }),
+		fromCLOMonitor(func(c *CLOMonitorProject) string { return c.Maturity })); ok {
+		result.MaturityPhase = c.Value
 	}
 
-	// Landscape category/subcategory
-	if landscape != nil {
-		result.LandscapeCategory = landscape.Category
-		result.LandscapeSubcategory = landscape.Subcategory
-		result.Sources["landscape_category"] = "landscape"
-	} else if clomonitor != nil {
-		result.LandscapeCategory = clomonitor.Category
-		result.LandscapeSubcategory = clomonitor.Subcategory
-		result.Sources["landscape_category"] = "clomonitor"
+	// Landscape category/subcategory, chosen together as "category / subcategory"
+	categoryOf := func(category, subcategory string) string {
+		if category == "" || subcategory == "" {
+			return category
+		}
+		return category + " / " + subcategory
+	}
+	if c, ok := chooseField(result, "landscape_category",
+		fromLandscape(func(l *LandscapeData) string { return categoryOf(l.Category, l.Subcategory) }),
+		fromCLOMonitor(func(c *CLOMonitorProject) string { return categoryOf(c.Category, c.Subcategory) })); ok {
+		if c.Source == "landscape" {
+			result.LandscapeCategory, result.LandscapeSubcategory = landscape.Category, landscape.Subcategory
+		} else {
+			result.LandscapeCategory, result.LandscapeSubcategory = clomonitor.Category, clomonitor.Subcategory
+		}
 	}
 
-	// Artwork: landscape logo
-	if landscape != nil && landscape.LogoURL != "" {
-		result.Artwork = landscape.LogoURL
-		result.Sources["artwork"] = "landscape"
-	} else if clomonitor != nil && clomonitor.LogoURL != "" {
-		result.Artwork = clomonitor.LogoURL
-		result.Sources["artwork"] = "clomonitor"
+	// Artwork: landscape logo > clomonitor logo
+	if c, ok := chooseField(result, "artwork",
+		fromLandscape(func(l *LandscapeData) string { return l.LogoURL }),
+		fromCLOMonitor(func(c *CLOMonitorProject) string { return c.LogoURL })); ok {
+		result.Artwork = c.Value
 	}
 
 	// Social: twitter from landscape or github org
-	if landscape != nil && landscape.Twitter != "" {
-		result.Social["twitter"] = landscape.Twitter
-		result.Sources["social.twitter"] = "landscape"
-	} else if github != nil && github.Org != nil && github.Org.TwitterUser != "" {
-		result.Social["twitter"] = "https://twitter.com/" + github.Org.TwitterUser
-		result.Sources["social.twitter"] = "github"
+	if c, ok := chooseField(result, "social.twitter",
+		fromLandscape(func(l *LandscapeData) string { return l.Twitter }),
+		fromGitHub(func(g *GitHubData) string {
+			if g.Org == nil || g.Org.TwitterUser == "" {
+				return ""
+			}
+			return "https://twitter.com/" + g.Org.TwitterUser
+		})); ok {
+		result.Social["twitter"] = c.Value
 	}
 
 	// Slack channel: landscape extra.chat_channel > derived from extra.slack_url
@@ -842,26 +829,26 @@ func mergeBootstrapData(slug string, landscape *LandscapeData, clomonitor *CLOMo
 		result.HasDCO = github.HasDCO
 		result.HasCLA = github.HasCLA
 		if github.HasDCO || github.HasCLA {
-			result.Sources["identity_type"] = "github"
+			result.Sources["identity_type"] = forgeSource
 		}
 
 		// Discovered file URLs
 		if github.SecurityPolicyURL != "" {
 			result.SecurityPolicyURL = github.SecurityPolicyURL
 			result.HasSecurityPolicy = true
-			result.Sources["security_policy"] = "github"
+			result.Sources["security_policy"] = forgeSource
 		}
 		if github.ContributingURL != "" {
 			result.ContributingURL = github.ContributingURL
-			result.Sources["contributing"] = "github"
+			result.Sources["contributing"] = forgeSource
 		}
 		if github.CodeOfConductURL != "" {
 			result.CodeOfConductURL = github.CodeOfConductURL
-			result.Sources["code_of_conduct"] = "github"
+			result.Sources["code_of_conduct"] = forgeSource
 		}
 		if github.LicenseURL != "" {
 			result.LicenseURL = github.LicenseURL
-			result.Sources["license"] = "github"
+			result.Sources["license"] = forgeSource
 		}
 	}
 
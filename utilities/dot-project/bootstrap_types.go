@@ -7,7 +7,7 @@ type BootstrapConfig struct {
 	ProjectName string // Display name to search for (e.g., "Kubernetes")
 	GitHubOrg   string // GitHub organization (e.g., "kubernetes")
 	GitHubRepo  string // Primary repository name (e.g., "kubernetes")
-	GitHubToken string // GitHub personal access token (optional but recommended)
+	GitHubToken string // Personal access token of the forge (optional but recommended)
 	OutputDir   string // Directory to write scaffold output
 }
 
@@ -19,9 +19,12 @@ type BootstrapResult struct {
 	Name        string `json:"name" yaml:"name"`
 	Description string `json:"description" yaml:"description"`
 
-	// GitHub context (used for scaffold generation)
+	// Repository context (used for scaffold generation). The org and repo
+	// are on the forge, GitHub unless set.
 	GitHubOrg  string `json:"github_org,omitempty" yaml:"github_org,omitempty"`
 	GitHubRepo string `json:"github_repo,omitempty" yaml:"github_repo,omitempty"`
+	Forge      string `json:"forge,omitempty" yaml:"forge,omitempty"`         // "gitlab" or "gitea"; "" means GitHub
+	ForgeURL   string `json:"forge_url,omitempty" yaml:"forge_url,omitempty"` // GitLab or Gitea instance URL; "" for the public instance
 
 	// URLs
 	Website      string            `json:"website,omitempty" yaml:"website,omitempty"`
@@ -48,6 +51,12 @@ type BootstrapResult struct {
 	// CLOMonitor scores (informational, included as YAML comments)
 	CLOMonitorScore *CLOMonitorScore `json:"clomonitor_score,omitempty" yaml:"clomonitor_score,omitempty"`
 
+	// Additional sources: registry identifiers, proposed audits and the
+	// OpenSSF Scorecard run (informational, included as a YAML comment)
+	PackageManagers map[string]string `json:"package_managers,omitempty" yaml:"package_managers,omitempty"`
+	Audits          []Audit           `json:"audits,omitempty" yaml:"audits,omitempty"`
+	Scorecard       *ScorecardResult  `json:"scorecard,omitempty" yaml:"scorecard,omitempty"`
+
 	// Community health files discovered via GitHub community profile
 	HasCodeOfConduct   bool   `json:"has_code_of_conduct,omitempty" yaml:"has_code_of_conduct,omitempty"`
 	HasContributing    bool   `json:"has_contributing,omitempty" yaml:"has_contributing,omitempty"`
@@ -73,10 +82,41 @@ type BootstrapResult struct {
 	// Source tracking: which fields came from which source
 	Sources map[string]string `json:"sources,omitempty" yaml:"sources,omitempty"`
 
+	// Every value the sources offered for a field, best first, and the
+	// fields where they disagree
+	Candidates map[string][]FieldCandidate `json:"candidates,omitempty" yaml:"candidates,omitempty"`
+	Conflicts  []FieldConflict             `json:"conflicts,omitempty" yaml:"conflicts,omitempty"`
+
+	// How the landscape and CLOMonitor entries were matched to the project
+	Matches []SourceMatch `json:"matches,omitempty" yaml:"matches,omitempty"`
+
 	// TODOs: fields the user must manually fill in
 	TODOs []string `json:"todos,omitempty" yaml:"todos,omitempty"`
 }
 
+// FieldCandidate is a value a source offered for a scaffold field
+type FieldCandidate struct {
+	Source     string  `json:"source" yaml:"source"`
+	Value      string  `json:"value" yaml:"value"`
+	Confidence float64 `json:"confidence" yaml:"confidence"` // 0-1, from how well the source matched the project
+}
+
+// FieldConflict is a field the sources disagree on. Value is the candidate
+// the scaffold uses.
+type FieldConflict struct {
+	Field      string           `json:"field" yaml:"field"`
+	Value      FieldCandidate   `json:"value" yaml:"value"`
+	Candidates []FieldCandidate `json:"candidates" yaml:"candidates"`
+}
+
+// SourceMatch records the entry a source matched for the project by name
+type SourceMatch struct {
+	Source    string  `json:"source" yaml:"source"`
+	Matched   string  `json:"matched" yaml:"matched"`
+	Score     float64 `json:"score" yaml:"score"`
+	ExactSlug bool    `json:"exact_slug" yaml:"exact_slug"`
+}
+
 // CLOMonitorProject represents a project entry from the CLOMonitor API search results.
 type CLOMonitorProject struct {
 	ID           string           `json:"project_id"`
@@ -95,6 +135,10 @@ type CLOMonitorProject struct {
 	Repositories []CLOMonitorRepo `json:"repositories"`
 	Rating       string           `json:"rating"`
 	UpdatedAt    int64            `json:"updated_at"`
+
+	// How the project was matched by name (not part of the API response)
+	MatchScore     float64 `json:"match_score,omitempty"`
+	ExactSlugMatch bool    `json:"exact_slug_match,omitempty"`
 }
 
 // CLOMonitorRepo represents a repository within a CLOMonitor project.
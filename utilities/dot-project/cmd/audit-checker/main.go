@@ -16,38 +16,108 @@ import (
 
 func main() {
 	var (
-		projectFile  = flag.String("project", "", "Path to project.yaml file (required)")
-		outputFormat = flag.String("output", "text", "Output format: text, json, yaml")
-		timeout      = flag.Int("timeout", 10, "HTTP request timeout in seconds")
+		projectFile      = flag.String("project", "", "Path to project.yaml file")
+		configFile       = flag.String("config", "", "Path or URL of a project list; audits every listed project instead of -project")
+		outputFormat     = flag.String("output", "text", "Output format: text, json, yaml")
+		timeout          = flag.Int("timeout", 10, "HTTP request timeout in seconds")
+		concurrency      = flag.Int("concurrency", projects.DefaultFetchOptions().Concurrency, "Number of links checked at once")
+		hostInterval     = flag.Duration("host-interval", projects.DefaultFetchOptions().HostInterval, "Minimum delay between requests to the same host")
+		retries          = flag.Int("retries", projects.DefaultFetchOptions().MaxRetries, "Retries after HTTP 429, 5xx or network errors")
+		content          = flag.Bool("content", false, "Also check the content of the security policy, license, CODEOWNERS and governance document")
+		historyFile      = flag.String("history", "", "JSON-lines file the results are appended to; adds a trend report against earlier runs")
+		failOnRegression = flag.Bool("fail-on-regression", false, "Exit 1 only for links broken since the previous run (requires -history)")
 	)
 	flag.Parse()
 
-	if *projectFile == "" {
-		fmt.Fprintln(os.Stderr, "Error: -project flag is required")
+	if *failOnRegression && *historyFile == "" {
+		fmt.Fprintln(os.Stderr, "Error: -fail-on-regression requires -history")
+		os.Exit(1)
+	}
+
+	if (*projectFile == "") == (*configFile == "") {
+		fmt.Fprintln(os.Stderr, "Error: exactly one of -project or -config is required")
 		flag.Usage()
 		os.Exit(1)
 	}
 
-	project, err := projects.LoadProjectFromFile(*projectFile)
-	if err != nil {
-		log.Fatalf("Failed to load project: %v", err)
+	fetchOptions := projects.DefaultFetchOptions()
+	fetchOptions.Concurrency = *concurrency
+	fetchOptions.HostInterval = *hostInterval
+	fetchOptions.MaxRetries = *retries
+
+	var projectList []projects.Project
+	if *configFile != "" {
+		validator := projects.NewValidator("")
+		validator.SetFetchOptions(fetchOptions)
+		loaded, err := validator.LoadProjects(*configFile)
+		if err != nil {
+			log.Fatalf("Failed to load projects: %v", err)
+		}
+		projectList = loaded
+	} else {
+		project, err := projects.LoadProjectFromFile(*projectFile)
+		if err != nil {
+			log.Fatalf("Failed to load project: %v", err)
+		}
+		projectList = []projects.Project{project}
 	}
 
 	client := &http.Client{Timeout: time.Duration(*timeout) * time.Second}
-	result := projects.AuditProject(project, client)
+	auditor := projects.NewAuditor(client, fetchOptions)
+	auditor.SetContentProbes(*content)
+	results := auditor.AuditProjects(projectList)
+	runAt := time.Now().UTC()
+
+	var trend *projects.AuditTrend
+	if *historyFile != "" {
+		history, err := projects.LoadAuditHistory(*historyFile)
+		if err != nil {
+			log.Fatalf("Failed to load audit history: %v", err)
+		}
+		t := history.Trend(results, runAt)
+		trend = &t
+		if err := history.Append(results, runAt); err != nil {
+			log.Fatalf("Failed to save audit history: %v", err)
+		}
+	}
 
+	// A single project keeps the original single-result output
+	var output interface{} = results
+	if *projectFile != "" {
+		output = results[0]
+	}
+	if trend != nil {
+		output = map[string]interface{}{"results": output, "trend": trend}
+	}
 	switch *outputFormat {
 	case "json":
-		data, _ := json.MarshalIndent(result, "", "  ")
+		data, _ := json.MarshalIndent(output, "", "  ")
 		fmt.Println(string(data))
 	case "yaml":
-		data, _ := yaml.Marshal(result)
+		data, _ := yaml.Marshal(output)
 		fmt.Print(string(data))
 	default:
-		fmt.Print(projects.FormatAuditResult(result))
+		for i, result := range results {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Print(projects.FormatAuditResult(result))
+		}
+		if trend != nil {
+			fmt.Println()
+			fmt.Print(projects.FormatAuditTrend(*trend))
+		}
 	}
 
-	if result.FailCount > 0 {
-		os.Exit(1)
+	if *failOnRegression {
+		if len(trend.Regressions) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+	for _, result := range results {
+		if result.FailCount > 0 || result.ProbeFailCount > 0 {
+			os.Exit(1)
+		}
 	}
 }
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"projects"
+
+	"gopkg.in/yaml.v3"
+)
+
+func main() {
+	var (
+		name          = flag.String("name", "", "Project display name to search for (e.g., 'Kubernetes')")
+		githubOrg     = flag.String("github-org", "", "GitHub organization (e.g., 'kubernetes')")
+		githubRepo    = flag.String("github-repo", "", "Primary GitHub repository name (e.g., 'kubernetes')")
+		githubToken   = flag.String("github-token", "", "GitHub personal access token (or set GITHUB_TOKEN env; with -forge gitlab or gitea, GITLAB_TOKEN or GITEA_TOKEN)")
+		forgeName     = flag.String("forge", "github", "Forge hosting the repository: "+strings.Join(projects.ForgeNames, ", ")+" (-github-org and -github-repo name the group or owner and project there)")
+		forgeURL      = flag.String("forge-url", "", "With -forge gitlab or gitea, the instance URL (default: https://gitlab.com or https://codeberg.org)")
+		outputDir     = flag.String("output-dir", ".", "Directory to write scaffold output (with -batch, one subdirectory per project)")
+		skipLandscape = flag.Bool("skip-landscape", false, "Skip CNCF landscape YAML lookup")
+		skipCLO       = flag.Bool("skip-clomonitor", false, "Skip CLOMonitor API lookup")
+		skipGH        = flag.Bool("skip-github", false, "Skip the GitHub (or -forge) API lookup")
+		extraSources  = flag.String("sources", strings.Join(projects.BootstrapSourceNames, ","), "Comma-separated extra sources looked up by repository (\"\" for none): "+strings.Join(projects.BootstrapSourceNames, ", "))
+		dryRun        = flag.Bool("dry-run", false, "Print generated YAML to stdout without writing files")
+		refresh       = flag.Bool("refresh", false, "Merge newly discovered data into the existing scaffold in -output-dir and print a patch instead of writing")
+		patchFile     = flag.String("patch", "", "With -refresh, write the patch to this file instead of stdout")
+		reportFile    = flag.String("report", "", "Write a JSON report of every source's candidate values and their conflicts to this file")
+		batchFile     = flag.String("batch", "", "Bootstrap every project in a file of org|name|repo lines")
+		concurrency   = flag.Int("concurrency", 4, "With -batch, projects bootstrapped at once")
+		manifestPath  = flag.String("manifest", "", "With -batch, progress manifest (default: <output-dir>/bootstrap-progress.json)")
+		allowPartial  = flag.Bool("allow-partial", false, "With -batch, write scaffolds even when a data source failed")
+		outputFormat  = flag.String("output", "text", "With -batch, summary format: text, json, yaml")
+	)
+	flag.Parse()
+
+	// Forge token from env if not provided via flag
+	token := *githubToken
+	if token == "" {
+		tokenEnv := "GITHUB_TOKEN"
+		if *forgeName != "github" {
+			tokenEnv = strings.ToUpper(*forgeName) + "_TOKEN"
+		}
+		token = os.Getenv(tokenEnv)
+	}
+	if _, err := projects.NewForge(*forgeName, nil, *forgeURL, ""); err != nil {
+		log.Fatalf("Invalid -forge: %v", err)
+	}
+	extra, err := projects.NewBootstrapSources(strings.Split(*extraSources, ","))
+	if err != nil {
+		log.Fatalf("Invalid -sources: %v", err)
+	}
+	sources := projects.BootstrapOptions{SkipLandscape: *skipLandscape, SkipCLOMonitor: *skipCLO, SkipGitHub: *skipGH, Forge: *forgeName, ForgeURL: *forgeURL, Extra: extra}
+
+	if *batchFile != "" {
+		runBatch(*batchFile, projects.BatchOptions{
+			OutputDir:    *outputDir,
+			ManifestPath: *manifestPath,
+			Concurrency:  *concurrency,
+			GitHubToken:  token,
+			Sources:      sources,
+			AllowPartial: *allowPartial,
+		}, *outputFormat)
+		return
+	}
+
+	// Validate required inputs
+	if *name == "" && *githubOrg == "" {
+		fmt.Fprintln(os.Stderr, "Error: at least one of -name, -github-org or -batch is required")
+		fmt.Fprintln(os.Stderr)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	cfg := projects.BootstrapConfig{
+		ProjectName: *name,
+		GitHubOrg:   *githubOrg,
+		GitHubRepo:  *githubRepo,
+		GitHubToken: token,
+		OutputDir:   *outputDir,
+	}
+	if cfg.ProjectName == "" {
+		cfg.ProjectName = cfg.GitHubOrg
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	fmt.Fprintf(os.Stderr, "Bootstrapping project: %s (slug: %s)\n", cfg.ProjectName, projects.BootstrapSlug(cfg.ProjectName))
+	result, errs := projects.BootstrapProject(cfg, client, sources, func(format string, args ...interface{}) {
+		fmt.Fprintf(os.Stderr, "  "+format+"\n", args...)
+	})
+	for _, err := range errs {
+		log.Printf("  Warning: %v", err)
+	}
+	if *reportFile != "" {
+		data, _ := json.MarshalIndent(result.Report(), "", "  ")
+		if err := os.WriteFile(*reportFile, append(data, '\n'), 0644); err != nil {
+			log.Fatalf("Failed to write report: %v", err)
+		}
+		fmt.Fprintf(os.Stderr, "  Report written to %s\n", *reportFile)
+	}
+
+	// Phase 5: Generate output
+	if *refresh {
+		runRefresh(*outputDir, result, *patchFile)
+		return
+	}
+	if *dryRun {
+		fmt.Fprintln(os.Stderr, "\n--- project.yaml ---")
+		projectYAML, err := projects.GenerateProjectYAML(result)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating project.yaml: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(projectYAML))
+
+		fmt.Fprintln(os.Stderr, "--- maintainers.yaml ---")
+		maintainersYAML, err := projects.GenerateMaintainersYAML(result)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating maintainers.yaml: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(maintainersYAML))
+	} else {
+		fmt.Fprintf(os.Stderr, "  Writing scaffold to %s...\n", *outputDir)
+		if err := projects.WriteScaffold(*outputDir, result); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "\nScaffold written to %s:\n", *outputDir)
+		fmt.Fprintf(os.Stderr, "  - project.yaml\n")
+		fmt.Fprintf(os.Stderr, "  - maintainers.yaml\n")
+		fmt.Fprintf(os.Stderr, "  - README.md\n")
+		if result.SecurityPolicyURL == "" {
+			fmt.Fprintf(os.Stderr, "  - SECURITY.md\n")
+		} else {
+			fmt.Fprintf(os.Stderr, "  - SECURITY.md (skipped: using %s)\n", result.SecurityPolicyURL)
+		}
+		fmt.Fprintf(os.Stderr, "  - CODEOWNERS\n")
+		fmt.Fprintf(os.Stderr, "  - .gitignore\n")
+		fmt.Fprintf(os.Stderr, "  - .github/workflows/validate.yaml\n")
+		fmt.Fprintf(os.Stderr, "  - .github/workflows/update-landscape.yml\n")
+
+		// Report discovered file URLs
+		if result.SecurityPolicyURL != "" || result.ContributingURL != "" || result.CodeOfConductURL != "" || result.LicenseURL != "" {
+			fmt.Fprintln(os.Stderr, "\nDiscovered existing files:")
+			if result.SecurityPolicyURL != "" {
+				fmt.Fprintf(os.Stderr, "  SECURITY.md: %s\n", result.SecurityPolicyURL)
+			}
+			if result.ContributingURL != "" {
+				fmt.Fprintf(os.Stderr, "  CONTRIBUTING.md: %s\n", result.ContributingURL)
+			}
+			if result.CodeOfConductURL != "" {
+				fmt.Fprintf(os.Stderr, "  CODE_OF_CONDUCT: %s\n", result.CodeOfConductURL)
+			}
+			if result.LicenseURL != "" {
+				fmt.Fprintf(os.Stderr, "  LICENSE: %s\n", result.LicenseURL)
+			}
+		}
+	}
+
+	// Show TODOs
+	if len(result.TODOs) > 0 {
+		fmt.Fprintln(os.Stderr, "\nRemaining TODOs:")
+		for _, todo := range result.TODOs {
+			fmt.Fprintf(os.Stderr, "  - %s\n", todo)
+		}
+	}
+
+	// Show conflicting sources and uncertain matches
+	if notes := result.ReviewNotes(); len(notes) > 0 {
+		fmt.Fprintln(os.Stderr, "\nTo review (also commented in project.yaml):")
+		for _, note := range notes {
+			fmt.Fprintf(os.Stderr, "  - %s\n", note)
+		}
+	}
+
+	// Show data sources
+	if len(result.Sources) > 0 {
+		fmt.Fprintln(os.Stderr, "\nData sources used:")
+		for field, source := range result.Sources {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", field, source)
+		}
+	}
+}
+
+// runBatch bootstraps every project in a batch file and prints a summary
+func runBatch(path string, opts projects.BatchOptions, outputFormat string) {
+	batch, err := projects.LoadBatchFile(path)
+	if err != nil {
+		log.Fatalf("Failed to load batch: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "Bootstrapping %d projects into %s\n", len(batch), opts.OutputDir)
+
+	var mu sync.Mutex
+	manifest, err := projects.RunBootstrapBatch(batch, opts, func(format string, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Fprintf(os.Stderr, "  "+format+"\n", args...)
+	})
+	if err != nil {
+		log.Fatalf("Batch bootstrap failed: %v", err)
+	}
+
+	switch outputFormat {
+	case "json":
+		data, _ := json.MarshalIndent(manifest, "", "  ")
+		fmt.Println(string(data))
+	case "yaml":
+		data, _ := yaml.Marshal(manifest)
+		fmt.Print(string(data))
+	default:
+		fmt.Print(projects.FormatBatchSummary(manifest))
+	}
+
+	if manifest.Count(projects.BatchDone) < len(manifest.Projects) {
+		os.Exit(1)
+	}
+}
+
+// runRefresh merges a bootstrap result into an existing scaffold and prints
+// the patch, the fields it updates and the edits it keeps
+func runRefresh(dir string, result *projects.BootstrapResult, patchFile string) {
+	fmt.Fprintf(os.Stderr, "  Merging into %s...\n", dir)
+	refresh, err := projects.RefreshScaffold(dir, result)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(refresh.Changes) > 0 {
+		fmt.Fprintln(os.Stderr, "\nUpdated from the data sources:")
+		for _, change := range refresh.Changes {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", change.File, change)
+		}
+	}
+	if len(refresh.Conflicts) > 0 {
+		fmt.Fprintln(os.Stderr, "\nKept existing values (review by hand):")
+		for _, c := range refresh.Conflicts {
+			fmt.Fprintf(os.Stderr, "  %s: %s: %q, discovered %q (%s)\n", c.File, c.Path, c.Ours, c.Theirs, c.Reason)
+		}
+	}
+	if refresh.Patch == "" {
+		fmt.Fprintln(os.Stderr, "\nScaffold is up to date")
+		return
+	}
+
+	if patchFile == "" {
+		fmt.Print(refresh.Patch)
+		return
+	}
+	if err := os.WriteFile(patchFile, []byte(refresh.Patch), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "\nPatch written to %s; review it, then run git apply in %s\n", patchFile, dir)
+}
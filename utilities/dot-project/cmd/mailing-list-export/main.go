@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"projects"
+
+	"gopkg.in/yaml.v3"
+)
+
+func main() {
+	var (
+		maintainersFile = flag.String("maintainers", "", "Path to maintainers.yaml file (required)")
+		list            = flag.String("list", "", "Address of the mailing list, e.g. maintainers@lists.cncf.io (required)")
+		directoryFile   = flag.String("directory", "", "YAML file mapping handles to email addresses")
+		useLFX          = flag.Bool("lfx", false, "Look up addresses in LFX instead of -directory (uses LFX_AUTH_TOKEN)")
+		teams           = flag.String("teams", "", "Comma-separated teams whose members belong on the list (default: all teams)")
+		projectIDs      = flag.String("project-id", "", "Comma-separated maintainers entries to include (default: all entries)")
+		currentFile     = flag.String("current", "", "Current list members: a CSV export or one address per line (default: empty list)")
+		keepFile        = flag.String("keep", "", "Addresses never removed, e.g. staff_emails.txt: a CSV or one address per line")
+		addCSV          = flag.String("add-csv", "", "Write the addresses to add as a Google Groups import CSV")
+		removeCSV       = flag.String("remove-csv", "", "Write the addresses to remove as a Google Groups CSV")
+		outputFormat    = flag.String("output", "text", "Output format: text, json, yaml")
+	)
+	flag.Parse()
+
+	if *maintainersFile == "" || *list == "" || (*directoryFile == "") == !*useLFX {
+		fmt.Fprintln(os.Stderr, "Error: -maintainers, -list and exactly one of -directory or -lfx are required")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	data, err := os.ReadFile(*maintainersFile)
+	if err != nil {
+		log.Fatalf("Failed to read maintainers file: %v", err)
+	}
+	var config projects.MaintainersConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		log.Fatalf("Failed to parse maintainers file: %v", err)
+	}
+
+	var directory projects.EmailDirectory
+	if *useLFX {
+		token := os.Getenv("LFX_AUTH_TOKEN")
+		if token == "" {
+			log.Fatalf("-lfx requires LFX_AUTH_TOKEN")
+		}
+		directory = projects.NewLFXDirectory(&http.Client{Timeout: 30 * time.Second}, os.Getenv("MAINTAINER_API_ENDPOINT"), token)
+	} else {
+		directory, err = projects.LoadEmailDirectory(*directoryFile)
+		if err != nil {
+			log.Fatalf("Failed to load email directory: %v", err)
+		}
+	}
+
+	var current []string
+	if *currentFile != "" {
+		current, err = projects.LoadListMembers(*currentFile)
+		if err != nil {
+			log.Fatalf("Failed to load current members: %v", err)
+		}
+	}
+	opts := projects.MailingListOptions{
+		List:       *list,
+		Teams:      splitList(*teams),
+		ProjectIDs: splitList(*projectIDs),
+		Keep:       make(map[string]bool),
+	}
+	if *keepFile != "" {
+		keep, err := projects.LoadListMembers(*keepFile)
+		if err != nil {
+			log.Fatalf("Failed to load kept addresses: %v", err)
+		}
+		for _, email := range keep {
+			opts.Keep[email] = true
+		}
+	}
+
+	diff := projects.BuildMailingListDiff(config, directory, opts, current)
+
+	if *addCSV != "" {
+		writeCSV(*addCSV, *list, diff.AddedEmails())
+	}
+	if *removeCSV != "" {
+		writeCSV(*removeCSV, *list, diff.Remove)
+	}
+
+	switch *outputFormat {
+	case "json":
+		data, _ := json.MarshalIndent(diff, "", "  ")
+		fmt.Println(string(data))
+	case "yaml":
+		data, _ := yaml.Marshal(diff)
+		fmt.Print(string(data))
+	default:
+		fmt.Print(projects.FormatMailingListDiff(diff))
+	}
+
+	if len(diff.Unresolved) > 0 {
+		os.Exit(1)
+	}
+}
+
+// writeCSV writes addresses as a Google Groups CSV
+func writeCSV(path, list string, emails []string) {
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatalf("Failed to create %s: %v", path, err)
+	}
+	defer f.Close()
+	if err := projects.WriteGoogleGroupsCSV(f, list, emails); err != nil {
+		log.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+// splitList splits a comma-separated flag value
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"projects"
+
+	"gopkg.in/yaml.v3"
+)
+
+func main() {
+	defaults := projects.DefaultActivityOptions()
+	var (
+		maintainersFile = flag.String("maintainers", "", "Path to maintainers.yaml file (required)")
+		projectFile     = flag.String("project", "", "Path to project.yaml file whose repositories are searched")
+		configFile      = flag.String("config", "", "Path or URL of a project list; each maintainers entry is matched to the project with its project_id as slug")
+		projectID       = flag.String("project-id", "", "Only analyse this maintainers entry (default with -project: the project slug)")
+		months          = flag.Int("months", defaults.Months, "Maintainers with no activity in this many months are inactive")
+		githubToken     = flag.String("github-token", "", "GitHub personal access token (or set GITHUB_TOKEN env)")
+		githubAPIURL    = flag.String("github-api-url", "", "GitHub API URL (default: https://api.github.com)")
+		concurrency     = flag.Int("concurrency", defaults.Concurrency, "Maintainers looked up at once")
+		searchInterval  = flag.Duration("search-interval", defaults.SearchInterval, "Minimum delay between GitHub search requests")
+		outputFormat    = flag.String("output", "text", "Output format: text, json, yaml")
+	)
+	flag.Parse()
+
+	if *maintainersFile == "" || (*projectFile == "") == (*configFile == "") {
+		fmt.Fprintln(os.Stderr, "Error: -maintainers and exactly one of -project or -config are required")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	data, err := os.ReadFile(*maintainersFile)
+	if err != nil {
+		log.Fatalf("Failed to read maintainers file: %v", err)
+	}
+	var config projects.MaintainersConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		log.Fatalf("Failed to parse maintainers file: %v", err)
+	}
+
+	// Repositories of each project, by slug
+	repositories := make(map[string][]string)
+	if *projectFile != "" {
+		project, err := projects.LoadProjectFromFile(*projectFile)
+		if err != nil {
+			log.Fatalf("Failed to load project: %v", err)
+		}
+		if *projectID == "" {
+			*projectID = project.Slug
+		}
+		repositories[*projectID] = project.Repositories
+	} else {
+		loaded, err := projects.NewValidator("").LoadProjects(*configFile)
+		if err != nil {
+			log.Fatalf("Failed to load projects: %v", err)
+		}
+		for _, project := range loaded {
+			repositories[project.Slug] = project.Repositories
+		}
+	}
+
+	token := *githubToken
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	opts := projects.ActivityOptions{
+		Months:         *months,
+		GitHubAPIURL:   *githubAPIURL,
+		GitHubToken:    token,
+		Concurrency:    *concurrency,
+		SearchInterval: *searchInterval,
+	}
+	analyser := projects.NewActivityAnalyser(&http.Client{Timeout: 30 * time.Second}, opts)
+
+	var reports []projects.ActivityReport
+	for _, entry := range config.Maintainers {
+		if *projectID != "" && entry.ProjectID != *projectID && len(config.Maintainers) > 1 {
+			continue
+		}
+		repos, ok := repositories[entry.ProjectID]
+		if !ok && *projectFile != "" {
+			repos, ok = repositories[*projectID]
+		}
+		if !ok {
+			log.Printf("Warning: no project found for maintainers entry %s, skipping", entry.ProjectID)
+			continue
+		}
+		reports = append(reports, analyser.AnalyseEntry(entry, repos))
+	}
+	if len(reports) == 0 {
+		log.Fatalf("No maintainers entry matched a project")
+	}
+
+	switch *outputFormat {
+	case "json":
+		data, _ := json.MarshalIndent(reports, "", "  ")
+		fmt.Println(string(data))
+	case "yaml":
+		data, _ := yaml.Marshal(reports)
+		fmt.Print(string(data))
+	default:
+		fmt.Print(projects.FormatActivityReports(reports))
+	}
+
+	for _, report := range reports {
+		if report.InactiveCount > 0 {
+			os.Exit(1)
+		}
+	}
+}
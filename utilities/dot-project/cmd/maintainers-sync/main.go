@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"projects"
+
+	"gopkg.in/yaml.v3"
+)
+
+func main() {
+	var (
+		maintainersFile = flag.String("maintainers", "", "Path to maintainers.yaml file (required)")
+		projectID       = flag.String("project-id", "", "Only sync this maintainers entry")
+		org             = flag.String("org", "", "GitHub org for entries that do not set one")
+		githubToken     = flag.String("github-token", "", "GitHub personal access token with read:org, or admin:org for -apply (or set GITHUB_TOKEN env)")
+		githubAPIURL    = flag.String("github-api-url", "", "GitHub API URL (default: https://api.github.com)")
+		reconcile       = flag.Bool("reconcile", false, "Print the changes that make GitHub teams match maintainers.yaml (dry run)")
+		apply           = flag.Bool("apply", false, "With -reconcile, make the changes")
+		outputFormat    = flag.String("output", "text", "Output format: text, json, yaml")
+	)
+	flag.Parse()
+
+	if *maintainersFile == "" {
+		fmt.Fprintln(os.Stderr, "Error: -maintainers is required")
+		flag.Usage()
+		os.Exit(1)
+	}
+	if *apply && !*reconcile {
+		log.Fatalf("-apply requires -reconcile")
+	}
+
+	data, err := os.ReadFile(*maintainersFile)
+	if err != nil {
+		log.Fatalf("Failed to read maintainers file: %v", err)
+	}
+	var config projects.MaintainersConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		log.Fatalf("Failed to parse maintainers file: %v", err)
+	}
+
+	token := *githubToken
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	syncer := projects.NewTeamSyncer(&http.Client{Timeout: 30 * time.Second}, *githubAPIURL, token)
+
+	for i := range config.Maintainers {
+		if config.Maintainers[i].Org == "" {
+			config.Maintainers[i].Org = *org
+		}
+	}
+	// Every entry is passed so teams shared with other entries are refused
+	reports := syncer.DriftEntries(config.Maintainers, *projectID)
+	if len(reports) == 0 {
+		log.Fatalf("No maintainers entry matched")
+	}
+	if *reconcile {
+		for i := range reports {
+			syncer.Plan(&reports[i])
+			if *apply {
+				syncer.Apply(&reports[i])
+			}
+		}
+	}
+
+	switch *outputFormat {
+	case "json":
+		data, _ := json.MarshalIndent(reports, "", "  ")
+		fmt.Println(string(data))
+	case "yaml":
+		data, _ := yaml.Marshal(reports)
+		fmt.Print(string(data))
+	default:
+		fmt.Print(projects.FormatTeamSyncReports(reports))
+	}
+
+	// Fail on errors, and on drift that was not reconciled
+	for _, report := range reports {
+		if report.Error != "" {
+			os.Exit(1)
+		}
+		for _, team := range report.Teams {
+			if team.Error != "" {
+				os.Exit(1)
+			}
+		}
+		for _, action := range report.Actions {
+			if action.Status == "failed" {
+				os.Exit(1)
+			}
+		}
+		if report.Drifted() && !*apply {
+			os.Exit(1)
+		}
+	}
+}
@@ -25,9 +25,19 @@ func main() {
 		projectLead  = flag.String("project-lead", "", "GitHub handle of project lead")
 		slackChannel = flag.String("slack-channel", "", "CNCF Slack channel (e.g., #my-project)")
 		outputFile   = flag.String("output", "", "Output file path (default: stdout)")
+		upgradeFile  = flag.String("file", "", "Upgrade an existing project.yaml in place to the latest schema_version instead of generating one")
+		check        = flag.Bool("check", false, "With -file, exit non-zero if the file is behind the latest schema_version without rewriting it")
 	)
 	flag.Parse()
 
+	if *upgradeFile != "" {
+		os.Exit(upgrade(*upgradeFile, *check))
+	}
+	if *check {
+		fmt.Fprintln(os.Stderr, "Error: -check requires -file")
+		os.Exit(1)
+	}
+
 	// Validate required fields
 	var errors []string
 	if *slug == "" {
@@ -73,7 +83,7 @@ func main() {
 	}
 
 	project := projects.Project{
-		SchemaVersion: "1.0.0",
+		SchemaVersion: projects.LatestSchemaVersion(),
 		Slug:          *slug,
 		Name:          *name,
 		Description:   *description,
@@ -129,3 +139,55 @@ func main() {
 		fmt.Fprintln(os.Stderr, "Generated project.yaml passes validation.")
 	}
 }
+
+// upgrade migrates an existing project.yaml to the latest schema version, or
+// only reports whether it is behind when check is set. It returns the exit code.
+func upgrade(path string, check bool) int {
+	migrator := projects.DefaultMigrator()
+
+	if check {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read %s: %v\n", path, err)
+			return 1
+		}
+		version, upToDate, err := migrator.Check(data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			return 1
+		}
+		if !upToDate {
+			fmt.Fprintf(os.Stderr, "%s: schema_version %s is behind %s; run migrate -file %s\n", path, version, migrator.Latest(), path)
+			return 1
+		}
+		fmt.Fprintf(os.Stderr, "%s: schema_version %s is up to date\n", path, version)
+		return 0
+	}
+
+	result, err := migrator.MigrateFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+		return 1
+	}
+	if !result.Changed() {
+		fmt.Fprintf(os.Stderr, "%s: schema_version %s is up to date\n", path, result.From)
+		return 0
+	}
+	for _, m := range result.Applied {
+		fmt.Fprintf(os.Stderr, "Applied %s -> %s: %s\n", m.From, m.To, m.Description)
+	}
+	fmt.Fprintf(os.Stderr, "Upgraded %s from %s to %s\n", path, result.From, result.To)
+
+	project, err := projects.LoadProjectFromFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: upgraded file does not load: %v\n", err)
+		return 1
+	}
+	if validationErrors := projects.ValidateProjectStruct(project); len(validationErrors) > 0 {
+		fmt.Fprintln(os.Stderr, "\nWarning: upgraded project.yaml has validation issues:")
+		for _, e := range validationErrors {
+			fmt.Fprintf(os.Stderr, "  - %s\n", e)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"projects"
+)
+
+func main() {
+	var (
+		landscapeFile = flag.String("landscape", "", "URL or path of the CNCF landscape.yml used to complete landscape categories (default: upstream landscape.yml)")
+		skipLandscape = flag.Bool("skip-landscape", false, "Do not load landscape categories")
+	)
+	flag.Parse()
+
+	// stdout carries the protocol, so logs go to stderr
+	log.SetOutput(os.Stderr)
+
+	var landscape projects.LandscapeCategories
+	if !*skipLandscape {
+		categories, err := projects.LoadLandscapeCategories(*landscapeFile, &http.Client{Timeout: 30 * time.Second})
+		if err != nil {
+			log.Printf("Landscape categories will not be completed: %v", err)
+		} else {
+			landscape = categories
+		}
+	}
+
+	server, err := projects.NewLanguageServer(landscape)
+	if err != nil {
+		log.Fatalf("failed to start language server: %v", err)
+	}
+	if err := server.Serve(os.Stdin, os.Stdout); err != nil {
+		log.Fatalf("language server: %v", err)
+	}
+}
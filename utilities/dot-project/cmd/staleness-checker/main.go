@@ -5,7 +5,10 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"projects"
@@ -15,41 +18,120 @@ import (
 
 func main() {
 	var (
-		projectFile   = flag.String("project", "", "Path to project.yaml file (required)")
+		projectFile   = flag.String("project", "", "Path to project.yaml file")
+		configFile    = flag.String("config", "", "Path or URL of a project list; sweeps every listed project instead of -project")
 		thresholdDays = flag.Int("threshold", 180, "Days before considering maintainers stale")
 		lastUpdate    = flag.String("last-update", "", "Override last update date (YYYY-MM-DD format)")
+		maintainers   = flag.String("maintainers", "", "Maintainers file tracked in git; per-team dates come from its history (default: maintainers.yaml next to the project file)")
+		projectID     = flag.String("project-id", "", "Entry of the project in a shared maintainers file (default: project slug)")
 		outputFormat  = flag.String("output", "text", "Output format: text, json, yaml")
+
+		githubToken   = flag.String("github-token", "", "GitHub token for dating remote projects and opening issues (or set GITHUB_TOKEN env)")
+		githubAPIURL  = flag.String("github-api-url", "", "GitHub API URL (default: https://api.github.com)")
+		githubIssues  = flag.Bool("github-issues", false, "Sweep: open or update an issue for each stale project")
+		issueLabels   = flag.String("issue-labels", "", "Sweep: comma-separated labels for opened issues")
+		slackWebhooks = flag.String("slack-webhooks", "", "Sweep: YAML file mapping cncf_slack_channel to the Slack incoming webhook URL of that channel")
+		slackWebhook  = flag.String("slack-webhook", "", "Sweep: default Slack incoming webhook URL, for channels without their own webhook (or set SLACK_WEBHOOK_URL env)")
+		digestFile    = flag.String("digest", "", "Sweep: write stale projects to this file (.json for JSON, Markdown otherwise)")
 	)
 	flag.Parse()
 
-	if *projectFile == "" {
-		fmt.Fprintln(os.Stderr, "Error: -project flag is required")
+	if (*projectFile == "") == (*configFile == "") {
+		fmt.Fprintln(os.Stderr, "Error: exactly one of -project or -config is required")
 		flag.Usage()
 		os.Exit(1)
 	}
 
+	if *configFile != "" {
+		token := *githubToken
+		if token == "" {
+			token = os.Getenv("GITHUB_TOKEN")
+		}
+		webhook := *slackWebhook
+		if webhook == "" {
+			webhook = os.Getenv("SLACK_WEBHOOK_URL")
+		}
+
+		client := &http.Client{Timeout: 30 * time.Second}
+		var notifiers []projects.StalenessNotifier
+		if *githubIssues {
+			if token == "" {
+				log.Fatalf("-github-issues requires -github-token or GITHUB_TOKEN")
+			}
+			notifier := projects.NewGitHubIssueNotifier(client, *githubAPIURL, token)
+			if *issueLabels != "" {
+				notifier.Labels = strings.Split(*issueLabels, ",")
+			}
+			notifiers = append(notifiers, notifier)
+		}
+		var webhooks map[string]string
+		if *slackWebhooks != "" {
+			data, err := os.ReadFile(*slackWebhooks)
+			if err != nil {
+				log.Fatalf("Failed to read Slack webhooks: %v", err)
+			}
+			if err := yaml.Unmarshal(data, &webhooks); err != nil {
+				log.Fatalf("Failed to parse Slack webhooks: %v", err)
+			}
+		}
+		if webhook != "" || len(webhooks) > 0 {
+			notifiers = append(notifiers, projects.NewSlackNotifier(client, webhooks, webhook))
+		}
+		if *digestFile != "" {
+			notifiers = append(notifiers, projects.NewDigestNotifier(*digestFile))
+		}
+
+		sweep(*configFile, projects.SweepOptions{
+			ThresholdDays: *thresholdDays,
+			GitHubAPIURL:  *githubAPIURL,
+			GitHubToken:   token,
+		}, notifiers, *outputFormat)
+		return
+	}
+
 	project, err := projects.LoadProjectFromFile(*projectFile)
 	if err != nil {
 		log.Fatalf("Failed to load project: %v", err)
 	}
 
-	var updateTime time.Time
-	if *lastUpdate != "" {
-		updateTime, err = time.Parse("2006-01-02", *lastUpdate)
+	id := *projectID
+	if id == "" {
+		id = project.Slug
+	}
+
+	var result projects.StalenessResult
+	switch {
+	case *lastUpdate != "":
+		updateTime, err := time.Parse("2006-01-02", *lastUpdate)
 		if err != nil {
 			log.Fatalf("Invalid date format: %v", err)
 		}
-	} else {
-		// Default: check file modification time
+		result = projects.CheckStaleness(project, updateTime, *thresholdDays)
+	case *maintainers != "":
+		history, err := projects.GitMaintainersHistory(*maintainers, id)
+		if err != nil {
+			log.Fatalf("Failed to read maintainers history: %v", err)
+		}
+		result = projects.CheckTeamStaleness(project, history, *thresholdDays)
+	default:
+		// Prefer the git history of maintainers.yaml next to the project
+		// file; fall back to the project file's modification time
+		candidate := filepath.Join(filepath.Dir(*projectFile), "maintainers.yaml")
+		if _, statErr := os.Stat(candidate); statErr == nil {
+			history, err := projects.GitMaintainersHistory(candidate, id)
+			if err == nil {
+				result = projects.CheckTeamStaleness(project, history, *thresholdDays)
+				break
+			}
+			log.Printf("Warning: using file modification time: %v", err)
+		}
 		info, err := os.Stat(*projectFile)
 		if err != nil {
 			log.Fatalf("Failed to stat file: %v", err)
 		}
-		updateTime = info.ModTime()
+		result = projects.CheckStaleness(project, info.ModTime(), *thresholdDays)
 	}
 
-	result := projects.CheckStaleness(project, updateTime, *thresholdDays)
-
 	switch *outputFormat {
 	case "json":
 		data, _ := json.MarshalIndent(result, "", "  ")
@@ -66,3 +148,46 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// sweep checks every project in a project list and notifies about the stale
+// ones. Exits 1 when a project is stale, could not be checked, or a
+// notification failed.
+func sweep(configFile string, opts projects.SweepOptions, notifiers []projects.StalenessNotifier, outputFormat string) {
+	validator := projects.NewValidator("")
+	result, err := validator.SweepStaleness(configFile, opts)
+	if err != nil {
+		log.Fatalf("Failed to sweep projects: %v", err)
+	}
+	result.Notify(notifiers...)
+
+	switch outputFormat {
+	case "json":
+		data, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(data))
+	case "yaml":
+		data, _ := yaml.Marshal(result)
+		fmt.Print(string(data))
+	default:
+		fmt.Print(projects.FormatStalenessResults(result.Results()))
+		for _, e := range result.Errors {
+			fmt.Printf("ERROR: %s: %s\n", e.Source, e.Error)
+		}
+		for _, n := range result.Notifications {
+			line := fmt.Sprintf("%s %s", n.Notifier, n.Action)
+			if n.Project != "" {
+				line += " for " + n.Project
+			}
+			if n.Target != "" {
+				line += ": " + n.Target
+			}
+			if n.Error != "" {
+				line += " (" + n.Error + ")"
+			}
+			fmt.Println(line)
+		}
+	}
+
+	if len(result.Stale()) > 0 || len(result.Errors) > 0 || result.NotificationFailures() > 0 {
+		os.Exit(1)
+	}
+}
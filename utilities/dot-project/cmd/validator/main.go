@@ -0,0 +1,276 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"projects"
+)
+
+func main() {
+	var (
+		configFile          = flag.String("config", "yaml/projectlist.yaml", "Path to project list configuration file")
+		cacheDir            = flag.String("cache", ".cache", "Directory to store cached validation results")
+		maintainersFile     = flag.String("maintainers", "yaml/maintainers.yaml", "Path to maintainers file (set empty to skip)")
+		baseMaintainersFile = flag.String("base-maintainers", "", "Path to base maintainers file for diff validation")
+		verifyMaintainers   = flag.Bool("verify-maintainers", false, "Verify maintainer handles with the -verifier")
+		verifierName        = flag.String("verifier", "", "Handle verifier: github, gitlab, gitea, lfx or allowlist (default: lfx when LFX_AUTH_TOKEN is set; -verify-maintainers fails without a verifier)")
+		allowListFile       = flag.String("allowlist", "", "File of known handles, one per line, for -verifier allowlist")
+		githubToken         = flag.String("github-token", "", "GitHub personal access token for -verifier github (or set GITHUB_TOKEN env)")
+		githubAPIURL        = flag.String("github-api-url", "", "GitHub API URL for -verifier github (default: https://api.github.com)")
+		forgeURL            = flag.String("forge-url", "", "Instance URL for -verifier gitlab or gitea and -advisory-forge (default: https://gitlab.com or https://codeberg.org); the token is read from GITLAB_TOKEN or GITEA_TOKEN")
+		advisoryForge       = flag.String("advisory-forge", "", "Also accept security.contact.advisory_url on this forge at -forge-url, e.g. gitlab for a self-hosted GitLab (GitHub and gitlab.com are always accepted)")
+		requireOrgMember    = flag.Bool("require-org-membership", false, "With -verifier github, gitlab or gitea, fail handles that are not members of their entry's org")
+		verifyCacheTTL      = flag.Duration("verify-cache-ttl", 24*time.Hour, "How long handle verification results are cached (in the -cache directory)")
+		outputFormat        = flag.String("output", "text", "Output format: text, json, yaml, markdown")
+		failOn              = flag.String("fail-on", "error", "Lowest diagnostic severity that fails the run: error, warning, info")
+		targetPhase         = flag.String("target-phase", "", "Check Due Diligence requirements for this phase instead of each project's current phase (e.g., graduated)")
+		profilesFile        = flag.String("profiles", "", "Path to requirement profiles YAML (default: embedded CNCF Due Diligence profiles)")
+		disableRules        = flag.String("disable-rules", "", "Comma-separated rule IDs to skip (see -list-rules)")
+		listRules           = flag.Bool("list-rules", false, "List validation rules and exit")
+		concurrency         = flag.Int("concurrency", projects.DefaultFetchOptions().Concurrency, "Number of projects fetched and validated at once")
+		hostInterval        = flag.Duration("host-interval", projects.DefaultFetchOptions().HostInterval, "Minimum delay between requests to the same host")
+		retries             = flag.Int("retries", projects.DefaultFetchOptions().MaxRetries, "Retries after HTTP 429, 5xx or network errors")
+		landscapeFile       = flag.String("landscape", "", "URL or path of the CNCF landscape.yml used to check landscape categories (default: upstream landscape.yml)")
+		skipLandscape       = flag.Bool("skip-landscape", false, "Skip the landscape category check")
+		repoRoot            = flag.String("repo-root", "", "Validate the project.yaml (and maintainers.yaml, if present) of this checked-out .project repository offline instead of the project list")
+		primaryRepo         = flag.String("primary-repo", "", "Local clone of the project's primary repository, for resolving PathRefs in -repo-root mode")
+	)
+	flag.Parse()
+
+	failThreshold, err := projects.ParseSeverity(*failOn)
+	if err != nil {
+		log.Fatalf("invalid -fail-on value: %v", err)
+	}
+
+	if *configFile == "" {
+		// Create a temporary dummy config file if none provided
+		f, err := os.CreateTemp("", "dummy-projectlist-*.yaml")
+		if err != nil {
+			log.Fatal("failed to create temporary config file")
+		}
+		f.WriteString("projects: []")
+		f.Close()
+		defer os.Remove(f.Name())
+		*configFile = f.Name()
+	}
+
+	// Local mode is offline and keeps its cache in memory
+	if *repoRoot != "" {
+		*cacheDir = ""
+	}
+
+	validator := projects.NewValidator(*cacheDir)
+	if *profilesFile != "" {
+		profiles, err := projects.LoadRequirementProfiles(*profilesFile)
+		if err != nil {
+			log.Fatalf("failed to load requirement profiles: %v", err)
+		}
+		validator.SetRequirementProfiles(profiles)
+	}
+	if err := validator.SetTargetPhase(*targetPhase); err != nil {
+		log.Fatalf("invalid -target-phase value: %v", err)
+	}
+	if *advisoryForge != "" {
+		forge, err := projects.NewForge(*advisoryForge, nil, *forgeURL, "")
+		if err != nil {
+			log.Fatalf("invalid -advisory-forge: %v", err)
+		}
+		validator.SetAdvisoryForges(forge)
+	}
+	if *verifyMaintainers && *verifierName == "" && os.Getenv("LFX_AUTH_TOKEN") == "" {
+		log.Fatalf("-verify-maintainers needs a -verifier (github, gitlab, gitea, lfx or allowlist) or LFX_AUTH_TOKEN")
+	}
+	if *verifierName != "" {
+		token := *githubToken
+		if token == "" {
+			token = os.Getenv("GITHUB_TOKEN")
+		}
+		verifier, err := handleVerifier(*verifierName, *allowListFile, *githubAPIURL, token, *forgeURL, *requireOrgMember)
+		if err != nil {
+			log.Fatalf("invalid -verifier: %v", err)
+		}
+		// Network lookups are cached; the allow-list is read fresh each run
+		if *verifierName != "allowlist" {
+			cacheFile := ""
+			if *cacheDir != "" {
+				if err := os.MkdirAll(*cacheDir, 0755); err != nil {
+					log.Fatalf("failed to create cache directory: %v", err)
+				}
+				cacheFile = filepath.Join(*cacheDir, "handles-"+verifier.Name()+".json")
+			}
+			verifier, err = projects.NewCachedVerifier(verifier, *verifyCacheTTL, cacheFile)
+			if err != nil {
+				log.Fatalf("failed to load handle cache: %v", err)
+			}
+		}
+		validator.SetHandleVerifier(verifier)
+	}
+	fetchOptions := projects.DefaultFetchOptions()
+	fetchOptions.Concurrency = *concurrency
+	fetchOptions.HostInterval = *hostInterval
+	fetchOptions.MaxRetries = *retries
+	validator.SetFetchOptions(fetchOptions)
+	for _, id := range strings.Split(*disableRules, ",") {
+		if id = strings.TrimSpace(id); id == "" {
+			continue
+		}
+		if err := validator.Rules().Disable(id); err != nil {
+			log.Fatalf("invalid -disable-rules value: %v", err)
+		}
+	}
+
+	if *listRules {
+		for _, rule := range validator.Rules().Rules() {
+			status := "enabled"
+			if !validator.Rules().Enabled(rule.ID()) {
+				status = "disabled"
+			}
+			fmt.Printf("%-28s %-9s %s\n", rule.ID(), status, rule.Description())
+		}
+		return
+	}
+
+	if *repoRoot != "" {
+		if code := validateLocal(validator, *repoRoot, *primaryRepo, *verifyMaintainers, *outputFormat, failThreshold); code != 0 {
+			os.Exit(code)
+		}
+		return
+	}
+
+	projectResults, err := validator.ValidateAll(*configFile)
+	if err != nil {
+		log.Fatalf("validation failed: %v", err)
+	}
+
+	var maintainerResults []projects.MaintainerValidationResult
+	maintainersEnabled := *maintainersFile != ""
+	if maintainersEnabled {
+		var excludedHandles map[string]bool
+		if *baseMaintainersFile != "" {
+			handles, err := validator.ExtractHandles(*baseMaintainersFile)
+			if err != nil {
+				log.Fatalf("failed to extract handles from base maintainers file: %v", err)
+			}
+			excludedHandles = handles
+		}
+
+		results, err := validator.ValidateMaintainersFileWithExclusion(*maintainersFile, *verifyMaintainers, excludedHandles)
+		if err != nil {
+			log.Fatalf("maintainers validation failed: %v", err)
+		}
+		maintainerResults = results
+	}
+
+	var landscapeCategories projects.LandscapeCategories
+	if !*skipLandscape {
+		categories, err := projects.LoadLandscapeCategories(*landscapeFile, &http.Client{Timeout: 30 * time.Second})
+		if err != nil {
+			log.Printf("Skipping landscape category check: %v", err)
+		} else {
+			landscapeCategories = categories
+		}
+	}
+
+	// Cross-project checks: duplicate slugs and repositories, maintainers
+	// entries and landscape categories
+	validator.CheckConsistency(projectResults, maintainerResults, landscapeCategories)
+
+	if code := report(validator, projectResults, maintainerResults, maintainersEnabled, *outputFormat, failThreshold); code != 0 {
+		os.Exit(code)
+	}
+}
+
+// handleVerifier creates the handle verifier named by -verifier
+func handleVerifier(name, allowListFile, githubAPIURL, githubToken, forgeURL string, requireOrgMembership bool) (projects.HandleVerifier, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	switch name {
+	case "github", "gitlab", "gitea":
+		baseURL, token := githubAPIURL, githubToken
+		if name != "github" {
+			baseURL, token = forgeURL, os.Getenv(strings.ToUpper(name)+"_TOKEN")
+		}
+		forge, err := projects.NewForge(name, client, baseURL, token)
+		if err != nil {
+			return nil, err
+		}
+		verifier := projects.NewForgeVerifier(forge)
+		verifier.RequireOrgMembership = requireOrgMembership
+		return verifier, nil
+	case "lfx":
+		token := os.Getenv("LFX_AUTH_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("lfx requires LFX_AUTH_TOKEN")
+		}
+		return projects.NewLFXVerifier(client, os.Getenv("MAINTAINER_API_ENDPOINT"), token), nil
+	case "allowlist":
+		if allowListFile == "" {
+			return nil, fmt.Errorf("allowlist requires -allowlist")
+		}
+		return projects.LoadAllowList(allowListFile)
+	default:
+		return nil, fmt.Errorf("unknown verifier %q (use github, gitlab, gitea, lfx or allowlist)", name)
+	}
+}
+
+// validateLocal validates a checked-out .project repository and returns the
+// exit code. maintainers.yaml is validated and cross-checked against the
+// project when the repository has one.
+func validateLocal(validator *projects.ProjectValidator, repoRoot, primaryRepo string, verify bool, format string, failThreshold projects.Severity) int {
+	result, err := validator.ValidateLocal(projects.LocalCheckout{RepoRoot: repoRoot, PrimaryRepo: primaryRepo})
+	if err != nil {
+		log.Fatalf("validation failed: %v", err)
+	}
+	projectResults := []projects.ValidationResult{result}
+
+	var maintainerResults []projects.MaintainerValidationResult
+	maintainersPath := filepath.Join(repoRoot, "maintainers.yaml")
+	_, statErr := os.Stat(maintainersPath)
+	maintainersEnabled := statErr == nil
+	if maintainersEnabled {
+		maintainerResults, err = validator.ValidateMaintainersFile(maintainersPath, verify)
+		if err != nil {
+			log.Fatalf("maintainers validation failed: %v", err)
+		}
+	}
+	validator.CheckConsistency(projectResults, maintainerResults, nil)
+
+	return report(validator, projectResults, maintainerResults, maintainersEnabled, format, failThreshold)
+}
+
+// report prints the results and returns the exit code: 1 if any project has
+// a finding at or above failThreshold or any maintainers entry is invalid
+func report(validator *projects.ProjectValidator, projectResults []projects.ValidationResult, maintainerResults []projects.MaintainerValidationResult, maintainersEnabled bool, format string, failThreshold projects.Severity) int {
+	output, err := validator.FormatResults(projectResults, format)
+	if err != nil {
+		log.Fatalf("failed to format project results: %v", err)
+	}
+	fmt.Print(output)
+	if maintainersEnabled {
+		fmt.Println()
+		maintainersOutput, err := validator.FormatMaintainersResults(maintainerResults, format)
+		if err != nil {
+			log.Fatalf("failed to format maintainer results: %v", err)
+		}
+		fmt.Print(maintainersOutput)
+	}
+
+	// Check if any validation failed at or above the configured severity
+	for _, result := range projectResults {
+		if !result.Valid || projects.HasSeverity(result.Diagnostics, failThreshold) {
+			return 1
+		}
+	}
+	for _, result := range maintainerResults {
+		if !result.Valid {
+			return 1
+		}
+	}
+	return 0
+}
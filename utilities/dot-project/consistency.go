@@ -0,0 +1,307 @@
+package projects
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Cross-project rule IDs. These checks need the whole project list, so they
+// run in CheckConsistency rather than per project.
+const (
+	duplicateSlugRuleID       = "duplicate-slug"
+	duplicateRepositoryRuleID = "duplicate-repository"
+	maintainersEntryRuleID    = "maintainers-entry"
+	landscapeCategoryRuleID   = "landscape-category"
+)
+
+// consistencyRules describes the cross-project checks, in the order they are run
+var consistencyRules = []consistencyRule{
+	{duplicateSlugRuleID, "slug is not used by another project in the list"},
+	{duplicateRepositoryRuleID, "repositories are not listed by another project"},
+	{maintainersEntryRuleID, "Project has a maintainers.yaml entry, and every entry's project_id names a project"},
+	{landscapeCategoryRuleID, "landscape category/subcategory exist in the CNCF Landscape"},
+}
+
+// consistencyRule registers a cross-project check with a validator's rule
+// registry so it can be listed, disabled and suppressed like any other rule.
+// Its findings come from CheckConsistency, so Check reports nothing.
+type consistencyRule struct {
+	id          string
+	description string
+}
+
+func (r consistencyRule) ID() string                 { return r.id }
+func (r consistencyRule) Description() string        { return r.description }
+func (r consistencyRule) Check(Project) []Diagnostic { return nil }
+
+// isConsistencyRule reports whether id names a cross-project check
+func isConsistencyRule(id string) bool {
+	for _, rule := range consistencyRules {
+		if rule.id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// parsedProject keeps what the consistency pass needs from a validated project
+type parsedProject struct {
+	project      Project
+	root         *yaml.Node        // Decoded document, for line/column of cross-project findings
+	listID       string            // id of the project list entry, if any
+	suppressions []RuleSuppression // project.yaml and project list suppressions
+}
+
+// LandscapeCategories maps each CNCF Landscape category to its subcategories
+type LandscapeCategories map[string][]string
+
+// Has reports whether the landscape has the category/subcategory pair
+func (c LandscapeCategories) Has(category, subcategory string) bool {
+	for _, sub := range c[category] {
+		if sub == subcategory {
+			return true
+		}
+	}
+	return false
+}
+
+// Categories returns the category names in sorted order
+func (c LandscapeCategories) Categories() []string {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadLandscapeCategories reads the categories and subcategories of a CNCF
+// landscape.yml from a URL or local file. An empty source uses the upstream
+// landscape.yml.
+func LoadLandscapeCategories(source string, client *http.Client) (LandscapeCategories, error) {
+	if source == "" {
+		source = defaultLandscapeYAMLURL
+	}
+
+	var data []byte
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		resp, err := client.Get(source)
+		if err != nil {
+			return nil, fmt.Errorf("landscape YAML request failed: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("landscape YAML returned HTTP %d", resp.StatusCode)
+		}
+		if data, err = io.ReadAll(resp.Body); err != nil {
+			return nil, fmt.Errorf("reading landscape YAML: %w", err)
+		}
+	} else {
+		var err error
+		if data, err = os.ReadFile(source); err != nil {
+			return nil, fmt.Errorf("reading landscape YAML: %w", err)
+		}
+	}
+
+	var root landscapeYAMLRoot
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("parsing landscape YAML: %w", err)
+	}
+	categories := make(LandscapeCategories)
+	for _, cat := range root.Landscape {
+		for _, sub := range cat.Subcategories {
+			categories[cat.Name] = append(categories[cat.Name], sub.Name)
+		}
+	}
+	return categories, nil
+}
+
+// CheckConsistency runs the cross-project checks over the results of
+// ValidateProjects and records the findings on the affected results:
+// duplicate slugs and repositories, projects without a maintainers entry and
+// landscape category/subcategory pairs that do not exist. maintainers entries
+// whose project_id matches no project get an error on their own result,
+// under the same rule. Pass nil maintainers or landscape to skip the checks
+// that need them.
+func (pv *ProjectValidator) CheckConsistency(results []ValidationResult, maintainers []MaintainerValidationResult, landscape LandscapeCategories) {
+	findings := make([]diagnosticSet, len(results))
+
+	if pv.rules.Enabled(duplicateSlugRuleID) {
+		checkDuplicateSlugs(results, findings)
+	}
+	if pv.rules.Enabled(duplicateRepositoryRuleID) {
+		checkDuplicateRepositories(results, findings)
+	}
+	if maintainers != nil && pv.rules.Enabled(maintainersEntryRuleID) {
+		checkMaintainersProjects(results, maintainers, findings)
+	}
+	if landscape != nil && pv.rules.Enabled(landscapeCategoryRuleID) {
+		checkLandscapeCategories(results, landscape, findings)
+	}
+
+	for i := range results {
+		parsed := results[i].parsed
+		if parsed == nil || len(findings[i]) == 0 {
+			continue
+		}
+		var kept []Diagnostic
+		for _, d := range findings[i] {
+			if !isSuppressed(d, parsed.suppressions) {
+				kept = append(kept, d)
+			}
+		}
+		locateDiagnostics(parsed.root, kept)
+		results[i].addDiagnostics(kept...)
+	}
+}
+
+// isSuppressed reports whether any suppression waives the diagnostic
+func isSuppressed(d Diagnostic, suppressions []RuleSuppression) bool {
+	for _, s := range suppressions {
+		if s.Matches(d) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDuplicateSlugs reports each project whose slug is also used by
+// another project in the list
+func checkDuplicateSlugs(results []ValidationResult, findings []diagnosticSet) {
+	bySlug := make(map[string][]int)
+	for i, r := range results {
+		if r.parsed != nil && r.parsed.project.Slug != "" {
+			bySlug[r.parsed.project.Slug] = append(bySlug[r.parsed.project.Slug], i)
+		}
+	}
+	for i, r := range results {
+		if r.parsed == nil {
+			continue
+		}
+		slug := r.parsed.project.Slug
+		if owners := bySlug[slug]; len(owners) > 1 {
+			findings[i].errorf(duplicateSlugRuleID, "slug", "slug %q is also used by %s", slug, otherURLs(results, owners, i))
+		}
+	}
+}
+
+// checkDuplicateRepositories reports each repository URL listed by more than
+// one project. URLs are compared ignoring case, a trailing slash and ".git".
+func checkDuplicateRepositories(results []ValidationResult, findings []diagnosticSet) {
+	byRepo := make(map[string][]int)
+	for i, r := range results {
+		if r.parsed == nil {
+			continue
+		}
+		for _, repo := range r.parsed.project.Repositories {
+			key := normalizeRepositoryURL(repo)
+			if owners := byRepo[key]; len(owners) == 0 || owners[len(owners)-1] != i {
+				byRepo[key] = append(owners, i)
+			}
+		}
+	}
+	for i, r := range results {
+		if r.parsed == nil {
+			continue
+		}
+		for j, repo := range r.parsed.project.Repositories {
+			if owners := byRepo[normalizeRepositoryURL(repo)]; len(owners) > 1 {
+				findings[i].errorf(duplicateRepositoryRuleID, fmt.Sprintf("repositories[%d]", j),
+					"repository %s is also listed by %s", repo, otherURLs(results, owners, i))
+			}
+		}
+	}
+}
+
+// checkMaintainersProjects cross-references maintainers.yaml entries and
+// projects. A project matches an entry by slug or by its project list id.
+// Unknown project_ids are only reported when every project could be parsed,
+// since a project that failed to load may be the one they refer to.
+func checkMaintainersProjects(results []ValidationResult, maintainers []MaintainerValidationResult, findings []diagnosticSet) {
+	entries := make(map[string]bool)
+	for _, m := range maintainers {
+		if m.ProjectID != "" {
+			entries[m.ProjectID] = true
+		}
+	}
+
+	known := make(map[string]bool)
+	complete := true
+	for i, r := range results {
+		if r.parsed == nil {
+			complete = false
+			continue
+		}
+		slug, listID := r.parsed.project.Slug, r.parsed.listID
+		known[slug], known[listID] = true, true
+		if slug != "" && !entries[slug] && !entries[listID] {
+			findings[i].add(SeverityWarning, maintainersEntryRuleID, "slug", "no maintainers.yaml entry has project_id %q", slug)
+		}
+	}
+
+	if !complete {
+		log.Printf("Skipping maintainers project_id check: not every project could be parsed")
+		return
+	}
+	// Maintainers entries are not projects, so there is no validation.ignore
+	// to consult; the rule ID is kept in the message like a diagnostic's
+	for i := range maintainers {
+		if id := maintainers[i].ProjectID; id != "" && !known[id] {
+			maintainers[i].Errors = append(maintainers[i].Errors,
+				fmt.Sprintf("project_id %q does not match any project in the project list [%s]", id, maintainersEntryRuleID))
+			maintainers[i].Valid = false
+		}
+	}
+}
+
+// checkLandscapeCategories reports landscape sections whose category or
+// subcategory is not in the CNCF Landscape
+func checkLandscapeCategories(results []ValidationResult, landscape LandscapeCategories, findings []diagnosticSet) {
+	for i, r := range results {
+		if r.parsed == nil || r.parsed.project.Landscape == nil {
+			continue
+		}
+		category, subcategory := r.parsed.project.Landscape.Category, r.parsed.project.Landscape.Subcategory
+		switch {
+		case category == "":
+			// Reported by landscape-required
+		case landscape[category] == nil:
+			findings[i].errorf(landscapeCategoryRuleID, "landscape.category", "landscape category %q does not exist in the CNCF Landscape", category)
+		case subcategory != "" && !landscape.Has(category, subcategory):
+			message := fmt.Sprintf("landscape subcategory %q does not exist in category %q", subcategory, category)
+			for _, other := range landscape.Categories() {
+				if landscape.Has(other, subcategory) {
+					message += fmt.Sprintf(" (it belongs to %q)", other)
+					break
+				}
+			}
+			findings[i].errorf(landscapeCategoryRuleID, "landscape.subcategory", "%s", message)
+		}
+	}
+}
+
+// normalizeRepositoryURL reduces a repository URL to a comparison key
+func normalizeRepositoryURL(repo string) string {
+	key := strings.ToLower(strings.TrimSpace(repo))
+	key = strings.TrimSuffix(key, "/")
+	key = strings.TrimSuffix(key, ".git")
+	return key
+}
+
+// otherURLs lists the URLs of the results at indexes other than self
+func otherURLs(results []ValidationResult, indexes []int, self int) string {
+	var urls []string
+	for _, i := range indexes {
+		if i != self {
+			urls = append(urls, results[i].URL)
+		}
+	}
+	return strings.Join(urls, ", ")
+}
@@ -0,0 +1,213 @@
+package projects
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testLandscapeYAML = `landscape:
+  - name: Orchestration & Management
+    subcategories:
+      - name: Scheduling & Orchestration
+        items: []
+      - name: Service Mesh
+        items: []
+  - name: Observability and Analysis
+    subcategories:
+      - name: Monitoring
+        items: []
+`
+
+// consistencyProjectYAML returns a valid project with the given slug,
+// repository and landscape location
+func consistencyProjectYAML(slug, repo, category, subcategory string) string {
+	content := strings.Replace(validProjectYAML(), "slug: test-project", "slug: "+slug, 1)
+	content = strings.Replace(content, "https://github.com/test/repo", repo, 1)
+	if category != "" {
+		content += fmt.Sprintf("landscape:\n  category: %s\n  subcategory: %s\n", category, subcategory)
+	}
+	return content
+}
+
+// validateConsistencyFixture validates the given project files through a
+// project list and returns the results in list order
+func validateConsistencyFixture(t *testing.T, pv *ProjectValidator, files ...string) []ValidationResult {
+	t.Helper()
+	dir := t.TempDir()
+	var list strings.Builder
+	list.WriteString("projects:\n")
+	for i, content := range files {
+		path := filepath.Join(dir, fmt.Sprintf("p%d.yaml", i))
+		writeFile(t, path, content)
+		fmt.Fprintf(&list, "  - url: \"%s\"\n", path)
+	}
+	listPath := filepath.Join(dir, "projectlist.yaml")
+	writeFile(t, listPath, list.String())
+
+	results, err := pv.ValidateAll(listPath)
+	if err != nil {
+		t.Fatalf("ValidateAll: %v", err)
+	}
+	return results
+}
+
+// findDiagnostic returns the first diagnostic with the given rule, or nil
+func findDiagnostic(diags []Diagnostic, rule string) *Diagnostic {
+	for i := range diags {
+		if diags[i].Rule == rule {
+			return &diags[i]
+		}
+	}
+	return nil
+}
+
+func TestCheckConsistencyDuplicates(t *testing.T) {
+	pv := newTestValidator(t)
+	results := validateConsistencyFixture(t, pv,
+		consistencyProjectYAML("alpha", "https://github.com/org/shared", "", ""),
+		consistencyProjectYAML("alpha", "https://github.com/org/alpha-two", "", ""),
+		consistencyProjectYAML("beta", "https://github.com/Org/shared.git", "", ""),
+	)
+	pv.CheckConsistency(results, nil, nil)
+
+	slug := findDiagnostic(results[0].Diagnostics, duplicateSlugRuleID)
+	if slug == nil || !strings.Contains(slug.Message, results[1].URL) || slug.Path != "slug" || slug.Line != 2 {
+		t.Errorf("expected duplicate slug error on slug (line 2) naming the other project, got %+v", slug)
+	}
+	if findDiagnostic(results[1].Diagnostics, duplicateSlugRuleID) == nil {
+		t.Error("expected duplicate slug error on both projects")
+	}
+	if findDiagnostic(results[2].Diagnostics, duplicateSlugRuleID) != nil {
+		t.Error("beta has a unique slug")
+	}
+
+	repo := findDiagnostic(results[2].Diagnostics, duplicateRepositoryRuleID)
+	if repo == nil || repo.Path != "repositories[0]" || !strings.Contains(repo.Message, results[0].URL) {
+		t.Errorf("expected duplicate repository error naming alpha, got %+v", repo)
+	}
+	if findDiagnostic(results[1].Diagnostics, duplicateRepositoryRuleID) != nil {
+		t.Error("alpha-two lists a unique repository")
+	}
+	for i, r := range results {
+		if r.Valid {
+			t.Errorf("results[%d] should be invalid after the consistency pass", i)
+		}
+	}
+}
+
+func TestCheckConsistencyMaintainers(t *testing.T) {
+	pv := newTestValidator(t)
+	results := validateConsistencyFixture(t, pv,
+		consistencyProjectYAML("alpha", "https://github.com/org/alpha", "", ""),
+		consistencyProjectYAML("beta", "https://github.com/org/beta", "", ""),
+	)
+	maintainers := []MaintainerValidationResult{
+		{ProjectID: "alpha", Valid: true},
+		{ProjectID: "gamma", Valid: true},
+	}
+	pv.CheckConsistency(results, maintainers, nil)
+
+	if d := findDiagnostic(results[1].Diagnostics, maintainersEntryRuleID); d == nil || d.Severity != SeverityWarning {
+		t.Errorf("expected missing maintainers entry warning for beta, got %+v", results[1].Diagnostics)
+	}
+	if d := findDiagnostic(results[0].Diagnostics, maintainersEntryRuleID); d != nil {
+		t.Errorf("alpha has a maintainers entry, got %+v", d)
+	}
+	if !maintainers[0].Valid {
+		t.Errorf("alpha entry should stay valid, got %v", maintainers[0].Errors)
+	}
+	if maintainers[1].Valid || len(maintainers[1].Errors) != 1 || !strings.Contains(maintainers[1].Errors[0], `"gamma"`) ||
+		!strings.Contains(maintainers[1].Errors[0], "["+maintainersEntryRuleID+"]") {
+		t.Errorf("expected unknown project error for gamma, got %+v", maintainers[1])
+	}
+
+	// Disabling the rule silences both directions, e.g. when validating
+	// against part of the project list
+	pv = newTestValidator(t)
+	if err := pv.Rules().Disable(maintainersEntryRuleID); err != nil {
+		t.Fatalf("Disable: %v", err)
+	}
+	results = validateConsistencyFixture(t, pv,
+		consistencyProjectYAML("beta", "https://github.com/org/beta", "", ""),
+	)
+	maintainers = []MaintainerValidationResult{{ProjectID: "gamma", Valid: true}}
+	pv.CheckConsistency(results, maintainers, nil)
+	if !maintainers[0].Valid || findDiagnostic(results[0].Diagnostics, maintainersEntryRuleID) != nil {
+		t.Errorf("expected no maintainers-entry findings with the rule disabled, got %+v / %+v", maintainers[0], results[0].Diagnostics)
+	}
+}
+
+func TestCheckConsistencyLandscape(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testLandscapeYAML))
+	}))
+	defer srv.Close()
+
+	landscape, err := LoadLandscapeCategories(srv.URL, srv.Client())
+	if err != nil {
+		t.Fatalf("LoadLandscapeCategories: %v", err)
+	}
+	if !landscape.Has("Orchestration & Management", "Service Mesh") || landscape.Has("Orchestration & Management", "Monitoring") {
+		t.Fatalf("unexpected categories: %v", landscape)
+	}
+
+	pv := newTestValidator(t)
+	results := validateConsistencyFixture(t, pv,
+		consistencyProjectYAML("alpha", "https://github.com/org/alpha", `"Orchestration & Management"`, "Service Mesh"),
+		consistencyProjectYAML("beta", "https://github.com/org/beta", "Runtime", "Container Runtime"),
+		consistencyProjectYAML("gamma", "https://github.com/org/gamma", `"Orchestration & Management"`, "Monitoring"),
+	)
+	pv.CheckConsistency(results, nil, landscape)
+
+	if d := findDiagnostic(results[0].Diagnostics, landscapeCategoryRuleID); d != nil {
+		t.Errorf("alpha has a valid landscape location, got %+v", d)
+	}
+	if d := findDiagnostic(results[1].Diagnostics, landscapeCategoryRuleID); d == nil || d.Path != "landscape.category" {
+		t.Errorf("expected unknown category error for beta, got %+v", d)
+	}
+	d := findDiagnostic(results[2].Diagnostics, landscapeCategoryRuleID)
+	if d == nil || d.Path != "landscape.subcategory" || !strings.Contains(d.Message, `(it belongs to "Observability and Analysis")`) {
+		t.Errorf("expected subcategory error pointing at the right category for gamma, got %+v", d)
+	}
+}
+
+func TestCheckConsistencySuppressionsAndDisabledRules(t *testing.T) {
+	suppressed := consistencyProjectYAML("alpha", "https://github.com/org/shared", "", "") +
+		"validation:\n  ignore:\n    - rule: duplicate-repository\n      reason: Monorepo shared with beta\n"
+	pv := newTestValidator(t)
+	results := validateConsistencyFixture(t, pv,
+		suppressed,
+		consistencyProjectYAML("beta", "https://github.com/org/shared", "", ""),
+	)
+	for _, d := range results[0].Diagnostics {
+		if d.Rule == suppressionRule {
+			t.Errorf("suppressing a cross-project rule should not be reported, got %+v", d)
+		}
+	}
+	pv.CheckConsistency(results, nil, nil)
+	if d := findDiagnostic(results[0].Diagnostics, duplicateRepositoryRuleID); d != nil {
+		t.Errorf("duplicate repository should be suppressed for alpha, got %+v", d)
+	}
+	if d := findDiagnostic(results[1].Diagnostics, duplicateRepositoryRuleID); d == nil {
+		t.Error("beta has no suppression and should still be reported")
+	}
+
+	pv = newTestValidator(t)
+	if err := pv.Rules().Disable(duplicateRepositoryRuleID); err != nil {
+		t.Fatalf("Disable: %v", err)
+	}
+	results = validateConsistencyFixture(t, pv,
+		consistencyProjectYAML("alpha", "https://github.com/org/shared", "", ""),
+		consistencyProjectYAML("beta", "https://github.com/org/shared", "", ""),
+	)
+	pv.CheckConsistency(results, nil, nil)
+	for i, r := range results {
+		if !r.Valid {
+			t.Errorf("results[%d] should be valid with duplicate-repository disabled, got %v", i, r.Errors)
+		}
+	}
+}
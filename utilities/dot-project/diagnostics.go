@@ -0,0 +1,196 @@
+package projects
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity classifies how serious a validation finding is
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// severityRank orders severities so thresholds can be compared
+var severityRank = map[Severity]int{
+	SeverityInfo:    0,
+	SeverityWarning: 1,
+	SeverityError:   2,
+}
+
+// ParseSeverity converts a string such as "warning" into a Severity
+func ParseSeverity(s string) (Severity, error) {
+	sev := Severity(strings.ToLower(strings.TrimSpace(s)))
+	if _, ok := severityRank[sev]; !ok {
+		return "", fmt.Errorf("unknown severity %q (allowed: error, warning, info)", s)
+	}
+	return sev, nil
+}
+
+// AtLeast reports whether s is as severe as, or more severe than, threshold
+func (s Severity) AtLeast(threshold Severity) bool {
+	return severityRank[s] >= severityRank[threshold]
+}
+
+// Diagnostic is a single validation finding addressed by its field path
+type Diagnostic struct {
+	Path     string   `json:"path,omitempty" yaml:"path,omitempty"` // Field path (e.g., "governance.maintainer_lifecycle.onboarding_doc.path")
+	Rule     string   `json:"rule" yaml:"rule"`                     // Identifier of the check that produced the finding (e.g., "slug-format")
+	Severity Severity `json:"severity" yaml:"severity"`
+	Message  string   `json:"message" yaml:"message"`
+	Line     int      `json:"line,omitempty" yaml:"line,omitempty"`     // 1-based source line, 0 if unknown
+	Column   int      `json:"column,omitempty" yaml:"column,omitempty"` // 1-based source column, 0 if unknown
+}
+
+// String renders the diagnostic as a single human-readable line
+func (d Diagnostic) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%s: %s", d.Severity, d.Message))
+	if d.Rule != "" {
+		b.WriteString(fmt.Sprintf(" [%s]", d.Rule))
+	}
+	if d.Line > 0 {
+		b.WriteString(fmt.Sprintf(" (line %d, column %d)", d.Line, d.Column))
+	}
+	return b.String()
+}
+
+// CountDiagnostics tallies diagnostics by severity
+func CountDiagnostics(diags []Diagnostic) map[Severity]int {
+	counts := make(map[Severity]int)
+	for _, d := range diags {
+		counts[d.Severity]++
+	}
+	return counts
+}
+
+// HasSeverity reports whether any diagnostic is at or above the given threshold
+func HasSeverity(diags []Diagnostic, threshold Severity) bool {
+	for _, d := range diags {
+		if d.Severity.AtLeast(threshold) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterDiagnostics returns the diagnostics with exactly the given severity
+func FilterDiagnostics(diags []Diagnostic, severity Severity) []Diagnostic {
+	var filtered []Diagnostic
+	for _, d := range diags {
+		if d.Severity == severity {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered
+}
+
+// diagnosticMessages flattens diagnostics to their messages
+func diagnosticMessages(diags []Diagnostic) []string {
+	var messages []string
+	for _, d := range diags {
+		messages = append(messages, d.Message)
+	}
+	return messages
+}
+
+// pathSegmentPattern splits a field path such as "maturity_log[0].phase" into
+// mapping keys and sequence indexes
+var pathSegmentPattern = regexp.MustCompile(`[^.\[\]]+|\[\d+\]`)
+
+// locateDiagnostics fills in Line and Column for each diagnostic by walking its
+// field path through the decoded YAML document. When the exact node does not
+// exist (e.g., a missing required field) the nearest enclosing node is used.
+func locateDiagnostics(root *yaml.Node, diags []Diagnostic) {
+	if root == nil {
+		return
+	}
+	doc := root
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		doc = doc.Content[0]
+	}
+	for i := range diags {
+		if diags[i].Line > 0 {
+			continue
+		}
+		node := findNode(doc, diags[i].Path)
+		if node != nil {
+			diags[i].Line = node.Line
+			diags[i].Column = node.Column
+		}
+	}
+}
+
+// findNode resolves a field path to the deepest matching YAML node
+func findNode(node *yaml.Node, path string) *yaml.Node {
+	current := node
+	for _, segment := range pathSegmentPattern.FindAllString(path, -1) {
+		next := childNode(current, segment)
+		if next == nil {
+			break
+		}
+		current = next
+	}
+	return current
+}
+
+// childNode returns the value node for a mapping key or sequence index segment
+func childNode(node *yaml.Node, segment string) *yaml.Node {
+	if strings.HasPrefix(segment, "[") {
+		if node.Kind != yaml.SequenceNode {
+			return nil
+		}
+		idx, err := strconv.Atoi(strings.Trim(segment, "[]"))
+		if err != nil || idx < 0 || idx >= len(node.Content) {
+			return nil
+		}
+		return node.Content[idx]
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == segment {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// yamlErrorLinePattern extracts the line number from yaml.v3 error messages
+var yamlErrorLinePattern = regexp.MustCompile(`line (\d+)`)
+
+// yamlErrorLine returns the source line referenced by a YAML decode error, or 0
+func yamlErrorLine(err error) int {
+	m := yamlErrorLinePattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0
+	}
+	line, _ := strconv.Atoi(m[1])
+	return line
+}
+
+// diagnosticSet accumulates diagnostics while a project is being checked
+type diagnosticSet []Diagnostic
+
+// add appends a diagnostic with the given severity
+func (s *diagnosticSet) add(severity Severity, rule, path, format string, args ...interface{}) {
+	*s = append(*s, Diagnostic{
+		Path:     path,
+		Rule:     rule,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// errorf appends an error-severity diagnostic
+func (s *diagnosticSet) errorf(rule, path, format string, args ...interface{}) {
+	s.add(SeverityError, rule, path, format, args...)
+}
@@ -0,0 +1,210 @@
+package projects
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidateProjectDiagnostics(t *testing.T) {
+	project := validBaseProject()
+	project.Slug = "Bad_Slug"
+	project.CNCFSlackChannel = "general"
+	project.Governance = &GovernanceConfig{
+		MaintainerLifecycle: MaintainerLifecycle{OnboardingDoc: &PathRef{}},
+	}
+
+	diags := validateProjectDiagnostics(project)
+
+	expected := []struct {
+		path string
+		rule string
+	}{
+		{"slug", "slug-format"},
+		{"cncf_slack_channel", "slack-channel-prefix"},
+		{"governance.maintainer_lifecycle.onboarding_doc.path", "pathref-path"},
+	}
+	for _, exp := range expected {
+		found := false
+		for _, d := range diags {
+			if d.Path == exp.path && d.Rule == exp.rule {
+				found = true
+				if d.Severity != SeverityError {
+					t.Errorf("expected %s to be an error, got %s", exp.path, d.Severity)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("expected diagnostic %s (%s) not found in: %v", exp.path, exp.rule, diags)
+		}
+	}
+
+	// The string wrapper must report the same findings as messages
+	messages := validateProjectStruct(project)
+	if len(messages) != len(diags) {
+		t.Errorf("expected %d messages, got %d", len(diags), len(messages))
+	}
+}
+
+func TestLocateDiagnostics(t *testing.T) {
+	content := `schema_version: "1.0.0"
+slug: Bad_Slug
+maturity_log:
+  - phase: sandbox
+    issue: ""
+governance:
+  maintainer_lifecycle:
+    onboarding_doc:
+      path: ""
+`
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &root); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	diags := []Diagnostic{
+		{Path: "slug"},
+		{Path: "maturity_log[0].issue"},
+		{Path: "governance.maintainer_lifecycle.onboarding_doc.path"},
+		{Path: "maturity_log[0].date"}, // missing: falls back to enclosing entry
+		{Path: "name"},                 // missing at top level: falls back to document
+	}
+	locateDiagnostics(&root, diags)
+
+	expected := []struct{ line, column int }{
+		{2, 7},
+		{5, 12},
+		{9, 13},
+		{4, 5},
+		{1, 1},
+	}
+	for i, exp := range expected {
+		if diags[i].Line != exp.line || diags[i].Column != exp.column {
+			t.Errorf("%s: expected %d:%d, got %d:%d", diags[i].Path, exp.line, exp.column, diags[i].Line, diags[i].Column)
+		}
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Severity
+		wantErr  bool
+	}{
+		{"error", SeverityError, false},
+		{"Warning", SeverityWarning, false},
+		{" info ", SeverityInfo, false},
+		{"fatal", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSeverity(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSeverity(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.expected {
+			t.Errorf("ParseSeverity(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+
+	if !SeverityError.AtLeast(SeverityWarning) {
+		t.Error("error should be at least warning")
+	}
+	if SeverityInfo.AtLeast(SeverityWarning) {
+		t.Error("info should not be at least warning")
+	}
+}
+
+func TestDiagnosticCounts(t *testing.T) {
+	diags := []Diagnostic{
+		{Severity: SeverityError},
+		{Severity: SeverityWarning},
+		{Severity: SeverityWarning},
+		{Severity: SeverityInfo},
+	}
+	counts := CountDiagnostics(diags)
+	if counts[SeverityError] != 1 || counts[SeverityWarning] != 2 || counts[SeverityInfo] != 1 {
+		t.Errorf("unexpected counts: %v", counts)
+	}
+	if !HasSeverity(diags, SeverityError) {
+		t.Error("expected HasSeverity(error) to be true")
+	}
+	if HasSeverity(diags[1:], SeverityError) {
+		t.Error("expected HasSeverity(error) to be false without errors")
+	}
+	if got := len(FilterDiagnostics(diags, SeverityWarning)); got != 2 {
+		t.Errorf("expected 2 warnings, got %d", got)
+	}
+}
+
+func TestValidateProjectDiagnosticPositions(t *testing.T) {
+	dir := t.TempDir()
+	projPath := filepath.Join(dir, "project.yaml")
+	writeFile(t, projPath, strings.Replace(validProjectYAML(), "slug: test-project", "slug: Test_Project", 1))
+
+	pv := newTestValidator(t)
+	result, err := pv.validateProject("file://" + projPath)
+	if err != nil {
+		t.Fatalf("validateProject: %v", err)
+	}
+	if result.Valid {
+		t.Fatal("expected invalid result")
+	}
+	if len(result.Diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %v", result.Diagnostics)
+	}
+	d := result.Diagnostics[0]
+	if d.Rule != "slug-format" || d.Line != 2 || d.Column != 7 {
+		t.Errorf("unexpected diagnostic: %+v", d)
+	}
+	if len(result.Errors) != 1 || result.Errors[0] != d.Message {
+		t.Errorf("expected Errors to mirror error diagnostics, got %v", result.Errors)
+	}
+}
+
+func TestValidateProjectParseErrorLine(t *testing.T) {
+	dir := t.TempDir()
+	projPath := filepath.Join(dir, "project.yaml")
+	writeFile(t, projPath, validProjectYAML()+"unknown_field: true\n")
+
+	pv := newTestValidator(t)
+	result, err := pv.validateProject("file://" + projPath)
+	if err != nil {
+		t.Fatalf("validateProject: %v", err)
+	}
+	if len(result.Diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %v", result.Diagnostics)
+	}
+	d := result.Diagnostics[0]
+	if d.Rule != "yaml-parse" || d.Line != 11 {
+		t.Errorf("expected yaml-parse diagnostic on line 11, got %+v", d)
+	}
+}
+
+func TestGenerateDiffWarnings(t *testing.T) {
+	pv := newTestValidator(t)
+	results := []ValidationResult{
+		{
+			URL:         "file:///warn.yaml",
+			ProjectName: "warn-proj",
+			Valid:       true,
+			Diagnostics: []Diagnostic{
+				{Path: "governance.contributor_ladder", Rule: "requirement-profile", Severity: SeverityWarning, Message: "governance.contributor_ladder is suggested"},
+			},
+		},
+	}
+	diff := pv.GenerateDiff(results)
+	if strings.Contains(diff, "INVALID") {
+		t.Error("warnings alone should not mark a project invalid")
+	}
+	if !strings.Contains(diff, "WARNINGS: warn-proj") {
+		t.Error("diff should contain WARNINGS line")
+	}
+	if !strings.Contains(diff, "warning: governance.contributor_ladder is suggested [requirement-profile]") {
+		t.Errorf("diff should list warning details, got:\n%s", diff)
+	}
+	if !strings.Contains(diff, "0 with errors, 1 with warnings") {
+		t.Errorf("summary should count warnings, got:\n%s", diff)
+	}
+}
@@ -0,0 +1,215 @@
+package projects
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxRetryAfter caps how long a Retry-After header can make the fetcher wait
+const maxRetryAfter = time.Minute
+
+// FetchOptions controls how project files are fetched during validation
+type FetchOptions struct {
+	Concurrency  int           // Projects validated at once
+	HostInterval time.Duration // Minimum delay between requests to the same host
+	MaxRetries   int           // Retries after a 429, 5xx or network error
+	RetryBackoff time.Duration // Delay before the first retry, doubled for each further retry
+}
+
+// DefaultFetchOptions returns the fetch settings used by the validator CLI
+func DefaultFetchOptions() FetchOptions {
+	return FetchOptions{
+		Concurrency:  8,
+		HostInterval: 100 * time.Millisecond,
+		MaxRetries:   3,
+		RetryBackoff: time.Second,
+	}
+}
+
+// SetFetchOptions replaces the concurrency, rate limiting and retry settings
+func (pv *ProjectValidator) SetFetchOptions(opts FetchOptions) {
+	pv.fetchOptions = opts
+	pv.limiter = newHostLimiter(opts.HostInterval)
+}
+
+// fetchResult is the outcome of fetching a project file
+type fetchResult struct {
+	Content      string
+	ETag         string
+	LastModified string
+	NotModified  bool // The server answered 304 and Content is the cached copy
+}
+
+// hostLimiter spaces out requests to the same host
+type hostLimiter struct {
+	interval time.Duration
+	mu       sync.Mutex
+	next     map[string]time.Time
+}
+
+func newHostLimiter(interval time.Duration) *hostLimiter {
+	return &hostLimiter{interval: interval, next: make(map[string]time.Time)}
+}
+
+// wait blocks until a request to host may be sent
+func (l *hostLimiter) wait(host string) {
+	if l == nil || l.interval <= 0 {
+		return
+	}
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next[host] = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	time.Sleep(time.Until(slot))
+}
+
+// isLocalURL reports whether a project list URL refers to a local file
+func isLocalURL(u string) bool {
+	return strings.HasPrefix(u, "file://") || (!strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://"))
+}
+
+// fetchContent fetches content from a URL or local file
+func (pv *ProjectValidator) fetchContent(url string) (string, error) {
+	result, err := pv.fetch(url, nil)
+	if err != nil {
+		return "", err
+	}
+	return result.Content, nil
+}
+
+// fetch reads a local file or GETs a URL. When cached carries an ETag or
+// Last-Modified value the request is conditional, and a 304 returns the
+// cached content. 429 and 5xx responses are retried with backoff.
+func (pv *ProjectValidator) fetch(rawURL string, cached *CacheEntry) (fetchResult, error) {
+	if isLocalURL(rawURL) {
+		data, err := os.ReadFile(strings.TrimPrefix(rawURL, "file://"))
+		if err != nil {
+			return fetchResult{}, err
+		}
+		return fetchResult{Content: string(data)}, nil
+	}
+
+	host := ""
+	if parsed, err := url.Parse(rawURL); err == nil {
+		host = parsed.Host
+	}
+
+	backoff := pv.fetchOptions.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		pv.limiter.wait(host)
+		result, retryAfter, err := pv.fetchOnce(rawURL, cached)
+		if err == nil || retryAfter < 0 || attempt >= pv.fetchOptions.MaxRetries {
+			return result, err
+		}
+
+		delay := backoff
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+		time.Sleep(delay)
+		backoff *= 2
+	}
+}
+
+// fetchOnce sends a single GET. retryAfter is negative when the error is not
+// worth retrying, and otherwise the delay the server asked for (0 if none).
+func (pv *ProjectValidator) fetchOnce(rawURL string, cached *CacheEntry) (fetchResult, time.Duration, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fetchResult{}, -1, err
+	}
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := pv.client.Do(req)
+	if err != nil {
+		return fetchResult{}, 0, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		return fetchResult{
+			Content:      cached.Content,
+			ETag:         cached.ETag,
+			LastModified: cached.LastModified,
+			NotModified:  true,
+		}, 0, nil
+	case resp.StatusCode == http.StatusOK:
+		content, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fetchResult{}, 0, err
+		}
+		return fetchResult{
+			Content:      string(content),
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+		}, 0, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fetchResult{}, parseRetryAfter(resp.Header.Get("Retry-After")), fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+	default:
+		return fetchResult{}, -1, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if at, err := http.ParseTime(value); err == nil {
+		delay = time.Until(at)
+	}
+	if delay < 0 {
+		return 0
+	}
+	if delay > maxRetryAfter {
+		return maxRetryAfter
+	}
+	return delay
+}
+
+// forEachConcurrently calls fn for every index in [0, n) from a bounded pool
+// of workers and returns when all calls have finished
+func forEachConcurrently(n, workers int, fn func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -0,0 +1,234 @@
+package projects
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchRetries(t *testing.T) {
+	t.Run("retries 503 then succeeds", func(t *testing.T) {
+		var attempts int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&attempts, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte("ok"))
+		}))
+		defer srv.Close()
+
+		pv := newTestValidator(t)
+		content, err := pv.fetchContent(srv.URL)
+		if err != nil {
+			t.Fatalf("fetchContent: %v", err)
+		}
+		if content != "ok" || atomic.LoadInt32(&attempts) != 3 {
+			t.Errorf("expected success on third attempt, got %q after %d attempts", content, attempts)
+		}
+	})
+
+	t.Run("retries 429 up to the limit", func(t *testing.T) {
+		var attempts int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer srv.Close()
+
+		pv := newTestValidator(t)
+		_, err := pv.fetchContent(srv.URL)
+		if err == nil || !strings.Contains(err.Error(), "429") {
+			t.Fatalf("expected 429 error, got: %v", err)
+		}
+		if got := atomic.LoadInt32(&attempts); got != 4 {
+			t.Errorf("expected 1 attempt plus 3 retries, got %d", got)
+		}
+	})
+
+	t.Run("does not retry 404", func(t *testing.T) {
+		var attempts int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer srv.Close()
+
+		pv := newTestValidator(t)
+		if _, err := pv.fetchContent(srv.URL); err == nil {
+			t.Fatal("expected error for 404")
+		}
+		if got := atomic.LoadInt32(&attempts); got != 1 {
+			t.Errorf("expected a single attempt, got %d", got)
+		}
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"2", 2 * time.Second},
+		{"3600", maxRetryAfter},
+		{"soon", 0},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestConditionalFetch(t *testing.T) {
+	var fullResponses, notModified int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` && r.Header.Get("If-Modified-Since") != "" {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(&fullResponses, 1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		w.Write([]byte(validProjectYAML()))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	listPath := filepath.Join(dir, "projectlist.yaml")
+	writeFile(t, listPath, "projects:\n  - url: \""+srv.URL+"/project.yaml\"\n")
+	cacheDir := filepath.Join(dir, "cache")
+
+	pv := NewValidator(cacheDir)
+	pv.SetFetchOptions(testFetchOptions())
+	first, err := pv.ValidateAll(listPath)
+	if err != nil {
+		t.Fatalf("ValidateAll: %v", err)
+	}
+	if first[0].NotModified || !first[0].Changed {
+		t.Errorf("first run should fetch the full file, got %+v", first[0])
+	}
+
+	// A fresh validator reads the validators back from the saved cache
+	pv = NewValidator(cacheDir)
+	pv.SetFetchOptions(testFetchOptions())
+	second, err := pv.ValidateAll(listPath)
+	if err != nil {
+		t.Fatalf("ValidateAll: %v", err)
+	}
+	r := second[0]
+	if !r.NotModified || r.Changed || !r.Valid || r.ProjectName != "Test Project" {
+		t.Errorf("second run should validate the cached copy after a 304, got %+v", r)
+	}
+	if atomic.LoadInt32(&fullResponses) != 1 || atomic.LoadInt32(&notModified) != 1 {
+		t.Errorf("expected 1 full response and 1 not-modified, got %d and %d", fullResponses, notModified)
+	}
+}
+
+func TestValidateProjectsConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		name := strings.TrimPrefix(r.URL.Path, "/")
+		w.Write([]byte(strings.Replace(validProjectYAML(), "name: Test Project", "name: "+name, 1)))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	var list strings.Builder
+	list.WriteString("projects:\n")
+	for i := 0; i < 10; i++ {
+		fmt.Fprintf(&list, "  - url: \"%s/p%d\"\n", srv.URL, i)
+	}
+	listPath := filepath.Join(dir, "projectlist.yaml")
+	writeFile(t, listPath, list.String())
+
+	pv := NewValidator(filepath.Join(dir, "cache"))
+	pv.SetFetchOptions(FetchOptions{Concurrency: 3})
+	results, err := pv.ValidateAll(listPath)
+	if err != nil {
+		t.Fatalf("ValidateAll: %v", err)
+	}
+	if got := atomic.LoadInt32(&maxInFlight); got > 3 || got < 2 {
+		t.Errorf("expected between 2 and 3 requests in flight, got %d", got)
+	}
+	for i, r := range results {
+		if r.ProjectName != fmt.Sprintf("p%d", i) {
+			t.Errorf("results[%d]: expected project p%d, got %q", i, i, r.ProjectName)
+		}
+	}
+}
+
+func TestHostLimiter(t *testing.T) {
+	limiter := newHostLimiter(20 * time.Millisecond)
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			limiter.wait("example.com")
+		}()
+	}
+	limiter.wait("other.example.com") // other hosts are not delayed
+	if elapsed := time.Since(start); elapsed > 15*time.Millisecond {
+		t.Errorf("request to another host waited %v", elapsed)
+	}
+	wg.Wait()
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("expected three requests to one host to take at least 40ms, took %v", elapsed)
+	}
+}
+
+func TestLoadProjects(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/broken.yaml":
+			w.Write([]byte("slug: [unterminated"))
+		case "/missing.yaml":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Write([]byte(strings.Replace(validProjectYAML(), "slug: test-project", "slug: "+strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/"), ".yaml"), 1)))
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	listPath := filepath.Join(dir, "projectlist.yaml")
+	writeFile(t, listPath, fmt.Sprintf("projects:\n  - url: %[1]s/alpha.yaml\n  - url: %[1]s/missing.yaml\n  - url: %[1]s/broken.yaml\n  - url: %[1]s/beta.yaml\n", srv.URL))
+
+	pv := NewValidator("")
+	pv.SetFetchOptions(testFetchOptions())
+	loaded, err := pv.LoadProjects(listPath)
+	if err != nil {
+		t.Fatalf("LoadProjects: %v", err)
+	}
+	var slugs []string
+	for _, p := range loaded {
+		slugs = append(slugs, p.Slug)
+	}
+	if strings.Join(slugs, ",") != "alpha,beta" {
+		t.Errorf("expected the loadable projects in list order, got %v", slugs)
+	}
+
+	if _, err := pv.LoadProjects(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing project list")
+	}
+}
@@ -0,0 +1,152 @@
+package projects
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// yamlFieldName returns the YAML key for a struct field, or "" if the field is
+// not serialized
+func yamlFieldName(f reflect.StructField) string {
+	tag := f.Tag.Get("yaml")
+	if tag == "-" || !f.IsExported() {
+		return ""
+	}
+	name := strings.Split(tag, ",")[0]
+	if name == "" {
+		name = strings.ToLower(f.Name)
+	}
+	return name
+}
+
+// resolveFieldPath walks a dotted YAML field path (e.g., "governance.code_of_conduct")
+// through a value using yaml struct tags. Nil pointers are traversed as zero
+// values so the second return value only reports whether the path exists in
+// the type, not whether it is set.
+func resolveFieldPath(v reflect.Value, path string) (reflect.Value, bool) {
+	for _, segment := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v = reflect.Zero(v.Type().Elem())
+			} else {
+				v = v.Elem()
+			}
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			found := false
+			for i := 0; i < v.NumField(); i++ {
+				if yamlFieldName(v.Type().Field(i)) == segment {
+					v = v.Field(i)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return reflect.Value{}, false
+			}
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return reflect.Value{}, false
+			}
+			elem := v.MapIndex(reflect.ValueOf(segment))
+			if !elem.IsValid() {
+				elem = reflect.Zero(v.Type().Elem())
+			}
+			v = elem
+		default:
+			return reflect.Value{}, false
+		}
+	}
+	return v, true
+}
+
+// isFieldSet reports whether a resolved field carries a meaningful value. A
+// PathRef only counts as set when its path is non-empty, and a struct counts
+// as set when any of its fields is set.
+func isFieldSet(v reflect.Value) bool {
+	if !v.IsValid() {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return !v.IsNil() && isFieldSet(v.Elem())
+	case reflect.Struct:
+		switch val := v.Interface().(type) {
+		case PathRef:
+			return strings.TrimSpace(val.Path) != ""
+		case time.Time:
+			return !val.IsZero()
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() && isFieldSet(v.Field(i)) {
+				return true
+			}
+		}
+		return false
+	case reflect.Slice, reflect.Map:
+		return v.Len() > 0
+	case reflect.String:
+		return strings.TrimSpace(v.String()) != ""
+	default:
+		return !v.IsZero()
+	}
+}
+
+// pathRefField is a PathRef found in a project together with its field path
+type pathRefField struct {
+	Field string // e.g. "governance.maintainer_lifecycle.onboarding_doc"
+	Ref   PathRef
+}
+
+// collectPathRefs returns every PathRef with a non-empty path in a project,
+// in field order
+func collectPathRefs(project Project) []pathRefField {
+	var refs []pathRefField
+	walkPathRefs(reflect.ValueOf(project), "", fieldSchema{}, func(path string, v reflect.Value, _ fieldSchema) {
+		if ref, ok := v.Interface().(PathRef); ok && strings.TrimSpace(ref.Path) != "" {
+			refs = append(refs, pathRefField{Field: path, Ref: ref})
+		}
+	})
+	return refs
+}
+
+// walkPathRefs calls visit, in field order, for every PathRef and every
+// string below v, with the fieldSchemas annotation of the field it was read
+// from. PathRefs are not walked into. schema annotates the field v was read
+// from and applies to the elements of slices and maps.
+func walkPathRefs(v reflect.Value, path string, schema fieldSchema, visit func(path string, v reflect.Value, schema fieldSchema)) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if _, ok := v.Interface().(PathRef); ok {
+		visit(path, v, schema)
+		return
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if name := yamlFieldName(v.Type().Field(i)); name != "" {
+				walkPathRefs(v.Field(i), joinDiffPath(path, name), fieldSchemas[v.Type().Name()+"."+name], visit)
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			walkPathRefs(v.Index(i), fmt.Sprintf("%s[%d]", path, i), schema, visit)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, k := range keys {
+			walkPathRefs(v.MapIndex(k), joinDiffPath(path, k.String()), schema, visit)
+		}
+	case reflect.String:
+		visit(path, v, schema)
+	}
+}
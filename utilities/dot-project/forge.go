@@ -0,0 +1,394 @@
+package projects
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Forge is a code hosting service. Bootstrap discovery, maintainer handle
+// verification and the advisory URL rule go through it, so projects hosted on
+// GitLab or Gitea (such as Codeberg) are handled like GitHub ones. GitLab and
+// Gitea data is converted to the GitHub-shaped types bootstrap already uses.
+type Forge interface {
+	Name() string  // "github", "gitlab" or "gitea"
+	Title() string // Display name, e.g. "GitHub"
+
+	// Repository returns a repository's metadata, or an error when it cannot
+	// be read
+	Repository(owner, repo string) (*GitHubRepoData, error)
+	// Owner returns the organization or group owning repositories; nil when
+	// unavailable
+	Owner(owner string) (*GitHubOrgData, error)
+	// CommunityFiles returns the repository's contributing guide, code of
+	// conduct, license and readme; nil when unavailable
+	CommunityFiles(owner, repo string) (*GitHubCommunityProfile, error)
+	// ListDir lists a directory ("" for the root) of the default branch; nil
+	// when it does not exist
+	ListDir(owner, repo, dir string) ([]GitHubContentEntry, error)
+	// ReadFile returns the content of a listed file
+	ReadFile(entry GitHubContentEntry) (string, error)
+	// GovernanceLocations are the directories searched for CODEOWNERS,
+	// OWNERS, MAINTAINERS and the other governance files, in priority order
+	GovernanceLocations(owner, repo string) []ForgeLocation
+	// ConfigDir is the repository directory holding forge configuration,
+	// where CLA bots are configured
+	ConfigDir() string
+	// CommitMessages returns the messages of the latest commits
+	CommitMessages(owner, repo string, n int) ([]string, error)
+
+	// UserByLogin and UserByID look up an account; found is false when there
+	// is none
+	UserByLogin(login string) (user ForgeUser, found bool, err error)
+	UserByID(id int64) (user ForgeUser, found bool, err error)
+	// IsMember reports whether a user is a (visible) member of an org
+	IsMember(org string, user ForgeUser) (bool, error)
+
+	RepoURL(owner, repo string) string
+	FileURL(owner, repo, branch, path string) string
+	// AdvisoryURL is where vulnerabilities are reported privately, and
+	// AdvisoryURLPattern matches such URLs; "" and nil when the forge has no
+	// private reporting
+	AdvisoryURL(owner, repo string) string
+	AdvisoryURLPattern() *regexp.Regexp
+}
+
+// ForgeLocation is a directory of a repository
+type ForgeLocation struct {
+	Owner, Repo, Dir string
+}
+
+// ForgeUser is an account on a forge
+type ForgeUser struct {
+	Login        string
+	ID           int64
+	Organization bool
+}
+
+// ForgeNames lists the forges NewForge knows
+var ForgeNames = []string{"github", "gitlab", "gitea"}
+
+// NewForge creates a forge by name. For GitHub, baseURL overrides the API
+// URL; for GitLab and Gitea, it is the instance's web URL (use "" for
+// gitlab.com and codeberg.org).
+func NewForge(name string, client *http.Client, baseURL, token string) (Forge, error) {
+	switch name {
+	case "", "github":
+		return NewGitHubForge(client, baseURL, token), nil
+	case "gitlab":
+		return NewGitLabForge(client, baseURL, token), nil
+	case "gitea":
+		return NewGiteaForge(client, baseURL, token), nil
+	default:
+		return nil, fmt.Errorf("unknown forge %q (use %s)", name, strings.Join(ForgeNames, ", "))
+	}
+}
+
+// githubAdvisoryURLPattern matches GitHub Security Advisory URLs of the form:
+// https://github.com/{org}/{repo}/security/advisories/new
+var githubAdvisoryURLPattern = regexp.MustCompile(`^https://github\.com/[^/]+/[^/]+/security/advisories/new$`)
+
+// gitlabAdvisoryURLPattern matches a new confidential issue of any project
+// of a GitLab instance, possibly with other parameters such as a template
+func gitlabAdvisoryURLPattern(baseURL string) *regexp.Regexp {
+	return regexp.MustCompile(`^` + regexp.QuoteMeta(baseURL) + `/[^?#]+/-/issues/new\?(?:[^#]*&)?issue\[confidential\]=true(?:&[^#]*)?$`)
+}
+
+// defaultAdvisoryPatterns match the advisory URLs project.yaml accepts
+// without configuration: GitHub and gitlab.com
+var defaultAdvisoryPatterns = []*regexp.Regexp{githubAdvisoryURLPattern, gitlabAdvisoryURLPattern(defaultGitLabURL)}
+
+// advisoryURLPattern matches any of the defaultAdvisoryPatterns, for the schema
+var advisoryURLPattern = func() *regexp.Regexp {
+	var alternatives []string
+	for _, p := range defaultAdvisoryPatterns {
+		alternatives = append(alternatives, "(?:"+p.String()+")")
+	}
+	return regexp.MustCompile(strings.Join(alternatives, "|"))
+}()
+
+// isAdvisoryURL reports whether a URL is a private vulnerability reporting
+// URL of GitHub, gitlab.com or one of the given forges (e.g., a
+// self-hosted GitLab)
+func isAdvisoryURL(u string, forges ...Forge) bool {
+	patterns := append([]*regexp.Regexp(nil), defaultAdvisoryPatterns...)
+	for _, f := range forges {
+		if p := f.AdvisoryURLPattern(); p != nil {
+			patterns = append(patterns, p)
+		}
+	}
+	for _, p := range patterns {
+		if p.MatchString(u) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetAdvisoryForges sets the forges whose advisory URLs the
+// advisory-url-pattern rule accepts besides GitHub and gitlab.com, such as
+// a self-hosted GitLab instance
+func (pv *ProjectValidator) SetAdvisoryForges(forges ...Forge) {
+	pv.advisoryForges = forges
+}
+
+// apiRequest sends an API request with the given headers and an optional
+// JSON body, and returns the response
+func apiRequest(client *http.Client, method, endpoint string, header http.Header, body interface{}) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, endpoint, reader)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return client.Do(req)
+}
+
+// apiJSON sends a request to a JSON API and decodes the response into out
+// (if not nil). Responses outside 2xx are *apiError. Forges, bootstrap
+// registries and the GitHub team sync all go through it.
+func apiJSON(client *http.Client, title, method, endpoint string, header http.Header, body, out interface{}) error {
+	resp, err := apiRequest(client, method, endpoint, header, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &apiError{Title: title, Method: method, Path: resp.Request.URL.Path, StatusCode: resp.StatusCode}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("parsing %s response: %w", title, err)
+	}
+	return nil
+}
+
+// apiError is an API response outside 2xx
+type apiError struct {
+	Title, Method, Path string
+	StatusCode          int
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s API returned HTTP %d for %s %s", e.Title, e.StatusCode, e.Method, e.Path)
+}
+
+// isAPINotFound reports whether err is an API 404
+func isAPINotFound(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// getJSON fetches a JSON document with apiJSON. found is false on 404
+// rather than an error.
+func getJSON(client *http.Client, title, endpoint string, header http.Header, out interface{}) (bool, error) {
+	err := apiJSON(client, title, http.MethodGet, endpoint, header, nil, out)
+	if isAPINotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// forgeMembership reads a membership check answered with a status: 204 (or
+// 200) when the user is a member, 404 when not
+func forgeMembership(client *http.Client, title, endpoint string, header http.Header) (bool, error) {
+	return getJSON(client, title, endpoint, header, nil)
+}
+
+// communityFilesFromListing builds a community profile from the files of a
+// repository's root, for forges without a community profile API
+func communityFilesFromListing(entries []GitHubContentEntry) *GitHubCommunityProfile {
+	profile := &GitHubCommunityProfile{}
+	for _, entry := range entries {
+		if entry.Type != "file" {
+			continue
+		}
+		base := strings.ToUpper(strings.TrimSuffix(entry.Name, ".md"))
+		file := &CommunityHealthFile{URL: entry.DownloadURL, HTMLURL: entry.HTMLURL}
+		switch {
+		case base == "CONTRIBUTING":
+			profile.Files.Contributing = file
+		case base == "CODE_OF_CONDUCT" || base == "CODE-OF-CONDUCT":
+			profile.Files.CodeOfConductFile = file
+		case base == "README":
+			profile.Files.Readme = file
+		case strings.HasPrefix(base, "LICENSE") || strings.HasPrefix(base, "COPYING"):
+			profile.Files.License = &struct {
+				Key     string `json:"key"`
+				Name    string `json:"name"`
+				SPDXID  string `json:"spdx_id"`
+				URL     string `json:"url"`
+				HTMLURL string `json:"html_url"`
+			}{URL: entry.DownloadURL, HTMLURL: entry.HTMLURL}
+		}
+	}
+	return profile
+}
+
+// GitHubForge is github.com, through the GitHub REST API
+type GitHubForge struct {
+	client  *http.Client
+	baseURL string
+	token   string
+}
+
+// NewGitHubForge creates the GitHub forge. baseURL overrides the GitHub API
+// URL (use "" for default).
+func NewGitHubForge(client *http.Client, baseURL, token string) *GitHubForge {
+	if baseURL == "" {
+		baseURL = defaultGitHubAPIURL
+	}
+	return &GitHubForge{client: client, baseURL: strings.TrimSuffix(baseURL, "/"), token: token}
+}
+
+// Name implements Forge
+func (f *GitHubForge) Name() string { return "github" }
+
+// Title implements Forge
+func (f *GitHubForge) Title() string { return "GitHub" }
+
+// header returns the headers of an authenticated API request
+func (f *GitHubForge) header() http.Header {
+	return githubHeader(f.token)
+}
+
+// Repository implements Forge
+func (f *GitHubForge) Repository(owner, repo string) (*GitHubRepoData, error) {
+	var data GitHubRepoData
+	if err := apiJSON(f.client, f.Title(), http.MethodGet, fmt.Sprintf("%s/repos/%s/%s", f.baseURL, owner, repo), f.header(), nil, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// Owner implements Forge
+func (f *GitHubForge) Owner(owner string) (*GitHubOrgData, error) {
+	var data GitHubOrgData
+	if found, err := getJSON(f.client, f.Title(), fmt.Sprintf("%s/orgs/%s", f.baseURL, owner), f.header(), &data); err != nil || !found {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// CommunityFiles implements Forge with the community profile API
+func (f *GitHubForge) CommunityFiles(owner, repo string) (*GitHubCommunityProfile, error) {
+	var data GitHubCommunityProfile
+	if found, err := getJSON(f.client, f.Title(), fmt.Sprintf("%s/repos/%s/%s/community/profile", f.baseURL, owner, repo), f.header(), &data); err != nil || !found {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ListDir implements Forge
+func (f *GitHubForge) ListDir(owner, repo, dir string) ([]GitHubContentEntry, error) {
+	var entries []GitHubContentEntry
+	if found, err := getJSON(f.client, f.Title(), fmt.Sprintf("%s/repos/%s/%s/contents/%s", f.baseURL, owner, repo, dir), f.header(), &entries); err != nil || !found {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ReadFile implements Forge
+func (f *GitHubForge) ReadFile(entry GitHubContentEntry) (string, error) {
+	return fetchFileContent(f.client, entry.DownloadURL)
+}
+
+// GovernanceLocations implements Forge: the repository root, its .github
+// directory and the org-level .github repository
+func (f *GitHubForge) GovernanceLocations(owner, repo string) []ForgeLocation {
+	return []ForgeLocation{{owner, repo, ""}, {owner, repo, ".github"}, {owner, ".github", ""}}
+}
+
+// ConfigDir implements Forge
+func (f *GitHubForge) ConfigDir() string { return ".github" }
+
+// CommitMessages implements Forge
+func (f *GitHubForge) CommitMessages(owner, repo string, n int) ([]string, error) {
+	var commits []struct {
+		Commit struct {
+			Message string `json:"message"`
+		} `json:"commit"`
+	}
+	if _, err := getJSON(f.client, f.Title(), fmt.Sprintf("%s/repos/%s/%s/commits?per_page=%d", f.baseURL, owner, repo, n), f.header(), &commits); err != nil {
+		return nil, err
+	}
+	var messages []string
+	for _, c := range commits {
+		messages = append(messages, c.Commit.Message)
+	}
+	return messages, nil
+}
+
+// gitHubUser is the part of a GitHub user the forge reads
+type gitHubUser struct {
+	Login string `json:"login"`
+	ID    int64  `json:"id"`
+	Type  string `json:"type"` // "User" or "Organization"
+}
+
+// user fetches a GitHub user; found is false on 404
+func (f *GitHubForge) user(path string) (ForgeUser, bool, error) {
+	var user gitHubUser
+	found, err := getJSON(f.client, f.Title(), f.baseURL+path, f.header(), &user)
+	if err != nil || !found {
+		return ForgeUser{}, false, err
+	}
+	return ForgeUser{Login: user.Login, ID: user.ID, Organization: user.Type == "Organization"}, true, nil
+}
+
+// UserByLogin implements Forge
+func (f *GitHubForge) UserByLogin(login string) (ForgeUser, bool, error) {
+	return f.user("/users/" + url.PathEscape(login))
+}
+
+// UserByID implements Forge
+func (f *GitHubForge) UserByID(id int64) (ForgeUser, bool, error) {
+	return f.user(fmt.Sprintf("/user/%d", id))
+}
+
+// IsMember implements Forge. Without a token of an org member, GitHub
+// redirects to the public membership check, which the client follows.
+func (f *GitHubForge) IsMember(org string, user ForgeUser) (bool, error) {
+	return forgeMembership(f.client, f.Title(), fmt.Sprintf("%s/orgs/%s/members/%s", f.baseURL, url.PathEscape(org), url.PathEscape(user.Login)), f.header())
+}
+
+// RepoURL implements Forge
+func (f *GitHubForge) RepoURL(owner, repo string) string {
+	return fmt.Sprintf("https://github.com/%s/%s", owner, repo)
+}
+
+// FileURL implements Forge
+func (f *GitHubForge) FileURL(owner, repo, branch, path string) string {
+	return fmt.Sprintf("https://github.com/%s/%s/blob/%s/%s", owner, repo, branch, path)
+}
+
+// AdvisoryURL implements Forge: the private vulnerability reporting form
+func (f *GitHubForge) AdvisoryURL(owner, repo string) string {
+	return fmt.Sprintf("https://github.com/%s/%s/security/advisories/new", owner, repo)
+}
+
+// AdvisoryURLPattern implements Forge
+func (f *GitHubForge) AdvisoryURLPattern() *regexp.Regexp { return githubAdvisoryURLPattern }
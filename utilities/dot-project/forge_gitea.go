@@ -0,0 +1,204 @@
+package projects
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// defaultGiteaURL is the Gitea instance used when none is given
+const defaultGiteaURL = "https://codeberg.org"
+
+// GiteaForge is a Gitea (or Forgejo) instance such as Codeberg, through the
+// Gitea REST API (v1)
+type GiteaForge struct {
+	client  *http.Client
+	baseURL string
+	token   string
+}
+
+// NewGiteaForge creates a Gitea forge. baseURL is the instance's web URL
+// (use "" for codeberg.org).
+func NewGiteaForge(client *http.Client, baseURL, token string) *GiteaForge {
+	if baseURL == "" {
+		baseURL = defaultGiteaURL
+	}
+	return &GiteaForge{client: client, baseURL: strings.TrimSuffix(baseURL, "/"), token: token}
+}
+
+// Name implements Forge
+func (f *GiteaForge) Name() string { return "gitea" }
+
+// Title implements Forge
+func (f *GiteaForge) Title() string { return "Gitea" }
+
+// api returns the URL of an API path
+func (f *GiteaForge) api(format string, args ...interface{}) string {
+	return f.baseURL + "/api/v1" + fmt.Sprintf(format, args...)
+}
+
+// header returns the headers of an authenticated API request
+func (f *GiteaForge) header() http.Header {
+	header := http.Header{"Accept": {"application/json"}}
+	if f.token != "" {
+		header.Set("Authorization", "token "+f.token)
+	}
+	return header
+}
+
+// Repository implements Forge
+func (f *GiteaForge) Repository(owner, repo string) (*GitHubRepoData, error) {
+	var data struct {
+		Name          string   `json:"name"`
+		FullName      string   `json:"full_name"`
+		Description   string   `json:"description"`
+		HTMLURL       string   `json:"html_url"`
+		Website       string   `json:"website"`
+		Language      string   `json:"language"`
+		DefaultBranch string   `json:"default_branch"`
+		StarsCount    int      `json:"stars_count"`
+		ForksCount    int      `json:"forks_count"`
+		Topics        []string `json:"topics"`
+	}
+	found, err := getJSON(f.client, f.Title(), f.api("/repos/%s/%s", url.PathEscape(owner), url.PathEscape(repo)), f.header(), &data)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("Gitea repo %s/%s not found", owner, repo)
+	}
+	return &GitHubRepoData{
+		Name:            data.Name,
+		FullName:        data.FullName,
+		Description:     data.Description,
+		HTMLURL:         data.HTMLURL,
+		Homepage:        data.Website,
+		Language:        data.Language,
+		DefaultBranch:   data.DefaultBranch,
+		StargazersCount: data.StarsCount,
+		ForksCount:      data.ForksCount,
+		Topics:          data.Topics,
+	}, nil
+}
+
+// Owner implements Forge with the owner's organization
+func (f *GiteaForge) Owner(owner string) (*GitHubOrgData, error) {
+	var org struct {
+		Username    string `json:"username"`
+		FullName    string `json:"full_name"`
+		Description string `json:"description"`
+		Website     string `json:"website"`
+	}
+	if found, err := getJSON(f.client, f.Title(), f.api("/orgs/%s", url.PathEscape(owner)), f.header(), &org); err != nil || !found {
+		return nil, err
+	}
+	return &GitHubOrgData{Login: org.Username, Name: org.FullName, Description: org.Description, Blog: org.Website, HTMLURL: f.baseURL + "/" + org.Username}, nil
+}
+
+// CommunityFiles implements Forge from the files of the repository root
+func (f *GiteaForge) CommunityFiles(owner, repo string) (*GitHubCommunityProfile, error) {
+	entries, err := f.ListDir(owner, repo, "")
+	if err != nil || entries == nil {
+		return nil, err
+	}
+	return communityFilesFromListing(entries), nil
+}
+
+// ListDir implements Forge with the contents API, whose entries have the
+// same shape as GitHub's
+func (f *GiteaForge) ListDir(owner, repo, dir string) ([]GitHubContentEntry, error) {
+	var entries []GitHubContentEntry
+	endpoint := f.api("/repos/%s/%s/contents", url.PathEscape(owner), url.PathEscape(repo))
+	if dir != "" {
+		endpoint += "/" + dir
+	}
+	if found, err := getJSON(f.client, f.Title(), endpoint, f.header(), &entries); err != nil || !found {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ReadFile implements Forge
+func (f *GiteaForge) ReadFile(entry GitHubContentEntry) (string, error) {
+	return fetchFileContent(f.client, entry.DownloadURL)
+}
+
+// GovernanceLocations implements Forge: the repository root and the .gitea
+// and docs directories, where Gitea reads CODEOWNERS
+func (f *GiteaForge) GovernanceLocations(owner, repo string) []ForgeLocation {
+	return []ForgeLocation{{owner, repo, ""}, {owner, repo, ".gitea"}, {owner, repo, "docs"}}
+}
+
+// ConfigDir implements Forge
+func (f *GiteaForge) ConfigDir() string { return ".gitea" }
+
+// CommitMessages implements Forge
+func (f *GiteaForge) CommitMessages(owner, repo string, n int) ([]string, error) {
+	var commits []struct {
+		Commit struct {
+			Message string `json:"message"`
+		} `json:"commit"`
+	}
+	if _, err := getJSON(f.client, f.Title(), f.api("/repos/%s/%s/commits?limit=%d&stat=false", url.PathEscape(owner), url.PathEscape(repo), n), f.header(), &commits); err != nil {
+		return nil, err
+	}
+	var messages []string
+	for _, c := range commits {
+		messages = append(messages, c.Commit.Message)
+	}
+	return messages, nil
+}
+
+// giteaUser is the part of a Gitea user the forge reads
+type giteaUser struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+}
+
+// UserByLogin implements Forge
+func (f *GiteaForge) UserByLogin(login string) (ForgeUser, bool, error) {
+	var user giteaUser
+	if found, err := getJSON(f.client, f.Title(), f.api("/users/%s", url.PathEscape(login)), f.header(), &user); err != nil || !found {
+		return ForgeUser{}, false, err
+	}
+	return ForgeUser{Login: user.Login, ID: user.ID}, true, nil
+}
+
+// UserByID implements Forge with the user search, which Gitea filters by ID
+func (f *GiteaForge) UserByID(id int64) (ForgeUser, bool, error) {
+	var search struct {
+		Data []giteaUser `json:"data"`
+	}
+	if _, err := getJSON(f.client, f.Title(), f.api("/users/search?uid=%d", id), f.header(), &search); err != nil || len(search.Data) == 0 {
+		return ForgeUser{}, false, err
+	}
+	return ForgeUser{Login: search.Data[0].Login, ID: search.Data[0].ID}, true, nil
+}
+
+// IsMember implements Forge. Without a token, only public members are seen.
+func (f *GiteaForge) IsMember(org string, user ForgeUser) (bool, error) {
+	members := "members"
+	if f.token == "" {
+		members = "public_members"
+	}
+	return forgeMembership(f.client, f.Title(), f.api("/orgs/%s/%s/%s", url.PathEscape(org), members, url.PathEscape(user.Login)), f.header())
+}
+
+// RepoURL implements Forge
+func (f *GiteaForge) RepoURL(owner, repo string) string {
+	return fmt.Sprintf("%s/%s/%s", f.baseURL, owner, repo)
+}
+
+// FileURL implements Forge
+func (f *GiteaForge) FileURL(owner, repo, branch, path string) string {
+	return fmt.Sprintf("%s/%s/%s/src/branch/%s/%s", f.baseURL, owner, repo, branch, path)
+}
+
+// AdvisoryURL implements Forge. Gitea has neither advisories nor
+// confidential issues, so projects give a security contact email instead.
+func (f *GiteaForge) AdvisoryURL(owner, repo string) string { return "" }
+
+// AdvisoryURLPattern implements Forge
+func (f *GiteaForge) AdvisoryURLPattern() *regexp.Regexp { return nil }
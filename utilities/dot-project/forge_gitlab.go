@@ -0,0 +1,244 @@
+package projects
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	defaultGitLabURL = "https://gitlab.com" // GitLab instance used when none is given
+	gitlabPageSize   = 100                  // Largest per_page the GitLab API allows
+)
+
+// GitLabForge is a GitLab instance, through the GitLab REST API (v4).
+// Owners are groups, and may be nested ("group/subgroup").
+type GitLabForge struct {
+	client  *http.Client
+	baseURL string
+	token   string
+}
+
+// NewGitLabForge creates a GitLab forge. baseURL is the instance's web URL
+// (use "" for gitlab.com).
+func NewGitLabForge(client *http.Client, baseURL, token string) *GitLabForge {
+	if baseURL == "" {
+		baseURL = defaultGitLabURL
+	}
+	return &GitLabForge{client: client, baseURL: strings.TrimSuffix(baseURL, "/"), token: token}
+}
+
+// Name implements Forge
+func (f *GitLabForge) Name() string { return "gitlab" }
+
+// Title implements Forge
+func (f *GitLabForge) Title() string { return "GitLab" }
+
+// api returns the URL of an API path
+func (f *GitLabForge) api(format string, args ...interface{}) string {
+	return f.baseURL + "/api/v4" + fmt.Sprintf(format, args...)
+}
+
+// header returns the headers of an authenticated API request
+func (f *GitLabForge) header() http.Header {
+	header := http.Header{"Accept": {"application/json"}}
+	if f.token != "" {
+		header.Set("PRIVATE-TOKEN", f.token)
+	}
+	return header
+}
+
+// projectID is the URL-encoded path GitLab accepts as a project ID
+func (f *GitLabForge) projectID(owner, repo string) string {
+	return url.PathEscape(owner + "/" + repo)
+}
+
+// Repository implements Forge
+func (f *GitLabForge) Repository(owner, repo string) (*GitHubRepoData, error) {
+	var project struct {
+		Path              string   `json:"path"`
+		PathWithNamespace string   `json:"path_with_namespace"`
+		Description       string   `json:"description"`
+		WebURL            string   `json:"web_url"`
+		DefaultBranch     string   `json:"default_branch"`
+		StarCount         int      `json:"star_count"`
+		ForksCount        int      `json:"forks_count"`
+		Topics            []string `json:"topics"`
+	}
+	found, err := getJSON(f.client, f.Title(), f.api("/projects/%s", f.projectID(owner, repo)), f.header(), &project)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("GitLab project %s/%s not found", owner, repo)
+	}
+	return &GitHubRepoData{
+		Name:            project.Path,
+		FullName:        project.PathWithNamespace,
+		Description:     project.Description,
+		HTMLURL:         project.WebURL,
+		DefaultBranch:   project.DefaultBranch,
+		StargazersCount: project.StarCount,
+		ForksCount:      project.ForksCount,
+		Topics:          project.Topics,
+	}, nil
+}
+
+// Owner implements Forge with the owner's group
+func (f *GitLabForge) Owner(owner string) (*GitHubOrgData, error) {
+	var group struct {
+		FullPath    string `json:"full_path"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		WebURL      string `json:"web_url"`
+	}
+	if found, err := getJSON(f.client, f.Title(), f.api("/groups/%s", url.PathEscape(owner)), f.header(), &group); err != nil || !found {
+		return nil, err
+	}
+	return &GitHubOrgData{Login: group.FullPath, Name: group.Name, Description: group.Description, HTMLURL: group.WebURL}, nil
+}
+
+// CommunityFiles implements Forge from the files of the repository root
+func (f *GitLabForge) CommunityFiles(owner, repo string) (*GitHubCommunityProfile, error) {
+	entries, err := f.ListDir(owner, repo, "")
+	if err != nil || entries == nil {
+		return nil, err
+	}
+	return communityFilesFromListing(entries), nil
+}
+
+// ListDir implements Forge with the repository tree of the default branch,
+// read a page at a time
+func (f *GitLabForge) ListDir(owner, repo, dir string) ([]GitHubContentEntry, error) {
+	type treeItem struct {
+		Name string `json:"name"`
+		Type string `json:"type"` // "blob" or "tree"
+		Path string `json:"path"`
+	}
+	var tree []treeItem
+	for page := 1; ; page++ {
+		endpoint := f.api("/projects/%s/repository/tree?per_page=%d&page=%d", f.projectID(owner, repo), gitlabPageSize, page)
+		if dir != "" {
+			endpoint += "&path=" + url.QueryEscape(dir)
+		}
+		var items []treeItem
+		if found, err := getJSON(f.client, f.Title(), endpoint, f.header(), &items); err != nil || !found {
+			return nil, err
+		}
+		tree = append(tree, items...)
+		if len(items) < gitlabPageSize {
+			break
+		}
+	}
+	// An empty tree is how GitLab answers for a missing directory
+	if len(tree) == 0 {
+		return nil, nil
+	}
+	entries := make([]GitHubContentEntry, 0, len(tree))
+	for _, item := range tree {
+		entry := GitHubContentEntry{Name: item.Name, Path: item.Path, Type: "dir"}
+		if item.Type == "blob" {
+			entry.Type = "file"
+			entry.DownloadURL = f.api("/projects/%s/repository/files/%s/raw?ref=HEAD", f.projectID(owner, repo), url.PathEscape(item.Path))
+			entry.HTMLURL = f.FileURL(owner, repo, "HEAD", item.Path)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// ReadFile implements Forge
+func (f *GitLabForge) ReadFile(entry GitHubContentEntry) (string, error) {
+	resp, err := apiRequest(f.client, http.MethodGet, entry.DownloadURL, f.header(), nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP %d fetching %s", resp.StatusCode, entry.DownloadURL)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// GovernanceLocations implements Forge: the repository root and the
+// .gitlab and docs directories, where GitLab reads CODEOWNERS
+func (f *GitLabForge) GovernanceLocations(owner, repo string) []ForgeLocation {
+	return []ForgeLocation{{owner, repo, ""}, {owner, repo, ".gitlab"}, {owner, repo, "docs"}}
+}
+
+// ConfigDir implements Forge
+func (f *GitLabForge) ConfigDir() string { return ".gitlab" }
+
+// CommitMessages implements Forge
+func (f *GitLabForge) CommitMessages(owner, repo string, n int) ([]string, error) {
+	var commits []struct {
+		Message string `json:"message"`
+	}
+	if _, err := getJSON(f.client, f.Title(), f.api("/projects/%s/repository/commits?per_page=%d", f.projectID(owner, repo), n), f.header(), &commits); err != nil {
+		return nil, err
+	}
+	var messages []string
+	for _, c := range commits {
+		messages = append(messages, c.Message)
+	}
+	return messages, nil
+}
+
+// gitLabUser is the part of a GitLab user the forge reads
+type gitLabUser struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+// UserByLogin implements Forge. Group names are not users on GitLab, so a
+// group's name is not found.
+func (f *GitLabForge) UserByLogin(login string) (ForgeUser, bool, error) {
+	var users []gitLabUser
+	if _, err := getJSON(f.client, f.Title(), f.api("/users?username=%s", url.QueryEscape(login)), f.header(), &users); err != nil || len(users) == 0 {
+		return ForgeUser{}, false, err
+	}
+	return ForgeUser{Login: users[0].Username, ID: users[0].ID}, true, nil
+}
+
+// UserByID implements Forge
+func (f *GitLabForge) UserByID(id int64) (ForgeUser, bool, error) {
+	var user gitLabUser
+	if found, err := getJSON(f.client, f.Title(), f.api("/users/%d", id), f.header(), &user); err != nil || !found {
+		return ForgeUser{}, false, err
+	}
+	return ForgeUser{Login: user.Username, ID: user.ID}, true, nil
+}
+
+// IsMember implements Forge, counting members inherited from parent groups
+func (f *GitLabForge) IsMember(org string, user ForgeUser) (bool, error) {
+	return forgeMembership(f.client, f.Title(), f.api("/groups/%s/members/all/%d", url.PathEscape(org), user.ID), f.header())
+}
+
+// RepoURL implements Forge
+func (f *GitLabForge) RepoURL(owner, repo string) string {
+	return fmt.Sprintf("%s/%s/%s", f.baseURL, owner, repo)
+}
+
+// FileURL implements Forge
+func (f *GitLabForge) FileURL(owner, repo, branch, path string) string {
+	return fmt.Sprintf("%s/%s/%s/-/blob/%s/%s", f.baseURL, owner, repo, branch, path)
+}
+
+// AdvisoryURL implements Forge: GitLab has no advisory form, so reports go
+// to a new confidential issue
+func (f *GitLabForge) AdvisoryURL(owner, repo string) string {
+	return fmt.Sprintf("%s/%s/%s/-/issues/new?issue[confidential]=true", f.baseURL, owner, repo)
+}
+
+// AdvisoryURLPattern implements Forge: a new issue of any project of the
+// instance, confidential, possibly with other parameters such as a template
+func (f *GitLabForge) AdvisoryURLPattern() *regexp.Regexp {
+	return gitlabAdvisoryURLPattern(f.baseURL)
+}
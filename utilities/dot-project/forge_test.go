@@ -0,0 +1,364 @@
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// respondJSON writes a JSON response, or 404 for a nil body
+func respondJSON(w http.ResponseWriter, body interface{}) {
+	if body == nil {
+		http.NotFound(w, nil)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+// newFakeGitLab serves the GitLab API for test-group/test-project, with
+// CODEOWNERS and a CLA config in .gitlab/, and the users alice (ID 1, a
+// member of test-group) and bob (ID 2)
+func newFakeGitLab(t *testing.T) *httptest.Server {
+	t.Helper()
+	const project = "/api/v4/projects/test-group%2Ftest-project"
+	users := map[string]gitLabUser{"alice": {ID: 1, Username: "alice"}, "bob": {ID: 2, Username: "bob"}}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "gl-token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch path := r.URL.EscapedPath(); {
+		case path == project:
+			respondJSON(w, map[string]interface{}{
+				"path": "test-project", "path_with_namespace": "test-group/test-project",
+				"description": "A project on GitLab", "web_url": "https://gitlab.example.com/test-group/test-project",
+				"default_branch": "main", "star_count": 5,
+			})
+		case path == "/api/v4/groups/test-group":
+			respondJSON(w, map[string]string{"full_path": "test-group", "name": "Test Group"})
+		case path == project+"/repository/tree":
+			switch r.URL.Query().Get("path") {
+			case "":
+				respondJSON(w, []map[string]string{
+					{"name": "README.md", "type": "blob", "path": "README.md"},
+					{"name": "CONTRIBUTING.md", "type": "blob", "path": "CONTRIBUTING.md"},
+					{"name": "LICENSE", "type": "blob", "path": "LICENSE"},
+					{"name": ".gitlab", "type": "tree", "path": ".gitlab"},
+				})
+			case "docs":
+				// Two pages: a full one and the rest
+				var items []map[string]string
+				for i := 0; i < gitlabPageSize; i++ {
+					items = append(items, map[string]string{"name": fmt.Sprintf("page-%d.md", i), "type": "blob", "path": fmt.Sprintf("docs/page-%d.md", i)})
+				}
+				if r.URL.Query().Get("page") == "2" {
+					items = items[:1]
+				}
+				respondJSON(w, items)
+			case ".gitlab":
+				respondJSON(w, []map[string]string{
+					{"name": "CODEOWNERS", "type": "blob", "path": ".gitlab/CODEOWNERS"},
+					{"name": "cla.yml", "type": "blob", "path": ".gitlab/cla.yml"},
+				})
+			default:
+				respondJSON(w, []interface{}{})
+			}
+		case path == project+"/repository/files/.gitlab%2FCODEOWNERS/raw":
+			w.Write([]byte("* @alice @bob\n"))
+		case path == project+"/repository/commits":
+			respondJSON(w, []map[string]string{
+				{"message": "Fix a bug\n\nSigned-off-by: Alice <alice@example.com>"},
+				{"message": "Add a feature\n\nSigned-off-by: Bob <bob@example.com>"},
+			})
+		case path == "/api/v4/users":
+			if user, ok := users[r.URL.Query().Get("username")]; ok {
+				respondJSON(w, []gitLabUser{user})
+				return
+			}
+			respondJSON(w, []gitLabUser{})
+		case path == "/api/v4/users/1":
+			respondJSON(w, users["alice"])
+		case path == "/api/v4/groups/test-group/members/all/1":
+			respondJSON(w, map[string]interface{}{"id": 1, "username": "alice"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+// newFakeGitea serves the Gitea API for test-org/test-project, with OWNERS in
+// the root, and the users alice (ID 1, a public member of test-org) and bob
+// (ID 2)
+func newFakeGitea(t *testing.T) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	users := map[string]giteaUser{"alice": {ID: 1, Login: "alice"}, "bob": {ID: 2, Login: "bob"}}
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/repos/test-org/test-project":
+			respondJSON(w, map[string]interface{}{
+				"name": "test-project", "full_name": "test-org/test-project", "description": "A project on Codeberg",
+				"html_url": server.URL + "/test-org/test-project", "website": "https://test-project.example.org",
+				"default_branch": "main",
+			})
+		case "/api/v1/orgs/test-org":
+			respondJSON(w, map[string]string{"username": "test-org", "full_name": "Test Org"})
+		case "/api/v1/repos/test-org/test-project/contents":
+			respondJSON(w, []GitHubContentEntry{
+				{Name: "OWNERS", Path: "OWNERS", Type: "file", DownloadURL: server.URL + "/raw/OWNERS", HTMLURL: server.URL + "/test-org/test-project/src/branch/main/OWNERS"},
+				{Name: "CODE_OF_CONDUCT.md", Path: "CODE_OF_CONDUCT.md", Type: "file", DownloadURL: server.URL + "/raw/CODE_OF_CONDUCT.md", HTMLURL: server.URL + "/test-org/test-project/src/branch/main/CODE_OF_CONDUCT.md"},
+			})
+		case "/raw/OWNERS":
+			w.Write([]byte("approvers:\n  - alice\nreviewers:\n  - bob\n"))
+		case "/api/v1/repos/test-org/test-project/commits":
+			respondJSON(w, []map[string]interface{}{{"commit": map[string]string{"message": "Unsigned change"}}})
+		case "/api/v1/users/search":
+			var found []giteaUser
+			for _, user := range users {
+				if r.URL.Query().Get("uid") == "1" && user.ID == 1 {
+					found = append(found, user)
+				}
+			}
+			respondJSON(w, map[string]interface{}{"data": found, "ok": true})
+		case "/api/v1/orgs/test-org/public_members/alice":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			if login, ok := strings.CutPrefix(r.URL.Path, "/api/v1/users/"); ok {
+				if user, ok := users[login]; ok {
+					respondJSON(w, user)
+					return
+				}
+			}
+			http.NotFound(w, r)
+		}
+	}))
+	return server
+}
+
+func TestNewForge(t *testing.T) {
+	for _, name := range append([]string{""}, ForgeNames...) {
+		forge, err := NewForge(name, http.DefaultClient, "", "")
+		if err != nil {
+			t.Fatalf("NewForge(%q): %v", name, err)
+		}
+		if want := name; want != "" && forge.Name() != want {
+			t.Errorf("NewForge(%q) created %s", name, forge.Name())
+		}
+	}
+	if _, err := NewForge("bitbucket", http.DefaultClient, "", ""); err == nil {
+		t.Error("expected an error for an unknown forge")
+	}
+}
+
+func TestGitLabForgeBootstrap(t *testing.T) {
+	server := newFakeGitLab(t)
+	defer server.Close()
+	forge := NewGitLabForge(server.Client(), server.URL+"/", "gl-token")
+
+	data, err := fetchFromForge(forge, "test-group", "test-project")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data.Forge != "gitlab" || data.Repo.FullName != "test-group/test-project" || data.Org == nil || data.Org.Login != "test-group" {
+		t.Errorf("unexpected repository data: %+v", data)
+	}
+	if len(data.Maintainers) != 2 || data.Maintainers[0] != "alice" {
+		t.Errorf("expected maintainers from .gitlab/CODEOWNERS, got %v", data.Maintainers)
+	}
+	if !data.HasDCO || !data.HasCLA {
+		t.Errorf("expected DCO from sign-offs and CLA from .gitlab/cla.yml, got dco=%v cla=%v", data.HasDCO, data.HasCLA)
+	}
+	if want := server.URL + "/test-group/test-project/-/blob/HEAD/CONTRIBUTING.md"; data.ContributingURL != want {
+		t.Errorf("contributing URL = %q, want %q", data.ContributingURL, want)
+	}
+	if data.Community == nil || data.Community.Files.Readme == nil || data.Community.Files.License == nil {
+		t.Errorf("expected readme and license from the root listing, got %+v", data.Community)
+	}
+
+	t.Run("bootstrap", func(t *testing.T) {
+		result, errs := BootstrapProject(BootstrapConfig{ProjectName: "Test Project", GitHubOrg: "test-group", GitHubRepo: "test-project", GitHubToken: "gl-token"},
+			server.Client(), BootstrapOptions{SkipLandscape: true, SkipCLOMonitor: true, Forge: "gitlab", ForgeURL: server.URL}, t.Logf)
+		if len(errs) > 0 {
+			t.Fatalf("unexpected errors: %v", errs)
+		}
+		if result.Forge != "gitlab" || result.Sources["contributing"] != "gitlab" || result.Sources["identity_type"] != "gitlab" {
+			t.Errorf("expected GitLab to be recorded as the source, got forge %q sources %v", result.Forge, result.Sources)
+		}
+		out, err := GenerateProjectYAML(result)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		yamlStr := string(out)
+		for _, want := range []string{
+			server.URL + "/test-group/test-project/-/blob/main/SECURITY.md",
+			`advisory_url: "` + server.URL + "/test-group/test-project/-/issues/new?issue[confidential]=true",
+		} {
+			if !strings.Contains(yamlStr, want) {
+				t.Errorf("project.yaml should contain %q:\n%s", want, yamlStr)
+			}
+		}
+		if strings.Contains(yamlStr, "https://github.com/test-group") {
+			t.Errorf("project.yaml should not link to GitHub:\n%s", yamlStr)
+		}
+
+		// The instance's advisory URL is only accepted once the forge is configured
+		advisoryErrors := func(pv *ProjectValidator) int {
+			var validation ValidationResult
+			pv.checkProjectContent(&validation, yamlStr, ProjectListEntry{}, ruleContext{})
+			n := 0
+			for _, d := range validation.Diagnostics {
+				if d.Rule == "advisory-url-pattern" {
+					n++
+				}
+			}
+			return n
+		}
+		pv := NewValidator("")
+		if n := advisoryErrors(pv); n != 1 {
+			t.Errorf("expected the self-hosted advisory URL to be rejected by default, got %d errors", n)
+		}
+		pv.SetAdvisoryForges(NewGitLabForge(nil, server.URL, ""))
+		if n := advisoryErrors(pv); n != 0 {
+			t.Errorf("expected the configured forge's advisory URL to be accepted, got %d errors", n)
+		}
+	})
+
+	t.Run("paged directory", func(t *testing.T) {
+		entries, err := forge.ListDir("test-group", "test-project", "docs")
+		if err != nil || len(entries) != gitlabPageSize+1 {
+			t.Fatalf("expected %d entries over two pages, got %d, %v", gitlabPageSize+1, len(entries), err)
+		}
+	})
+
+	t.Run("missing project", func(t *testing.T) {
+		if _, err := fetchFromForge(forge, "test-group", "missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("expected a not found error, got %v", err)
+		}
+	})
+}
+
+func TestGiteaForgeBootstrap(t *testing.T) {
+	server := newFakeGitea(t)
+	defer server.Close()
+
+	result, errs := BootstrapProject(BootstrapConfig{ProjectName: "Test Project", GitHubOrg: "test-org", GitHubRepo: "test-project"},
+		server.Client(), BootstrapOptions{SkipLandscape: true, SkipCLOMonitor: true, Forge: "gitea", ForgeURL: server.URL}, t.Logf)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if result.Website != "https://test-project.example.org" || result.Sources["website"] != "gitea" {
+		t.Errorf("expected the website from Gitea, got %q from %q", result.Website, result.Sources["website"])
+	}
+	if len(result.Maintainers) != 1 || result.Maintainers[0] != "alice" || len(result.Reviewers) != 1 {
+		t.Errorf("expected maintainers and reviewers from OWNERS, got %v and %v", result.Maintainers, result.Reviewers)
+	}
+	if result.HasDCO || result.HasCLA {
+		t.Errorf("expected no DCO or CLA, got dco=%v cla=%v", result.HasDCO, result.HasCLA)
+	}
+	if want := server.URL + "/test-org/test-project/src/branch/main/CODE_OF_CONDUCT.md"; result.CodeOfConductURL != want {
+		t.Errorf("code of conduct URL = %q, want %q", result.CodeOfConductURL, want)
+	}
+
+	out, err := GenerateProjectYAML(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	yamlStr := string(out)
+	if strings.Contains(yamlStr, "advisory_url") || !strings.Contains(yamlStr, "TODO: Set a security contact email") {
+		t.Errorf("Gitea has no private reporting, expected an email TODO:\n%s", yamlStr)
+	}
+	if !strings.Contains(yamlStr, server.URL+"/test-org/test-project/src/branch/main/SECURITY.md") {
+		t.Errorf("expected Gitea file URLs:\n%s", yamlStr)
+	}
+}
+
+func TestForgeVerifier(t *testing.T) {
+	gitlab := newFakeGitLab(t)
+	defer gitlab.Close()
+	gitea := newFakeGitea(t)
+	defer gitea.Close()
+
+	tests := []struct {
+		name     string
+		forge    Forge
+		req      HandleRequest
+		verified bool
+		message  string
+	}{
+		{"gitlab member", NewGitLabForge(gitlab.Client(), gitlab.URL, "gl-token"), HandleRequest{Handle: "alice", Org: "test-group"}, true, ""},
+		{"gitlab non-member", NewGitLabForge(gitlab.Client(), gitlab.URL, "gl-token"), HandleRequest{Handle: "bob", Org: "test-group"}, true, "not a public member of GitLab org test-group"},
+		{"gitlab typo", NewGitLabForge(gitlab.Client(), gitlab.URL, "gl-token"), HandleRequest{Handle: "alcie"}, false, "no GitLab user alcie"},
+		{"gitlab renamed", NewGitLabForge(gitlab.Client(), gitlab.URL, "gl-token"), HandleRequest{Handle: "alicia", Previous: &HandleCheck{UserID: 1}}, false, "GitLab user alicia was renamed to alice"},
+		{"gitea member", NewGiteaForge(gitea.Client(), gitea.URL, ""), HandleRequest{Handle: "alice", Org: "test-org"}, true, ""},
+		{"gitea typo", NewGiteaForge(gitea.Client(), gitea.URL, ""), HandleRequest{Handle: "alcie"}, false, "no Gitea user alcie"},
+		{"gitea renamed", NewGiteaForge(gitea.Client(), gitea.URL, ""), HandleRequest{Handle: "alicia", Previous: &HandleCheck{UserID: 1}}, false, "Gitea user alicia was renamed to alice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := NewForgeVerifier(tt.forge)
+			check, err := verifier.Verify(tt.req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if check.Verified != tt.verified || !strings.Contains(check.Message, tt.message) {
+				t.Errorf("got %+v, want verified=%v message %q", check, tt.verified, tt.message)
+			}
+			if check.Source != tt.forge.Name() {
+				t.Errorf("source = %q, want %q", check.Source, tt.forge.Name())
+			}
+		})
+	}
+
+	t.Run("gitlab API errors", func(t *testing.T) {
+		_, err := NewForgeVerifier(NewGitLabForge(gitlab.Client(), gitlab.URL, "wrong")).Verify(HandleRequest{Handle: "alice"})
+		if err == nil || !strings.Contains(err.Error(), "GitLab API returned HTTP 401") {
+			t.Errorf("expected an HTTP 401 error, got %v", err)
+		}
+	})
+}
+
+func TestAdvisoryURLs(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://github.com/org/repo/security/advisories/new", true},
+		{"https://github.com/org/repo/security/advisories", false},
+		{"https://gitlab.com/group/project/-/issues/new?issue[confidential]=true", true},
+		{"https://gitlab.com/group/subgroup/project/-/issues/new?issuable_template=security&issue[confidential]=true", true},
+		{"https://gitlab.com/group/project/-/issues/new", false},
+		{"https://gitlab.example.com/group/project/-/issues/new?issue[confidential]=true", false},
+		{"https://codeberg.org/org/repo/issues/new", false},
+	}
+	for _, tt := range tests {
+		if got := isAdvisoryURL(tt.url); got != tt.want {
+			t.Errorf("isAdvisoryURL(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+		if got := advisoryURLPattern.MatchString(tt.url); got != tt.want {
+			t.Errorf("advisoryURLPattern matches %q = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+
+	for _, forge := range []Forge{NewGitHubForge(nil, "", ""), NewGitLabForge(nil, "", "")} {
+		u := forge.AdvisoryURL("org", "repo")
+		if !isAdvisoryURL(u) {
+			t.Errorf("%s advisory URL %q is not accepted", forge.Name(), u)
+		}
+	}
+
+	// A self-hosted instance is accepted once configured
+	selfHosted := NewGitLabForge(nil, "https://gitlab.example.com/", "")
+	if u := selfHosted.AdvisoryURL("group", "project"); !isAdvisoryURL(u, selfHosted, NewGiteaForge(nil, "", "")) {
+		t.Errorf("configured GitLab advisory URL %q is not accepted", u)
+	}
+	if !isAdvisoryURL("https://github.com/org/repo/security/advisories/new", selfHosted) {
+		t.Error("configured forges should not replace the defaults")
+	}
+	if u := NewGiteaForge(nil, "", "").AdvisoryURL("org", "repo"); u != "" {
+		t.Errorf("expected no Gitea advisory URL, got %q", u)
+	}
+}
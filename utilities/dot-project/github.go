@@ -0,0 +1,35 @@
+package projects
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// parseGitHubRepoURL parses a github.com repository URL into owner and repo
+func parseGitHubRepoURL(repository string) (owner, repo string, ok bool) {
+	u, err := url.Parse(normalizeRepositoryURL(repository))
+	if err != nil || (u.Host != "github.com" && u.Host != "www.github.com") {
+		return "", "", false
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// githubHeader returns the headers of a GitHub API request
+func githubHeader(token string) http.Header {
+	header := http.Header{"Accept": {"application/vnd.github.v3+json"}}
+	if token != "" {
+		header.Set("Authorization", "token "+token)
+	}
+	return header
+}
+
+// githubJSON sends a GitHub API request with an optional JSON body and
+// decodes the JSON response into out (if not nil)
+func githubJSON(client *http.Client, method, endpoint, token string, body, out interface{}) error {
+	return apiJSON(client, "GitHub", method, endpoint, githubHeader(token), body, out)
+}
@@ -0,0 +1,66 @@
+package projects
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseGitHubRepoURL(t *testing.T) {
+	tests := []struct {
+		url         string
+		owner, repo string
+		ok          bool
+	}{
+		{"https://github.com/org/repo", "org", "repo", true},
+		{"https://github.com/Org/Repo.git/", "org", "repo", true},
+		{"https://www.github.com/org/repo/tree/main", "org", "repo", true},
+		{"https://github.com/org", "", "", false},
+		{"https://gitlab.com/org/repo", "", "", false},
+	}
+	for _, tt := range tests {
+		owner, repo, ok := parseGitHubRepoURL(tt.url)
+		if owner != tt.owner || repo != tt.repo || ok != tt.ok {
+			t.Errorf("parseGitHubRepoURL(%q) = %q, %q, %v; want %q, %q, %v", tt.url, owner, repo, ok, tt.owner, tt.repo, tt.ok)
+		}
+	}
+}
+
+func TestGitHubJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/echo":
+			var body map[string]string
+			if r.Header.Get("Content-Type") != "application/json" || json.NewDecoder(r.Body).Decode(&body) != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"method": r.Method, "title": body["title"]})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	var out map[string]string
+	if err := githubJSON(srv.Client(), http.MethodPost, srv.URL+"/echo", "secret", map[string]string{"title": "hello"}, &out); err != nil {
+		t.Fatalf("githubJSON: %v", err)
+	}
+	if out["method"] != http.MethodPost || out["title"] != "hello" {
+		t.Errorf("unexpected response: %v", out)
+	}
+
+	err := githubJSON(srv.Client(), http.MethodGet, srv.URL+"/missing", "secret", nil, nil)
+	if !isAPINotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	err = githubJSON(srv.Client(), http.MethodGet, srv.URL+"/echo", "", nil, nil)
+	if err == nil || isAPINotFound(err) {
+		t.Errorf("expected a non-404 API error, got %v", err)
+	}
+}
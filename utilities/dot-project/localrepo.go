@@ -0,0 +1,169 @@
+package projects
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// pathRefExistsRuleID is the rule ID of findings about PathRefs that do not
+// resolve to a file in a local checkout
+const pathRefExistsRuleID = "pathref-exists"
+
+// LocalCheckout locates the working trees that relative PathRefs are
+// resolved against when validating a checked-out .project repository
+type LocalCheckout struct {
+	RepoRoot    string // .project repository working tree holding project.yaml
+	PrimaryRepo string // Optional local clone of the project's primary repository (repositories[0])
+}
+
+// pathRefExistsRule checks that PathRefs point at files that exist in the
+// local checkout of the validation call. It reports nothing without one.
+type pathRefExistsRule struct{}
+
+func (r pathRefExistsRule) ID() string { return pathRefExistsRuleID }
+func (r pathRefExistsRule) Description() string {
+	return "Path references resolve to files in the local checkout (-repo-root only)"
+}
+
+func (r pathRefExistsRule) Check(project Project) []Diagnostic {
+	return r.checkContext(project, ruleContext{})
+}
+
+func (r pathRefExistsRule) checkContext(project Project, ctx ruleContext) []Diagnostic {
+	if ctx.checkout == nil {
+		return nil
+	}
+	var diags diagnosticSet
+	checkPathRefsExist(project, *ctx.checkout, &diags)
+	return diags
+}
+
+// ValidateLocal validates project.yaml in a checked-out .project repository
+// without touching the network or the cache. On top of the usual rules, every
+// PathRef must resolve to a file: relative paths against the repository root
+// or the primary repository clone, and blob/tree URLs of the primary
+// repository against the clone. Other URLs are not checked.
+func (pv *ProjectValidator) ValidateLocal(checkout LocalCheckout) (ValidationResult, error) {
+	path := filepath.Join(checkout.RepoRoot, "project.yaml")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ValidationResult{}, fmt.Errorf("failed to read project file: %w", err)
+	}
+
+	return pv.validateLocalContent(checkout, path, string(data)), nil
+}
+
+// validateLocalContent validates project.yaml content, e.g. an editor buffer,
+// resolving its PathRefs against the checkout
+func (pv *ProjectValidator) validateLocalContent(checkout LocalCheckout, path, content string) ValidationResult {
+	result := ValidationResult{
+		URL:         path,
+		LastChecked: time.Now(),
+		CurrentHash: calculateHash(content),
+	}
+	pv.checkProjectContent(&result, content, ProjectListEntry{}, ruleContext{checkout: &checkout})
+	return result
+}
+
+// checkPathRefsExist reports PathRefs that do not resolve in the checkout
+func checkPathRefsExist(project Project, checkout LocalCheckout, diags *diagnosticSet) {
+	for _, field := range collectPathRefs(project) {
+		ref := field.Ref.Path
+		candidates, where, err := checkout.resolvePathRef(project, ref)
+		if err != nil {
+			diags.errorf(pathRefExistsRuleID, field.Field+".path", "%s.path %q %v", field.Field, ref, err)
+			continue
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+		found := false
+		for _, candidate := range candidates {
+			if _, err := os.Stat(candidate); err == nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			diags.errorf(pathRefExistsRuleID, field.Field+".path", "%s.path %q does not exist in %s", field.Field, ref, where)
+		}
+	}
+}
+
+// resolvePathRef returns the local files a PathRef may refer to and a
+// description of where they were looked for. It returns no candidates for
+// URLs outside the primary repository, which cannot be checked offline, and
+// an error for paths that leave the repository they are resolved against.
+func (c LocalCheckout) resolvePathRef(project Project, ref string) ([]string, string, error) {
+	// Anchors and queries do not change which file is referenced
+	if i := strings.IndexAny(ref, "#?"); i >= 0 {
+		ref = ref[:i]
+	}
+
+	if strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://") {
+		rel, ok := c.primaryRepoPath(project, ref)
+		if !ok {
+			return nil, "", nil
+		}
+		candidate, ok := joinWithin(c.PrimaryRepo, rel)
+		if !ok {
+			return nil, "", fmt.Errorf("points outside the primary repository clone %s", c.PrimaryRepo)
+		}
+		return []string{candidate}, "the primary repository clone " + c.PrimaryRepo, nil
+	}
+
+	// A leading slash is relative to the repository root, as in GitHub links
+	rel := filepath.FromSlash(strings.TrimPrefix(ref, "/"))
+	candidate, ok := joinWithin(c.RepoRoot, rel)
+	if !ok {
+		return nil, "", fmt.Errorf("points outside %s", c.RepoRoot)
+	}
+	candidates := []string{candidate}
+	where := c.RepoRoot
+	if c.PrimaryRepo != "" {
+		// Both roots hold the same relative path, so it stays inside either
+		candidate, _ := joinWithin(c.PrimaryRepo, rel)
+		candidates = append(candidates, candidate)
+		where += " or " + c.PrimaryRepo
+	}
+	return candidates, where, nil
+}
+
+// joinWithin joins a relative path to root, reporting false when the result
+// is outside root (e.g., for "../other/file")
+func joinWithin(root, rel string) (string, bool) {
+	joined := filepath.Join(root, rel)
+	r, err := filepath.Rel(root, joined)
+	if err != nil || r == ".." || strings.HasPrefix(r, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return joined, true
+}
+
+// primaryRepoPath maps a blob or tree URL in the project's primary repository
+// (e.g., https://github.com/org/repo/blob/main/SECURITY.md) to a path inside
+// the clone. The first segment after blob/ or tree/ is taken as the branch.
+func (c LocalCheckout) primaryRepoPath(project Project, ref string) (string, bool) {
+	if c.PrimaryRepo == "" || len(project.Repositories) == 0 {
+		return "", false
+	}
+	prefix := normalizeRepositoryURL(project.Repositories[0]) + "/"
+	if !strings.HasPrefix(strings.ToLower(ref), prefix) {
+		return "", false
+	}
+	rest := ref[len(prefix):]
+	for _, kind := range []string{"blob/", "tree/"} {
+		if !strings.HasPrefix(rest, kind) {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimPrefix(rest, kind), "/", 2)
+		if len(parts) < 2 || parts[1] == "" {
+			return "", false
+		}
+		return filepath.FromSlash(strings.TrimSuffix(parts[1], "/")), true
+	}
+	return "", false
+}
@@ -0,0 +1,186 @@
+package projects
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCollectPathRefs(t *testing.T) {
+	p := validBaseProject()
+	p.Adopters = &PathRef{Path: "ADOPTERS.md"}
+	p.Security = &SecurityConfig{Policy: &PathRef{Path: "SECURITY.md"}, ThreatModel: &PathRef{}}
+	p.Governance = &GovernanceConfig{
+		MaintainerLifecycle: MaintainerLifecycle{OnboardingDoc: &PathRef{Path: "docs/onboarding.md"}},
+	}
+
+	var got []string
+	for _, ref := range collectPathRefs(p) {
+		got = append(got, ref.Field+"="+ref.Ref.Path)
+	}
+	want := "adopters=ADOPTERS.md,security.policy=SECURITY.md,governance.maintainer_lifecycle.onboarding_doc=docs/onboarding.md"
+	if strings.Join(got, ",") != want {
+		t.Errorf("collectPathRefs = %v, want %s", got, want)
+	}
+}
+
+func TestValidateLocal(t *testing.T) {
+	root := t.TempDir()
+	clone := t.TempDir()
+	writeFile(t, filepath.Join(root, "SECURITY.md"), "# Security\n")
+	writeFile(t, filepath.Join(clone, "CONTRIBUTING.md"), "# Contributing\n")
+	if err := os.MkdirAll(filepath.Join(clone, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(clone, "docs", "governance.md"), "# Governance\n")
+
+	writeFile(t, filepath.Join(root, "project.yaml"), validProjectYAML()+`security:
+  policy:
+    path: SECURITY.md
+  threat_model:
+    path: docs/threat-model.md
+governance:
+  contributing:
+    path: CONTRIBUTING.md
+  governance_doc:
+    path: https://github.com/test/repo/blob/main/docs/governance.md#decisions
+  codeowners:
+    path: https://github.com/test/repo/blob/main/.github/CODEOWNERS
+  code_of_conduct:
+    path: https://github.com/cncf/foundation/blob/main/code-of-conduct.md
+`)
+
+	t.Run("with primary repository clone", func(t *testing.T) {
+		pv := newTestValidator(t)
+		result, err := pv.ValidateLocal(LocalCheckout{RepoRoot: root, PrimaryRepo: clone})
+		if err != nil {
+			t.Fatalf("ValidateLocal: %v", err)
+		}
+		if result.Valid {
+			t.Fatal("expected missing files to make the project invalid")
+		}
+
+		var paths []string
+		for _, d := range result.Diagnostics {
+			if d.Rule == pathRefExistsRuleID {
+				paths = append(paths, d.Path)
+				if d.Line == 0 {
+					t.Errorf("expected a source position for %s", d.Path)
+				}
+			}
+		}
+		if strings.Join(paths, ",") != "security.threat_model.path,governance.codeowners.path" {
+			t.Errorf("unexpected missing files: %v", paths)
+		}
+	})
+
+	t.Run("without primary repository clone", func(t *testing.T) {
+		pv := newTestValidator(t)
+		result, err := pv.ValidateLocal(LocalCheckout{RepoRoot: root})
+		if err != nil {
+			t.Fatalf("ValidateLocal: %v", err)
+		}
+		var paths []string
+		for _, d := range result.Diagnostics {
+			if d.Rule == pathRefExistsRuleID {
+				paths = append(paths, d.Path)
+			}
+		}
+		// URLs cannot be resolved offline, and CONTRIBUTING.md is only in the clone
+		if strings.Join(paths, ",") != "security.threat_model.path,governance.contributing.path" {
+			t.Errorf("unexpected missing files: %v", paths)
+		}
+	})
+
+	t.Run("missing project.yaml", func(t *testing.T) {
+		pv := newTestValidator(t)
+		if _, err := pv.ValidateLocal(LocalCheckout{RepoRoot: clone}); err == nil {
+			t.Error("expected error when project.yaml is missing")
+		}
+	})
+}
+
+func TestValidateLocalPathOutsideCheckout(t *testing.T) {
+	parent := t.TempDir()
+	root := filepath.Join(parent, "project")
+	clone := filepath.Join(parent, "clone")
+	for _, dir := range []string{root, clone} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The files exist, but outside the repositories readers can follow
+	writeFile(t, filepath.Join(parent, "ADOPTERS.md"), "# Adopters\n")
+	writeFile(t, filepath.Join(parent, "SECURITY.md"), "# Security\n")
+	writeFile(t, filepath.Join(root, "project.yaml"), validProjectYAML()+`adopters:
+  path: ../ADOPTERS.md
+security:
+  policy:
+    path: https://github.com/test/repo/blob/main/../../SECURITY.md
+`)
+
+	pv := newTestValidator(t)
+	result, err := pv.ValidateLocal(LocalCheckout{RepoRoot: root, PrimaryRepo: clone})
+	if err != nil {
+		t.Fatalf("ValidateLocal: %v", err)
+	}
+	var messages []string
+	for _, d := range result.Diagnostics {
+		if d.Rule == pathRefExistsRuleID {
+			messages = append(messages, d.Message)
+		}
+	}
+	if len(messages) != 2 || !strings.Contains(messages[0], "points outside "+root) || !strings.Contains(messages[1], "points outside the primary repository clone") {
+		t.Errorf("expected both paths to be rejected, got %v", messages)
+	}
+}
+
+func TestPathRefExistsRuleOnlyRunsLocally(t *testing.T) {
+	dir := t.TempDir()
+	projPath := filepath.Join(dir, "project.yaml")
+	writeFile(t, projPath, validProjectYAML()+"adopters:\n  path: ADOPTERS.md\n")
+
+	pv := newTestValidator(t)
+	if _, err := pv.ValidateLocal(LocalCheckout{RepoRoot: dir}); err != nil {
+		t.Fatalf("ValidateLocal: %v", err)
+	}
+	result, err := pv.validateProject(projPath)
+	if err != nil {
+		t.Fatalf("validateProject: %v", err)
+	}
+	if findDiagnostic(result.Diagnostics, pathRefExistsRuleID) != nil {
+		t.Errorf("project list validation should not resolve PathRefs, got %v", result.Diagnostics)
+	}
+}
+
+func TestValidateLocalConcurrentCheckouts(t *testing.T) {
+	content := validProjectYAML() + "adopters:\n  path: ADOPTERS.md\n"
+	withFile, withoutFile := t.TempDir(), t.TempDir()
+	writeFile(t, filepath.Join(withFile, "ADOPTERS.md"), "# Adopters\n")
+
+	// One validator shared by concurrent calls, as in the language server
+	pv := newTestValidator(t)
+	var wg sync.WaitGroup
+	errs := make(chan string, 100)
+	for i := 0; i < 50; i++ {
+		for _, root := range []string{withFile, withoutFile} {
+			wg.Add(1)
+			go func(root string) {
+				defer wg.Done()
+				result := pv.validateLocalContent(LocalCheckout{RepoRoot: root}, filepath.Join(root, "project.yaml"), content)
+				missing := findDiagnostic(result.Diagnostics, pathRefExistsRuleID) != nil
+				if missing != (root == withoutFile) {
+					errs <- fmt.Sprintf("%s: missing=%v", root, missing)
+				}
+			}(root)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("PathRefs resolved against the wrong checkout: %s", err)
+	}
+}
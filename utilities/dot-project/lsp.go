@@ -0,0 +1,327 @@
+package projects
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// JSON-RPC error codes used by the language server
+const (
+	lspMethodNotFound = -32601
+	lspInvalidParams  = -32602
+)
+
+// LSP enumerations (see the Language Server Protocol specification)
+const (
+	lspSyncFull = 1
+
+	lspSeverityError       = 1
+	lspSeverityWarning     = 2
+	lspSeverityInformation = 3
+
+	lspCompletionValue      = 12
+	lspCompletionProperty   = 10
+	lspCompletionEnumMember = 20
+)
+
+// lspMessage is an incoming JSON-RPC request or notification
+type lspMessage struct {
+	ID     *json.RawMessage `json:"id,omitempty"`
+	Method string           `json:"method"`
+	Params json.RawMessage  `json:"params,omitempty"`
+}
+
+type lspResponse struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Result  interface{}      `json:"result"`
+}
+
+type lspErrorResponse struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Error   lspError         `json:"error"`
+}
+
+type lspError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type lspNotification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"` // UTF-16 code units
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Code     string   `json:"code,omitempty"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+type lspTextDocument struct {
+	URI  string `json:"uri"`
+	Text string `json:"text,omitempty"`
+}
+
+type lspDocumentParams struct {
+	TextDocument   lspTextDocument `json:"textDocument"`
+	Text           *string         `json:"text,omitempty"` // didSave with includeText
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges,omitempty"`
+	Position lspPosition `json:"position"`
+}
+
+type lspCompletionItem struct {
+	Label         string `json:"label"`
+	Kind          int    `json:"kind"`
+	Detail        string `json:"detail,omitempty"`
+	Documentation string `json:"documentation,omitempty"`
+	InsertText    string `json:"insertText,omitempty"`
+}
+
+type lspHover struct {
+	Contents struct {
+		Kind  string `json:"kind"`
+		Value string `json:"value"`
+	} `json:"contents"`
+}
+
+// LanguageServer is a Language Server Protocol server for project.yaml. It
+// publishes the validator's diagnostics when a document is opened or saved,
+// completes enum values, keys and landscape categories, and shows field
+// documentation on hover.
+type LanguageServer struct {
+	validator *ProjectValidator
+	schema    *JSONSchema
+	docs      fieldDocs
+	landscape LandscapeCategories
+	documents map[string]string // Open documents by URI
+
+	out io.Writer
+}
+
+// NewLanguageServer creates a language server. landscape may be nil, in which
+// case landscape categories are not completed.
+func NewLanguageServer(landscape LandscapeCategories) (*LanguageServer, error) {
+	schema, err := ProjectJSONSchema()
+	if err != nil {
+		return nil, err
+	}
+	docs, err := loadFieldDocs()
+	if err != nil {
+		return nil, err
+	}
+	return &LanguageServer{
+		validator: NewValidator(""),
+		schema:    schema,
+		docs:      docs,
+		landscape: landscape,
+		documents: make(map[string]string),
+	}, nil
+}
+
+// Serve reads requests from in and writes responses to out until the client
+// sends exit or closes the stream
+func (s *LanguageServer) Serve(in io.Reader, out io.Writer) error {
+	s.out = out
+	reader := bufio.NewReader(in)
+	for {
+		msg, err := readLSPMessage(reader)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if msg.Method == "exit" {
+			return nil
+		}
+		if err := s.handle(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// handle dispatches a message. Requests always get a response; unknown
+// notifications are ignored.
+func (s *LanguageServer) handle(msg lspMessage) error {
+	var params lspDocumentParams
+	if len(msg.Params) > 0 && msg.Method != "initialize" {
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			if msg.ID != nil {
+				return s.writeError(msg.ID, lspInvalidParams, err.Error())
+			}
+			return nil
+		}
+	}
+	uri := params.TextDocument.URI
+
+	switch msg.Method {
+	case "initialize":
+		return s.reply(msg.ID, map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync": map[string]interface{}{
+					"openClose": true,
+					"change":    lspSyncFull,
+					"save":      map[string]bool{"includeText": true},
+				},
+				"completionProvider": map[string]interface{}{"triggerCharacters": []string{":", " ", "-"}},
+				"hoverProvider":      true,
+			},
+			"serverInfo": map[string]string{"name": "dot-project"},
+		})
+	case "shutdown":
+		return s.reply(msg.ID, nil)
+	case "textDocument/didOpen":
+		s.documents[uri] = params.TextDocument.Text
+		return s.publishDiagnostics(uri)
+	case "textDocument/didChange":
+		if n := len(params.ContentChanges); n > 0 {
+			s.documents[uri] = params.ContentChanges[n-1].Text
+		}
+		return nil
+	case "textDocument/didSave":
+		if params.Text != nil {
+			s.documents[uri] = *params.Text
+		}
+		return s.publishDiagnostics(uri)
+	case "textDocument/didClose":
+		delete(s.documents, uri)
+		return s.notify("textDocument/publishDiagnostics", map[string]interface{}{"uri": uri, "diagnostics": []lspDiagnostic{}})
+	case "textDocument/completion":
+		return s.reply(msg.ID, s.complete(s.documents[uri], params.Position))
+	case "textDocument/hover":
+		text, ok := s.hover(s.documents[uri], params.Position)
+		if !ok {
+			return s.reply(msg.ID, nil)
+		}
+		var hover lspHover
+		hover.Contents.Kind = "markdown"
+		hover.Contents.Value = text
+		return s.reply(msg.ID, hover)
+	}
+
+	if msg.ID != nil {
+		return s.writeError(msg.ID, lspMethodNotFound, fmt.Sprintf("method %q is not supported", msg.Method))
+	}
+	return nil
+}
+
+// publishDiagnostics validates a document and sends its diagnostics. PathRefs
+// are resolved against the document's directory.
+func (s *LanguageServer) publishDiagnostics(uri string) error {
+	content := s.documents[uri]
+	path := uri
+	if u, err := url.Parse(uri); err == nil && u.Scheme == "file" {
+		path = u.Path
+	}
+	result := s.validator.validateLocalContent(LocalCheckout{RepoRoot: filepath.Dir(path)}, path, content)
+
+	lines := strings.Split(content, "\n")
+	diagnostics := []lspDiagnostic{}
+	for _, d := range result.Diagnostics {
+		diagnostics = append(diagnostics, lspDiagnostic{
+			Range:    diagnosticRange(lines, d),
+			Severity: lspSeverity(d.Severity),
+			Code:     d.Rule,
+			Source:   "dot-project",
+			Message:  d.Message,
+		})
+	}
+	return s.notify("textDocument/publishDiagnostics", map[string]interface{}{"uri": uri, "diagnostics": diagnostics})
+}
+
+// diagnosticRange spans from a diagnostic's position to the end of its line
+func diagnosticRange(lines []string, d Diagnostic) lspRange {
+	if d.Line <= 0 || d.Line > len(lines) {
+		return lspRange{}
+	}
+	line := strings.TrimRight(lines[d.Line-1], "\r")
+	start := 0
+	if d.Column > 0 {
+		start = utf16Len(string([]rune(line)[:min(d.Column-1, len([]rune(line)))]))
+	}
+	return lspRange{
+		Start: lspPosition{Line: d.Line - 1, Character: start},
+		End:   lspPosition{Line: d.Line - 1, Character: utf16Len(line)},
+	}
+}
+
+func lspSeverity(s Severity) int {
+	switch s {
+	case SeverityError:
+		return lspSeverityError
+	case SeverityWarning:
+		return lspSeverityWarning
+	default:
+		return lspSeverityInformation
+	}
+}
+
+func (s *LanguageServer) reply(id *json.RawMessage, result interface{}) error {
+	return writeLSPMessage(s.out, lspResponse{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+func (s *LanguageServer) writeError(id *json.RawMessage, code int, message string) error {
+	return writeLSPMessage(s.out, lspErrorResponse{JSONRPC: "2.0", ID: id, Error: lspError{Code: code, Message: message}})
+}
+
+func (s *LanguageServer) notify(method string, params interface{}) error {
+	return writeLSPMessage(s.out, lspNotification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+// readLSPMessage reads one Content-Length framed JSON-RPC message
+func readLSPMessage(r *bufio.Reader) (lspMessage, error) {
+	header, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		if err == io.EOF || (err == io.ErrUnexpectedEOF && len(header) == 0) {
+			return lspMessage{}, io.EOF
+		}
+		return lspMessage{}, fmt.Errorf("reading message header: %w", err)
+	}
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil || length < 0 {
+		return lspMessage{}, fmt.Errorf("invalid Content-Length %q", header.Get("Content-Length"))
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return lspMessage{}, fmt.Errorf("reading message body: %w", err)
+	}
+	var msg lspMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return lspMessage{}, fmt.Errorf("parsing message: %w", err)
+	}
+	return msg, nil
+}
+
+// writeLSPMessage writes one Content-Length framed JSON-RPC message
+func writeLSPMessage(w io.Writer, msg interface{}) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	return err
+}
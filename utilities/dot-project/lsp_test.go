@@ -0,0 +1,276 @@
+package projects
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// lspSession runs a language server over a scripted sequence of messages and
+// returns every message it wrote
+func lspSession(t *testing.T, landscape LandscapeCategories, messages ...map[string]interface{}) []map[string]interface{} {
+	t.Helper()
+	var in bytes.Buffer
+	for _, msg := range messages {
+		msg["jsonrpc"] = "2.0"
+		if err := writeLSPMessage(&in, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	server, err := NewLanguageServer(landscape)
+	if err != nil {
+		t.Fatalf("NewLanguageServer: %v", err)
+	}
+	var out bytes.Buffer
+	if err := server.Serve(&in, &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	var written []map[string]interface{}
+	reader := bufio.NewReader(&out)
+	for {
+		header, err := reader.ReadString('\n')
+		if err == io.EOF {
+			return written
+		}
+		if !strings.HasPrefix(header, "Content-Length: ") {
+			t.Fatalf("unexpected header %q", header)
+		}
+		reader.ReadString('\n')
+		var length int
+		json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(header, "Content-Length: "))), &length)
+		body := make([]byte, length)
+		io.ReadFull(reader, body)
+		var msg map[string]interface{}
+		if err := json.Unmarshal(body, &msg); err != nil {
+			t.Fatalf("invalid message %s: %v", body, err)
+		}
+		written = append(written, msg)
+	}
+}
+
+// lspResult returns the result of the response to the request with the given id
+func lspResult(t *testing.T, messages []map[string]interface{}, id float64) interface{} {
+	t.Helper()
+	for _, msg := range messages {
+		if msg["id"] == id {
+			return msg["result"]
+		}
+	}
+	t.Fatalf("no response to request %v in %v", id, messages)
+	return nil
+}
+
+func lspPositionParams(uri string, line, character int) map[string]interface{} {
+	return map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": uri},
+		"position":     map[string]interface{}{"line": line, "character": character},
+	}
+}
+
+func completionLabels(result interface{}) []string {
+	var labels []string
+	items, _ := result.([]interface{})
+	for _, item := range items {
+		labels = append(labels, item.(map[string]interface{})["label"].(string))
+	}
+	return labels
+}
+
+func TestLanguageServerDiagnostics(t *testing.T) {
+	dir := t.TempDir()
+	uri := "file://" + filepath.Join(dir, "project.yaml")
+	invalid := strings.Replace(validProjectYAML(), "slug: test-project", "slug: Test_Project", 1) + "security:\n  policy:\n    path: SECURITY.md\n"
+	writeFile(t, filepath.Join(dir, "SECURITY.md"), "# Security\n")
+
+	messages := lspSession(t, nil,
+		map[string]interface{}{"id": 1, "method": "initialize", "params": map[string]interface{}{}},
+		map[string]interface{}{"method": "initialized", "params": map[string]interface{}{}},
+		map[string]interface{}{"method": "textDocument/didOpen", "params": map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": uri, "languageId": "yaml", "version": 1, "text": invalid},
+		}},
+		map[string]interface{}{"method": "textDocument/didChange", "params": map[string]interface{}{
+			"textDocument":   map[string]interface{}{"uri": uri},
+			"contentChanges": []interface{}{map[string]interface{}{"text": validProjectYAML() + "security:\n  policy:\n    path: MISSING.md\n"}},
+		}},
+		map[string]interface{}{"method": "textDocument/didSave", "params": map[string]interface{}{"textDocument": map[string]interface{}{"uri": uri}}},
+		map[string]interface{}{"id": 2, "method": "workspace/symbol", "params": map[string]interface{}{}},
+		map[string]interface{}{"id": 3, "method": "shutdown"},
+		map[string]interface{}{"method": "exit"},
+	)
+
+	caps := lspResult(t, messages, 1).(map[string]interface{})["capabilities"].(map[string]interface{})
+	if caps["hoverProvider"] != true || caps["completionProvider"] == nil {
+		t.Errorf("unexpected capabilities: %v", caps)
+	}
+
+	var published [][]interface{}
+	for _, msg := range messages {
+		if msg["method"] == "textDocument/publishDiagnostics" {
+			published = append(published, msg["params"].(map[string]interface{})["diagnostics"].([]interface{}))
+		}
+	}
+	if len(published) != 2 {
+		t.Fatalf("expected diagnostics on open and on save, got %d", len(published))
+	}
+
+	// On open: invalid slug on line 2 (0-based 1); SECURITY.md exists
+	opened := published[0]
+	if len(opened) != 1 {
+		t.Fatalf("expected one diagnostic on open, got %v", opened)
+	}
+	d := opened[0].(map[string]interface{})
+	start := d["range"].(map[string]interface{})["start"].(map[string]interface{})
+	if d["code"] != "slug-format" || d["severity"] != float64(lspSeverityError) || start["line"] != float64(1) || start["character"] != float64(6) {
+		t.Errorf("unexpected diagnostic: %v", d)
+	}
+
+	// On save: the changed content is validated and the missing file reported
+	saved := published[1]
+	if len(saved) != 1 || saved[0].(map[string]interface{})["code"] != pathRefExistsRuleID {
+		t.Errorf("expected a pathref-exists diagnostic on save, got %v", saved)
+	}
+
+	for _, msg := range messages {
+		if msg["id"] == float64(2) {
+			if msg["error"].(map[string]interface{})["code"] != float64(lspMethodNotFound) {
+				t.Errorf("expected method not found for unsupported request, got %v", msg)
+			}
+		}
+	}
+	if _, ok := messages[len(messages)-1]["result"]; !ok {
+		t.Errorf("shutdown should get a null result, got %v", messages[len(messages)-1])
+	}
+}
+
+func TestLanguageServerCompletion(t *testing.T) {
+	uri := "file:///tmp/project.yaml"
+	doc := strings.Join([]string{
+		"slug: test-project",
+		"maturity_log:",
+		"  - phase: ",
+		"landscape:",
+		"  category: Orchestration & Management",
+		"  subcategory: ",
+		"legal:",
+		"  identity_type:",
+		"    has_dco: ",
+		"    ",
+		"validation:",
+		"  ignore:",
+		"    - rule: ",
+	}, "\n")
+	landscape := LandscapeCategories{
+		"Orchestration & Management": {"Scheduling & Orchestration", "Service Mesh"},
+		"Observability and Analysis": {"Monitoring"},
+	}
+	lines := strings.Split(doc, "\n")
+	lineOf := func(prefix string) int {
+		for i, l := range lines {
+			if l == prefix {
+				return i
+			}
+		}
+		t.Fatalf("line %q not found", prefix)
+		return -1
+	}
+
+	messages := lspSession(t, landscape,
+		map[string]interface{}{"method": "textDocument/didOpen", "params": map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": uri, "text": doc},
+		}},
+		map[string]interface{}{"id": 1, "method": "textDocument/completion", "params": lspPositionParams(uri, lineOf("  - phase: "), 11)},
+		map[string]interface{}{"id": 2, "method": "textDocument/completion", "params": lspPositionParams(uri, lineOf("  subcategory: "), 15)},
+		map[string]interface{}{"id": 3, "method": "textDocument/completion", "params": lspPositionParams(uri, lineOf("    has_dco: "), 13)},
+		map[string]interface{}{"id": 4, "method": "textDocument/completion", "params": lspPositionParams(uri, lineOf("    "), 4)},
+		map[string]interface{}{"id": 5, "method": "textDocument/completion", "params": lspPositionParams(uri, lineOf("    - rule: "), 12)},
+		map[string]interface{}{"id": 6, "method": "textDocument/completion", "params": lspPositionParams(uri, lineOf("  category: Orchestration & Management"), 12)},
+	)
+
+	tests := []struct {
+		id   float64
+		want string
+	}{
+		{1, "sandbox,incubating,graduated,archived"},
+		{2, "Scheduling & Orchestration,Service Mesh"},
+		{3, "true,false"},
+		{4, "cla_only,cla_url,dco_url,has_cla,has_dco"},
+		{6, "Observability and Analysis,Orchestration & Management"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(completionLabels(lspResult(t, messages, tt.id)), ","); got != tt.want {
+			t.Errorf("completion %v = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+	rules := completionLabels(lspResult(t, messages, 5))
+	if len(rules) == 0 || !strings.Contains(strings.Join(rules, ","), "maturity-log-issue") {
+		t.Errorf("expected rule IDs for validation.ignore, got %v", rules)
+	}
+}
+
+func TestLanguageServerHover(t *testing.T) {
+	uri := "file:///tmp/project.yaml"
+	doc := validProjectYAML() + "legal:\n  identity_type:\n    has_cla: true\n"
+	lines := strings.Split(doc, "\n")
+
+	messages := lspSession(t, nil,
+		map[string]interface{}{"method": "textDocument/didOpen", "params": map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": uri, "text": doc},
+		}},
+		map[string]interface{}{"id": 1, "method": "textDocument/hover", "params": lspPositionParams(uri, len(lines)-2, 6)},
+		map[string]interface{}{"id": 2, "method": "textDocument/hover", "params": lspPositionParams(uri, 5, 6)},
+		map[string]interface{}{"id": 3, "method": "textDocument/hover", "params": lspPositionParams(uri, 1, 2)},
+		map[string]interface{}{"id": 4, "method": "textDocument/hover", "params": lspPositionParams(uri, 0, 0)},
+	)
+
+	hoverText := func(id float64) string {
+		result, _ := lspResult(t, messages, id).(map[string]interface{})
+		if result == nil {
+			return ""
+		}
+		return result["contents"].(map[string]interface{})["value"].(string)
+	}
+
+	// Line comment from types.go
+	if got := hoverText(1); !strings.Contains(got, "**legal.identity_type.has_cla** `boolean`") || !strings.Contains(got, "Whether the project uses CLA (requires DCO unless cla_only is true)") {
+		t.Errorf("unexpected has_cla hover:\n%s", got)
+	}
+	// Schema description and enum for a field without a line comment
+	if got := hoverText(2); !strings.Contains(got, "**maturity_log[].phase**") || !strings.Contains(got, "`sandbox`, `incubating`") {
+		t.Errorf("unexpected phase hover:\n%s", got)
+	}
+	if got := hoverText(3); !strings.Contains(got, "Unique project identifier (lowercase, alphanumeric + hyphens)") || !strings.Contains(got, "Pattern:") {
+		t.Errorf("unexpected slug hover:\n%s", got)
+	}
+	if got := hoverText(4); !strings.Contains(got, "Schema version") {
+		t.Errorf("unexpected schema_version hover:\n%s", got)
+	}
+}
+
+func TestCursorAt(t *testing.T) {
+	lines := strings.Split("maturity_log:\n  - phase: sandbox\n    date: 2024-01-15\n    \ngovernance:\n  maintainer_lifecycle:\n    onboarding_doc:\n      pa", "\n")
+	tests := []struct {
+		line, char int
+		want       string
+	}{
+		{1, 11, "maturity_log.[] phase value"},
+		{2, 9, "maturity_log.[] date value"},
+		{3, 4, "maturity_log.[]  key"},
+		{7, 8, "governance.maintainer_lifecycle.onboarding_doc  key"},
+	}
+	for _, tt := range tests {
+		c := cursorAt(lines, tt.line, tt.char)
+		mode := "key"
+		if c.inValue {
+			mode = "value"
+		}
+		if got := strings.Join(c.path, ".") + " " + c.key + " " + mode; got != tt.want {
+			t.Errorf("cursorAt(%d, %d) = %q, want %q", tt.line, tt.char, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,453 @@
+package projects
+
+import (
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// typesSource is types.go itself, so hover docs come from its field comments
+//
+//go:embed types.go
+var typesSource string
+
+// fieldDocs holds Go doc comments from types.go: field line comments keyed
+// "<Type>.<yaml key>" and type doc comments keyed by type name
+type fieldDocs struct {
+	fields map[string]string
+	types  map[string]string
+}
+
+// loadFieldDocs parses the embedded types.go. Only line comments are used
+// for fields, since the comments above fields are section headings.
+func loadFieldDocs() (fieldDocs, error) {
+	docs := fieldDocs{fields: make(map[string]string), types: make(map[string]string)}
+	file, err := parser.ParseFile(token.NewFileSet(), "types.go", typesSource, parser.ParseComments)
+	if err != nil {
+		return docs, fmt.Errorf("failed to parse types.go: %w", err)
+	}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			doc := ts.Doc
+			if doc == nil && len(gen.Specs) == 1 {
+				doc = gen.Doc
+			}
+			if doc != nil {
+				docs.types[ts.Name.Name] = strings.Join(strings.Fields(doc.Text()), " ")
+			}
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			for _, field := range st.Fields.List {
+				if field.Tag == nil || field.Comment == nil {
+					continue
+				}
+				tag, err := strconv.Unquote(field.Tag.Value)
+				if err != nil {
+					continue
+				}
+				name := strings.Split(reflect.StructTag(tag).Get("yaml"), ",")[0]
+				if name == "" || name == "-" {
+					continue
+				}
+				docs.fields[ts.Name.Name+"."+name] = strings.TrimSpace(field.Comment.Text())
+			}
+		}
+	}
+	return docs, nil
+}
+
+// yamlLevel is a sequence item or mapping key opened on a line
+type yamlLevel struct {
+	indent int
+	key    string // "" for a sequence item
+}
+
+// parseYAMLLine splits a block-style YAML line into the sequence items and
+// mapping key it opens, e.g. "  - phase: sandbox" gives [{2 ""} {4 "phase"}]
+// and value "sandbox". hasKey is false when the line has no "key:".
+func parseYAMLLine(line string) (levels []yamlLevel, value string, hasKey bool) {
+	i := len(line) - len(strings.TrimLeft(line, " "))
+	for strings.HasPrefix(line[i:], "- ") || line[i:] == "-" {
+		levels = append(levels, yamlLevel{indent: i})
+		i++
+		for i < len(line) && line[i] == ' ' {
+			i++
+		}
+	}
+	rest := line[i:]
+	if strings.HasPrefix(rest, "#") {
+		return levels, "", false
+	}
+	for j := 0; j < len(rest); j++ {
+		if rest[j] == ':' && (j+1 == len(rest) || rest[j+1] == ' ') {
+			key := strings.Trim(strings.TrimSpace(rest[:j]), `"'`)
+			levels = append(levels, yamlLevel{indent: i, key: key})
+			return levels, strings.TrimSpace(rest[j+1:]), true
+		}
+	}
+	return levels, rest, false
+}
+
+// yamlCursor describes the position of the cursor in a block-style YAML
+// document. It is worked out from indentation so it also works while the
+// document is being edited and does not parse.
+type yamlCursor struct {
+	path    []string // Enclosing keys from the root; "[]" for a sequence item
+	key     string   // Key on the cursor line when inValue, "" for a scalar sequence item
+	inValue bool     // Cursor is after "key:" or on a "- " item
+	text    string   // Key or value typed so far
+}
+
+// cursorAt works out the cursor context for a position in a document
+func cursorAt(lines []string, line, character int) yamlCursor {
+	if line < 0 || line >= len(lines) {
+		return yamlCursor{}
+	}
+	current := strings.TrimRight(lines[line], "\r")
+	before := current[:utf16ToByte(current, character)]
+
+	levels, value, hasKey := parseYAMLLine(before)
+	cursor := yamlCursor{text: value}
+	target := len(before) - len(strings.TrimLeft(before, " "))
+	if len(levels) > 0 {
+		target = levels[0].indent
+	}
+	if hasKey {
+		cursor.key = levels[len(levels)-1].key
+		cursor.inValue = true
+		levels = levels[:len(levels)-1]
+	} else if len(levels) > 0 {
+		cursor.inValue = true // "- " item: a scalar value or the first key of a mapping
+	}
+
+	cursor.path = append(enclosingLevels(lines, line, target), levelPath(levels)...)
+	return cursor
+}
+
+// enclosingLevels walks up from a line and returns the path of the mappings
+// and sequences enclosing content at the given indent
+func enclosingLevels(lines []string, line, target int) []string {
+	var parents []yamlLevel
+	for i := line - 1; i >= 0 && target > 0; i-- {
+		text := strings.TrimRight(lines[i], "\r")
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(strings.TrimSpace(text), "#") {
+			continue
+		}
+		levels, _, _ := parseYAMLLine(text)
+		for j := len(levels) - 1; j >= 0; j-- {
+			if levels[j].indent < target {
+				parents = append(parents, levels[j])
+				target = levels[j].indent
+			}
+		}
+	}
+	// parents were collected innermost first
+	for i, j := 0, len(parents)-1; i < j; i, j = i+1, j-1 {
+		parents[i], parents[j] = parents[j], parents[i]
+	}
+	return levelPath(parents)
+}
+
+func levelPath(levels []yamlLevel) []string {
+	var path []string
+	for _, l := range levels {
+		if l.key == "" {
+			path = append(path, "[]")
+		} else {
+			path = append(path, l.key)
+		}
+	}
+	return path
+}
+
+// siblingValue finds the value of another key in the same mapping as a line,
+// e.g. the category next to a landscape subcategory
+func siblingValue(lines []string, line int, key string) string {
+	levels, _, _ := parseYAMLLine(strings.TrimRight(lines[line], "\r"))
+	if len(levels) == 0 {
+		return ""
+	}
+	indent := levels[len(levels)-1].indent
+	for _, step := range []int{-1, 1} {
+		for i := line + step; i >= 0 && i < len(lines); i += step {
+			text := strings.TrimRight(lines[i], "\r")
+			if strings.TrimSpace(text) == "" {
+				continue
+			}
+			other, value, hasKey := parseYAMLLine(text)
+			if len(other) == 0 || other[len(other)-1].indent < indent {
+				break
+			}
+			if hasKey && other[len(other)-1].indent == indent && other[len(other)-1].key == key {
+				return strings.Trim(value, `"'`)
+			}
+		}
+	}
+	return ""
+}
+
+// schemaField is a schema node found by walking a path, with the Go
+// "<Type>.<yaml key>" of the field it describes
+type schemaField struct {
+	schema   *JSONSchema
+	goField  string
+	property *JSONSchema // The property as declared, carrying its description
+}
+
+// resolveSchema follows $ref and string-shorthand anyOf to the object or
+// value schema. It also returns the Go type name of a $defs target.
+func (s *LanguageServer) resolveSchema(schema *JSONSchema) (*JSONSchema, string) {
+	typeName := ""
+	for schema != nil {
+		switch {
+		case schema.Ref != "":
+			typeName = strings.TrimPrefix(schema.Ref, "#/$defs/")
+			schema = s.schema.Defs[typeName]
+		case len(schema.AnyOf) > 0 && schema.Type == "" && len(schema.Properties) == 0:
+			var next *JSONSchema
+			for _, alt := range schema.AnyOf {
+				if alt.Ref != "" {
+					next = alt
+				}
+			}
+			if next == nil {
+				return schema, typeName
+			}
+			schema = next
+		default:
+			return schema, typeName
+		}
+	}
+	return nil, typeName
+}
+
+// schemaAt walks a path of keys and "[]" items from the document root
+func (s *LanguageServer) schemaAt(path []string) (schemaField, bool) {
+	field := schemaField{schema: s.schema, property: s.schema}
+	typeName := "Project"
+	for _, segment := range path {
+		resolved, name := s.resolveSchema(field.schema)
+		if resolved == nil {
+			return schemaField{}, false
+		}
+		if name != "" {
+			typeName = name
+		}
+		switch {
+		case segment == "[]" && resolved.Items != nil:
+			field = schemaField{schema: resolved.Items, goField: field.goField, property: field.property}
+		case resolved.Properties[segment] != nil:
+			prop := resolved.Properties[segment]
+			field = schemaField{schema: prop, goField: typeName + "." + segment, property: prop}
+		default:
+			values, ok := resolved.AdditionalProperties.(*JSONSchema)
+			if !ok {
+				return schemaField{}, false
+			}
+			field = schemaField{schema: values, goField: field.goField, property: field.property}
+		}
+	}
+	return field, true
+}
+
+// complete returns completion items for a position: the keys of the
+// enclosing mapping, or the allowed values of the field being edited
+func (s *LanguageServer) complete(content string, pos lspPosition) []lspCompletionItem {
+	lines := strings.Split(content, "\n")
+	cursor := cursorAt(lines, pos.Line, pos.Character)
+	items := []lspCompletionItem{}
+
+	if cursor.inValue {
+		valuePath := cursor.path
+		if cursor.key != "" {
+			valuePath = append(append([]string(nil), cursor.path...), cursor.key)
+		}
+		items = append(items, s.completeValues(lines, pos.Line, valuePath)...)
+		// A "- " item may also start a mapping, e.g. "- phase: ...", so its
+		// keys are offered too
+		if cursor.key != "" {
+			return items
+		}
+	}
+
+	field, ok := s.schemaAt(cursor.path)
+	if !ok {
+		return items
+	}
+	object, _ := s.resolveSchema(field.schema)
+	if object == nil || object.Type != "object" || len(object.Properties) == 0 {
+		return items
+	}
+	for _, key := range sortedSchemaKeys(object.Properties) {
+		items = append(items, lspCompletionItem{
+			Label:      key,
+			Kind:       lspCompletionProperty,
+			Detail:     object.Properties[key].Description,
+			InsertText: key + ": ",
+		})
+	}
+	return items
+}
+
+// completeValues lists the values allowed for the field at path
+func (s *LanguageServer) completeValues(lines []string, line int, path []string) []lspCompletionItem {
+	joined := strings.Join(path, ".")
+	var items []lspCompletionItem
+	switch joined {
+	case "landscape.category":
+		for _, category := range s.landscape.Categories() {
+			items = append(items, lspCompletionItem{Label: category, Kind: lspCompletionValue, Detail: "CNCF Landscape category"})
+		}
+		return items
+	case "landscape.subcategory":
+		categories := s.landscape.Categories()
+		if category := siblingValue(lines, line, "category"); s.landscape[category] != nil {
+			categories = []string{category}
+		}
+		for _, category := range categories {
+			for _, sub := range s.landscape[category] {
+				items = append(items, lspCompletionItem{Label: sub, Kind: lspCompletionValue, Detail: category})
+			}
+		}
+		return items
+	case "validation.ignore.[]", "validation.ignore.[].rule":
+		for _, rule := range s.validator.Rules().Rules() {
+			items = append(items, lspCompletionItem{Label: rule.ID(), Kind: lspCompletionEnumMember, Detail: rule.Description()})
+		}
+		return items
+	}
+
+	field, ok := s.schemaAt(path)
+	if !ok {
+		return nil
+	}
+	value, _ := s.resolveSchema(field.schema)
+	if value == nil {
+		return nil
+	}
+	for _, enum := range value.Enum {
+		items = append(items, lspCompletionItem{Label: enum, Kind: lspCompletionEnumMember, Detail: field.property.Description})
+	}
+	if value.Type == "boolean" {
+		for _, b := range []string{"true", "false"} {
+			items = append(items, lspCompletionItem{Label: b, Kind: lspCompletionValue, Detail: field.property.Description})
+		}
+	}
+	return items
+}
+
+// hover returns Markdown documentation for the key on a line
+func (s *LanguageServer) hover(content string, pos lspPosition) (string, bool) {
+	lines := strings.Split(content, "\n")
+	if pos.Line < 0 || pos.Line >= len(lines) {
+		return "", false
+	}
+	line := strings.TrimRight(lines[pos.Line], "\r")
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return "", false
+	}
+	cursor := cursorAt(lines, pos.Line, utf16Len(line[:colon+1]))
+	if cursor.key == "" {
+		return "", false
+	}
+	path := append(cursor.path, cursor.key)
+	field, ok := s.schemaAt(path)
+	if !ok {
+		return "", false
+	}
+
+	var b strings.Builder
+	resolved, typeName := s.resolveSchema(field.schema)
+	kind := schemaTypeLabel(field.schema, resolved, typeName)
+	b.WriteString(fmt.Sprintf("**%s**", strings.ReplaceAll(strings.Join(path, "."), ".[]", "[]")))
+	if kind != "" {
+		b.WriteString(fmt.Sprintf(" `%s`", kind))
+	}
+	b.WriteString("\n\n")
+
+	goDoc := s.docs.fields[field.goField]
+	if goDoc != "" {
+		b.WriteString(goDoc + "\n\n")
+	}
+	if desc := field.property.Description; desc != "" && !strings.EqualFold(desc, goDoc) {
+		b.WriteString(desc + "\n\n")
+	}
+	if typeDoc := s.docs.types[typeName]; typeDoc != "" {
+		b.WriteString(typeDoc + "\n\n")
+	}
+	if resolved != nil {
+		if len(resolved.Enum) > 0 {
+			b.WriteString("Allowed values: `" + strings.Join(resolved.Enum, "`, `") + "`\n\n")
+		}
+		if resolved.Format != "" {
+			b.WriteString(fmt.Sprintf("Format: %s\n\n", resolved.Format))
+		}
+		if resolved.Pattern != "" {
+			b.WriteString(fmt.Sprintf("Pattern: `%s`\n\n", resolved.Pattern))
+		}
+	}
+	return strings.TrimSpace(b.String()), true
+}
+
+// schemaTypeLabel describes a schema's type for hover, e.g. "PathRef" or "array of Audit"
+func schemaTypeLabel(schema, resolved *JSONSchema, typeName string) string {
+	if typeName != "" {
+		return typeName
+	}
+	if resolved == nil {
+		return ""
+	}
+	if resolved.Type == "array" && resolved.Items != nil {
+		if ref := strings.TrimPrefix(resolved.Items.Ref, "#/$defs/"); ref != "" {
+			return "array of " + ref
+		}
+		return "array of " + resolved.Items.Type
+	}
+	if resolved.Type == "object" && resolved.AdditionalProperties != nil {
+		if values, ok := resolved.AdditionalProperties.(*JSONSchema); ok {
+			return "map of " + values.Type
+		}
+	}
+	return resolved.Type
+}
+
+func sortedSchemaKeys(m map[string]*JSONSchema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// utf16Len returns the length of s in UTF-16 code units, the unit of LSP positions
+func utf16Len(s string) int {
+	return len(utf16.Encode([]rune(s)))
+}
+
+// utf16ToByte converts a UTF-16 offset within a line to a byte offset
+func utf16ToByte(line string, character int) int {
+	units := 0
+	for i, r := range line {
+		if units >= character {
+			return i
+		}
+		units += len(utf16.Encode([]rune{r}))
+	}
+	return len(line)
+}
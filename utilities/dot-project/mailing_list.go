@@ -0,0 +1,313 @@
+package projects
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EmailDirectory maps maintainer handles to email addresses
+type EmailDirectory interface {
+	Name() string
+	// Email returns the address of a handle, or "" when the directory has none
+	Email(handle string) (string, error)
+}
+
+// FileDirectory is an email directory read from a YAML file mapping handles
+// to addresses (`alice: alice@example.com`)
+type FileDirectory struct {
+	path   string
+	emails map[string]string
+}
+
+// LoadEmailDirectory reads a handle to email YAML file. Handles match case
+// insensitively, with or without a leading "@".
+func LoadEmailDirectory(path string) (*FileDirectory, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read email directory: %w", err)
+	}
+	var entries map[string]string
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse email directory %s: %w", path, err)
+	}
+	d := &FileDirectory{path: path, emails: make(map[string]string)}
+	for handle, email := range entries {
+		d.emails[strings.ToLower(strings.TrimPrefix(strings.TrimSpace(handle), "@"))] = normalizeEmail(email)
+	}
+	return d, nil
+}
+
+// Name implements EmailDirectory
+func (d *FileDirectory) Name() string { return "file:" + d.path }
+
+// Email implements EmailDirectory
+func (d *FileDirectory) Email(handle string) (string, error) {
+	return d.emails[strings.ToLower(handle)], nil
+}
+
+// LFXDirectory looks up the primary email of the LFX account linked to a
+// GitHub handle
+type LFXDirectory struct {
+	lfx *LFXVerifier
+}
+
+// NewLFXDirectory creates an LFX email directory. baseURL overrides the LFX
+// API gateway URL (use "" for default).
+func NewLFXDirectory(client *http.Client, baseURL, token string) *LFXDirectory {
+	return &LFXDirectory{lfx: NewLFXVerifier(client, baseURL, token)}
+}
+
+// Name implements EmailDirectory
+func (d *LFXDirectory) Name() string { return "lfx" }
+
+// Email implements EmailDirectory
+func (d *LFXDirectory) Email(handle string) (string, error) {
+	users, err := d.lfx.search(handle)
+	if err != nil {
+		return "", err
+	}
+	email := ""
+	for _, user := range users {
+		for _, e := range user.Emails {
+			if e.IsPrimary || email == "" {
+				email = e.EmailAddress
+			}
+		}
+	}
+	return normalizeEmail(email), nil
+}
+
+// MailingListOptions selects the maintainers that belong on a list
+type MailingListOptions struct {
+	List       string          // Address of the list, e.g. maintainers@lists.cncf.io
+	Teams      []string        // Only members of these teams (default: all teams)
+	ProjectIDs []string        // Only these maintainers entries (default: all entries)
+	Keep       map[string]bool // Addresses never removed, e.g. staff; lowercase
+}
+
+// ListMember is an address that belongs on a list, with the handle it was
+// resolved from
+type ListMember struct {
+	Email    string   `json:"email"`
+	Handle   string   `json:"handle"`
+	Projects []string `json:"projects"`
+}
+
+// MailingListDiff is the change that makes a list's membership follow
+// maintainers.yaml
+type MailingListDiff struct {
+	List      string       `json:"list"`
+	Directory string       `json:"directory"`
+	Add       []ListMember `json:"add"`
+	Remove    []string     `json:"remove"`
+	Unchanged int          `json:"unchanged"`
+	// Handles without an address. Their current addresses cannot be told
+	// apart from ones to remove, so removals are held until they resolve.
+	Unresolved   []UnresolvedHandle `json:"unresolved,omitempty"`
+	HeldRemovals []string           `json:"held_removals,omitempty"`
+}
+
+// UnresolvedHandle is a maintainer handle the directory has no address for
+type UnresolvedHandle struct {
+	Handle string `json:"handle"`
+	Error  string `json:"error,omitempty"` // Lookup failed, as opposed to no address
+}
+
+// BuildMailingListDiff resolves the selected maintainers to addresses and
+// compares them with the current members of the list. A handle listed in
+// several teams or projects is looked up once.
+func BuildMailingListDiff(config MaintainersConfig, directory EmailDirectory, opts MailingListOptions, current []string) MailingListDiff {
+	diff := MailingListDiff{List: opts.List, Directory: directory.Name(), Add: []ListMember{}, Remove: []string{}}
+
+	// Selected handles and their projects, by lowercase handle
+	projectIDs := make(map[string][]string)
+	handles := make(map[string]string)
+	for _, entry := range config.Maintainers {
+		if len(opts.ProjectIDs) > 0 && !containsFold(opts.ProjectIDs, entry.ProjectID) {
+			continue
+		}
+		for _, team := range entry.Teams {
+			if len(opts.Teams) > 0 && !containsFold(opts.Teams, team.Name) {
+				continue
+			}
+			cleaned, _ := normalizeHandles(team.Members)
+			for _, handle := range cleaned {
+				key := strings.ToLower(handle)
+				if _, seen := handles[key]; !seen {
+					handles[key] = handle
+				}
+				if !containsFold(projectIDs[key], entry.ProjectID) {
+					projectIDs[key] = append(projectIDs[key], entry.ProjectID)
+				}
+			}
+		}
+	}
+	keys := make([]string, 0, len(handles))
+	for key := range handles {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	members := make(map[string]bool)
+	for _, email := range current {
+		members[normalizeEmail(email)] = true
+	}
+	desired := make(map[string]bool)
+	for _, key := range keys {
+		email, err := directory.Email(handles[key])
+		if err != nil || email == "" {
+			unresolved := UnresolvedHandle{Handle: handles[key]}
+			if err != nil {
+				unresolved.Error = err.Error()
+			}
+			diff.Unresolved = append(diff.Unresolved, unresolved)
+			continue
+		}
+		if desired[email] {
+			continue
+		}
+		desired[email] = true
+		if members[email] {
+			diff.Unchanged++
+		} else {
+			diff.Add = append(diff.Add, ListMember{Email: email, Handle: handles[key], Projects: projectIDs[key]})
+		}
+	}
+
+	for email := range members {
+		if email != "" && !desired[email] && !opts.Keep[email] {
+			diff.Remove = append(diff.Remove, email)
+		}
+	}
+	sort.Strings(diff.Remove)
+	if len(diff.Unresolved) > 0 && len(diff.Remove) > 0 {
+		diff.HeldRemovals, diff.Remove = diff.Remove, []string{}
+	}
+	return diff
+}
+
+// LoadListMembers reads list members or other addresses from a file: a CSV
+// export (the first column whose header mentions "email") or plain text with
+// one address per line, where blank lines and "#" comments are ignored
+func LoadListMembers(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read list members: %w", err)
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return readCSVEmails(f, path)
+	}
+	var emails []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		emails = append(emails, normalizeEmail(line))
+	}
+	return emails, scanner.Err()
+}
+
+// readCSVEmails reads the email column of a CSV file
+func readCSVEmails(r io.Reader, path string) ([]string, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	column := -1
+	for i, header := range rows[0] {
+		if strings.Contains(strings.ToLower(header), "email") && !strings.Contains(strings.ToLower(header), "group") {
+			column = i
+			break
+		}
+	}
+	if column < 0 {
+		return nil, fmt.Errorf("%s has no email column", path)
+	}
+	var emails []string
+	for _, row := range rows[1:] {
+		if column < len(row) && strings.TrimSpace(row[column]) != "" {
+			emails = append(emails, normalizeEmail(row[column]))
+		}
+	}
+	return emails, nil
+}
+
+// WriteGoogleGroupsCSV writes addresses in the Google Groups bulk member
+// upload format
+func WriteGoogleGroupsCSV(w io.Writer, list string, emails []string) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"Group Email [Required]", "Member Email", "Member Type", "Member Role"})
+	for _, email := range emails {
+		writer.Write([]string{list, email, "USER", "MEMBER"})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// AddedEmails returns the addresses to add to the list
+func (d MailingListDiff) AddedEmails() []string {
+	emails := make([]string, len(d.Add))
+	for i, member := range d.Add {
+		emails[i] = member.Email
+	}
+	return emails
+}
+
+// FormatMailingListDiff formats a mailing list diff as human-readable text
+func FormatMailingListDiff(diff MailingListDiff) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Mailing List Diff: %s\n", diff.List))
+	b.WriteString("==================\n\n")
+	for _, member := range diff.Add {
+		b.WriteString(fmt.Sprintf("  + %s (%s, %s)\n", member.Email, member.Handle, strings.Join(member.Projects, ", ")))
+	}
+	for _, email := range diff.Remove {
+		b.WriteString(fmt.Sprintf("  - %s\n", email))
+	}
+	for _, u := range diff.Unresolved {
+		line := fmt.Sprintf("  ? %s: no email in %s", u.Handle, diff.Directory)
+		if u.Error != "" {
+			line = fmt.Sprintf("  ? %s: lookup failed: %s", u.Handle, u.Error)
+		}
+		b.WriteString(line + "\n")
+	}
+	if len(diff.HeldRemovals) > 0 {
+		b.WriteString(fmt.Sprintf("\n%d removals held until every handle resolves:\n", len(diff.HeldRemovals)))
+		for _, email := range diff.HeldRemovals {
+			b.WriteString(fmt.Sprintf("  - %s\n", email))
+		}
+	}
+	b.WriteString(fmt.Sprintf("\nSummary: %d to add, %d to remove, %d unchanged, %d unresolved\n", len(diff.Add), len(diff.Remove), diff.Unchanged, len(diff.Unresolved)))
+	return b.String()
+}
+
+// normalizeEmail lowercases and trims an address
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// containsFold reports whether list contains s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
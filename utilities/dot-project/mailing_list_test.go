@@ -0,0 +1,142 @@
+package projects
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mailingListConfig is two projects sharing a maintainer
+func mailingListConfig() MaintainersConfig {
+	return MaintainersConfig{Maintainers: []MaintainerEntry{
+		{ProjectID: "alpha", Teams: []Team{
+			{Name: "project-maintainers", Members: []string{"@Alice", "bob"}},
+			{Name: "reviewers", Members: []string{"carol"}},
+		}},
+		{ProjectID: "beta", Teams: []Team{
+			{Name: "project-maintainers", Members: []string{"alice", "dave"}},
+		}},
+	}}
+}
+
+func TestBuildMailingListDiff(t *testing.T) {
+	dir := t.TempDir()
+	directoryFile := filepath.Join(dir, "emails.yaml")
+	writeFile(t, directoryFile, "alice: Alice@Example.com\n\"@bob\": bob@example.com\ncarol: carol@example.com\ndave: dave@example.com\n")
+	directory, err := LoadEmailDirectory(directoryFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current := []string{"bob@example.com", "old@example.com", "staff@cncf.io"}
+	opts := MailingListOptions{
+		List:  "maintainers@lists.cncf.io",
+		Teams: []string{"project-maintainers"},
+		Keep:  map[string]bool{"staff@cncf.io": true},
+	}
+	diff := BuildMailingListDiff(mailingListConfig(), directory, opts, current)
+
+	if got := strings.Join(diff.AddedEmails(), ","); got != "alice@example.com,dave@example.com" {
+		t.Errorf("unexpected additions %s", got)
+	}
+	if strings.Join(diff.Add[0].Projects, ",") != "alpha,beta" {
+		t.Errorf("alice should be added once for both projects, got %+v", diff.Add[0])
+	}
+	if strings.Join(diff.Remove, ",") != "old@example.com" || diff.Unchanged != 1 {
+		t.Errorf("expected old@example.com removed and bob unchanged, got %+v", diff)
+	}
+
+	t.Run("project filter", func(t *testing.T) {
+		opts := opts
+		opts.ProjectIDs = []string{"beta"}
+		diff := BuildMailingListDiff(mailingListConfig(), directory, opts, nil)
+		if got := strings.Join(diff.AddedEmails(), ","); got != "alice@example.com,dave@example.com" {
+			t.Errorf("unexpected additions %s", got)
+		}
+	})
+
+	t.Run("unresolved handles hold removals", func(t *testing.T) {
+		config := mailingListConfig()
+		config.Maintainers[1].Teams[0].Members = append(config.Maintainers[1].Teams[0].Members, "erin")
+		diff := BuildMailingListDiff(config, directory, opts, current)
+		if len(diff.Unresolved) != 1 || diff.Unresolved[0].Handle != "erin" {
+			t.Errorf("expected erin unresolved, got %+v", diff.Unresolved)
+		}
+		if len(diff.Remove) != 0 || strings.Join(diff.HeldRemovals, ",") != "old@example.com" {
+			t.Errorf("expected removals to be held, got remove %v held %v", diff.Remove, diff.HeldRemovals)
+		}
+		output := FormatMailingListDiff(diff)
+		for _, want := range []string{"+ alice@example.com (Alice, alpha, beta)", "? erin: no email in file:", "1 removals held"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("expected %q in output:\n%s", want, output)
+			}
+		}
+	})
+}
+
+func TestLFXDirectory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("githubID") {
+		case "alice":
+			w.Write([]byte(`{"data":[{"Emails":[{"EmailAddress":"alice@old.example.com"},{"EmailAddress":"Alice@example.com","IsPrimary":true}]}]}`))
+		case "broken":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.Write([]byte(`{"data":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	directory := NewLFXDirectory(server.Client(), server.URL, "token")
+	for handle, want := range map[string]string{"alice": "alice@example.com", "nobody": ""} {
+		if got, err := directory.Email(handle); err != nil || got != want {
+			t.Errorf("%s: got %q, %v; want %q", handle, got, err, want)
+		}
+	}
+	if _, err := directory.Email("broken"); err == nil {
+		t.Error("expected an error when LFX fails")
+	}
+
+	diff := BuildMailingListDiff(MaintainersConfig{Maintainers: []MaintainerEntry{{ProjectID: "p", Teams: []Team{{Name: "m", Members: []string{"broken"}}}}}}, directory, MailingListOptions{}, nil)
+	if len(diff.Unresolved) != 1 || !strings.Contains(diff.Unresolved[0].Error, "HTTP 502") {
+		t.Errorf("expected a failed lookup to be reported, got %+v", diff.Unresolved)
+	}
+}
+
+func TestListMembersFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	text := filepath.Join(dir, "maintainers_emails.txt")
+	writeFile(t, text, "# Maintainers\n\nAlice@Example.com\n  bob@example.com  \n")
+	emails, err := LoadListMembers(text)
+	if err != nil || strings.Join(emails, ",") != "alice@example.com,bob@example.com" {
+		t.Errorf("unexpected text members %v, %v", emails, err)
+	}
+
+	export := filepath.Join(dir, "members.csv")
+	writeFile(t, export, "Group Email,Email address,Nickname\nlist@example.com,Carol@example.com,carol\nlist@example.com,,\n")
+	emails, err = LoadListMembers(export)
+	if err != nil || strings.Join(emails, ",") != "carol@example.com" {
+		t.Errorf("unexpected CSV members %v, %v", emails, err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteGoogleGroupsCSV(&buf, "list@example.com", []string{"a@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	want := fmt.Sprintf("%s\n%s\n", "Group Email [Required],Member Email,Member Type,Member Role", "list@example.com,a@example.com,USER,MEMBER")
+	if buf.String() != want {
+		t.Errorf("unexpected CSV:\n%s", buf.String())
+	}
+
+	// The CSV written for Google Groups reads back as its member addresses
+	roundTrip := filepath.Join(dir, "add.csv")
+	writeFile(t, roundTrip, buf.String())
+	if emails, err := LoadListMembers(roundTrip); err != nil || strings.Join(emails, ",") != "a@example.com" {
+		t.Errorf("unexpected round trip %v, %v", emails, err)
+	}
+}
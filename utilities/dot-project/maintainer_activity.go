@@ -0,0 +1,343 @@
+package projects
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ActivityOptions controls the maintainer activity analysis
+type ActivityOptions struct {
+	Months         int           // Maintainers with no activity in this many months are inactive
+	GitHubAPIURL   string        // Overrides the GitHub API URL (use "" for default)
+	GitHubToken    string        // Token for the GitHub API; the search API requires one for useful rate limits
+	Concurrency    int           // Maintainers looked up at once
+	SearchInterval time.Duration // Minimum delay between search API requests (30 a minute with a token)
+}
+
+// DefaultActivityOptions returns the activity settings used by the CLI
+func DefaultActivityOptions() ActivityOptions {
+	return ActivityOptions{
+		Months:         12,
+		Concurrency:    4,
+		SearchInterval: 2 * time.Second,
+	}
+}
+
+// ActivityEvidence is a dated contribution of a maintainer
+type ActivityEvidence struct {
+	Kind       string    `json:"kind"` // "commit", "review" or "merged_pr"
+	Date       time.Time `json:"date"`
+	Repository string    `json:"repository"` // owner/repo
+	URL        string    `json:"url"`
+}
+
+// MaintainerActivity is the activity of one maintainer handle
+type MaintainerActivity struct {
+	Handle       string            `json:"handle"`
+	Teams        []string          `json:"teams"`
+	LastActivity *ActivityEvidence `json:"last_activity,omitempty"` // Nil when no activity was found at all
+	Inactive     bool              `json:"inactive"`
+	Error        string            `json:"error,omitempty"` // Lookup failed, so activity is unknown
+}
+
+// ActivityReport is the activity of the maintainers of one project
+type ActivityReport struct {
+	ProjectID     string               `json:"project_id"`
+	Repositories  []string             `json:"repositories"` // owner/repo of the GitHub repositories searched
+	Cutoff        time.Time            `json:"cutoff"`       // Activity before this date does not count
+	Maintainers   []MaintainerActivity `json:"maintainers"`
+	InactiveCount int                  `json:"inactive_count"`
+}
+
+// ActivityAnalyser looks up recent commits, reviews and merged pull requests
+// of maintainers in their project's GitHub repositories
+type ActivityAnalyser struct {
+	client  *http.Client
+	options ActivityOptions
+	baseURL string
+	limiter *hostLimiter // Spaces out search API requests
+}
+
+// NewActivityAnalyser creates an activity analyser
+func NewActivityAnalyser(client *http.Client, opts ActivityOptions) *ActivityAnalyser {
+	baseURL := opts.GitHubAPIURL
+	if baseURL == "" {
+		baseURL = defaultGitHubAPIURL
+	}
+	return &ActivityAnalyser{
+		client:  client,
+		options: opts,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		limiter: newHostLimiter(opts.SearchInterval),
+	}
+}
+
+// AnalyseEntry checks the activity of every handle in a maintainers entry
+// across the GitHub repositories of the project. Lookups stop at the first
+// activity newer than the cutoff, so the evidence of an active maintainer is
+// recent but not necessarily their latest. Pull requests are only searched
+// since the cutoff, so an inactive maintainer's evidence is their last commit,
+// or an older review found on a recently updated pull request.
+func (a *ActivityAnalyser) AnalyseEntry(entry MaintainerEntry, repositories []string) ActivityReport {
+	report := ActivityReport{
+		ProjectID: entry.ProjectID,
+		Cutoff:    time.Now().AddDate(0, -a.options.Months, 0),
+	}
+	var repos []string
+	for _, repository := range repositories {
+		if owner, repo, ok := parseGitHubRepoURL(repository); ok {
+			repos = append(repos, owner+"/"+repo)
+		}
+	}
+	report.Repositories = repos
+
+	// A handle listed in several teams is looked up once
+	teams := make(map[string][]string)
+	var handles []string
+	for _, team := range entry.Teams {
+		cleaned, _ := normalizeHandles(team.Members)
+		for _, handle := range cleaned {
+			key := strings.ToLower(handle)
+			if _, seen := teams[key]; !seen {
+				handles = append(handles, handle)
+			}
+			teams[key] = append(teams[key], team.Name)
+		}
+	}
+	sort.Slice(handles, func(i, j int) bool { return strings.ToLower(handles[i]) < strings.ToLower(handles[j]) })
+
+	report.Maintainers = make([]MaintainerActivity, len(handles))
+	forEachConcurrently(len(handles), a.options.Concurrency, func(i int) {
+		activity := MaintainerActivity{Handle: handles[i], Teams: teams[strings.ToLower(handles[i])]}
+		if len(repos) == 0 {
+			activity.Error = "project has no GitHub repositories"
+		} else {
+			evidence, err := a.lastActivity(handles[i], repos, report.Cutoff)
+			activity.LastActivity = evidence
+			if err != nil {
+				activity.Error = err.Error()
+			} else {
+				activity.Inactive = evidence == nil || evidence.Date.Before(report.Cutoff)
+			}
+		}
+		report.Maintainers[i] = activity
+	})
+
+	for _, m := range report.Maintainers {
+		if m.Inactive {
+			report.InactiveCount++
+		}
+	}
+	return report
+}
+
+// lastActivity returns the latest activity of a handle, stopping as soon as
+// an activity newer than cutoff is found
+func (a *ActivityAnalyser) lastActivity(handle string, repos []string, cutoff time.Time) (*ActivityEvidence, error) {
+	var latest *ActivityEvidence
+	lookups := []func(string, []string, time.Time) (*ActivityEvidence, error){a.lastCommit, a.lastMergedPR, a.lastReview}
+	for _, lookup := range lookups {
+		evidence, err := lookup(handle, repos, cutoff)
+		if err != nil {
+			return latest, err
+		}
+		if evidence != nil && (latest == nil || evidence.Date.After(latest.Date)) {
+			latest = evidence
+		}
+		if latest != nil && latest.Date.After(cutoff) {
+			break
+		}
+	}
+	return latest, nil
+}
+
+// lastCommit returns the latest commit authored by handle in any repository
+func (a *ActivityAnalyser) lastCommit(handle string, repos []string, _ time.Time) (*ActivityEvidence, error) {
+	var latest *ActivityEvidence
+	for _, repo := range repos {
+		var commits []struct {
+			HTMLURL string `json:"html_url"`
+			Commit  struct {
+				Author struct {
+					Date time.Time `json:"date"`
+				} `json:"author"`
+			} `json:"commit"`
+		}
+		endpoint := fmt.Sprintf("%s/repos/%s/commits?author=%s&per_page=1", a.baseURL, repo, url.QueryEscape(handle))
+		if err := githubJSON(a.client, http.MethodGet, endpoint, a.options.GitHubToken, nil, &commits); err != nil {
+			return nil, fmt.Errorf("commits of %s in %s: %w", handle, repo, err)
+		}
+		if len(commits) > 0 && (latest == nil || commits[0].Commit.Author.Date.After(latest.Date)) {
+			latest = &ActivityEvidence{Kind: "commit", Date: commits[0].Commit.Author.Date, Repository: repo, URL: commits[0].HTMLURL}
+		}
+	}
+	return latest, nil
+}
+
+// searchedPR is a pull request returned by the issue search API
+type searchedPR struct {
+	Number        int       `json:"number"`
+	HTMLURL       string    `json:"html_url"`
+	RepositoryURL string    `json:"repository_url"`
+	ClosedAt      time.Time `json:"closed_at"`
+	PullRequest   struct {
+		MergedAt *time.Time `json:"merged_at"`
+	} `json:"pull_request"`
+}
+
+// repository returns the owner/repo of a searched pull request
+func (pr searchedPR) repository() string {
+	parts := strings.Split(strings.TrimSuffix(pr.RepositoryURL, "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[len(parts)-2] + "/" + parts[len(parts)-1]
+}
+
+// Search result pages read per query; the search API returns at most 1000
+// results (10 pages of 100) anyway
+const (
+	searchPageSize = 100
+	maxSearchPages = 10
+)
+
+// searchPRs runs an issue search for pull requests, most recently updated
+// first, and calls visit for each result in one of repos until visit returns
+// true. The query is not scoped with repo: qualifiers, which would exceed the
+// search API's query length limit for projects with many repositories, so
+// callers bound it by date and results elsewhere are skipped here. Running
+// out of results the search API returns before visit is done is an error, so
+// activity past them is reported as unknown rather than missing.
+func (a *ActivityAnalyser) searchPRs(qualifiers string, repos []string, visit func(searchedPR) (bool, error)) error {
+	inRepos := make(map[string]bool, len(repos))
+	for _, repo := range repos {
+		inRepos[strings.ToLower(repo)] = true
+	}
+	query := "type:pr " + qualifiers
+	for page := 1; page <= maxSearchPages; page++ {
+		if parsed, err := url.Parse(a.baseURL); err == nil {
+			a.limiter.wait(parsed.Host)
+		}
+		var result struct {
+			TotalCount int          `json:"total_count"`
+			Items      []searchedPR `json:"items"`
+		}
+		endpoint := fmt.Sprintf("%s/search/issues?q=%s&sort=updated&order=desc&per_page=%d&page=%d", a.baseURL, url.QueryEscape(query), searchPageSize, page)
+		if err := githubJSON(a.client, http.MethodGet, endpoint, a.options.GitHubToken, nil, &result); err != nil {
+			return err
+		}
+		for _, pr := range result.Items {
+			if !inRepos[strings.ToLower(pr.repository())] {
+				continue
+			}
+			if done, err := visit(pr); err != nil || done {
+				return err
+			}
+		}
+		if len(result.Items) < searchPageSize || page*searchPageSize >= result.TotalCount {
+			return nil
+		}
+	}
+	return fmt.Errorf("search matched more pull requests than the %d it returns", maxSearchPages*searchPageSize)
+}
+
+// lastMergedPR returns a pull request authored by handle and merged since
+// cutoff, or nil if there is none
+func (a *ActivityAnalyser) lastMergedPR(handle string, repos []string, cutoff time.Time) (*ActivityEvidence, error) {
+	var found *ActivityEvidence
+	qualifiers := fmt.Sprintf("is:merged author:%s merged:>=%s", handle, cutoff.Format("2006-01-02"))
+	err := a.searchPRs(qualifiers, repos, func(pr searchedPR) (bool, error) {
+		merged := pr.ClosedAt
+		if pr.PullRequest.MergedAt != nil {
+			merged = *pr.PullRequest.MergedAt
+		}
+		found = &ActivityEvidence{Kind: "merged_pr", Date: merged, Repository: pr.repository(), URL: pr.HTMLURL}
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("merged pull requests of %s: %w", handle, err)
+	}
+	return found, nil
+}
+
+// lastReview returns a review by handle on someone else's pull request,
+// stopping at the first one submitted since cutoff. The search finds pull
+// requests updated since cutoff; their reviews give the date, so an older
+// review found on the way is returned when there is no newer one.
+func (a *ActivityAnalyser) lastReview(handle string, repos []string, cutoff time.Time) (*ActivityEvidence, error) {
+	var latest *ActivityEvidence
+	qualifiers := fmt.Sprintf("reviewed-by:%s -author:%s updated:>=%s", handle, handle, cutoff.Format("2006-01-02"))
+	err := a.searchPRs(qualifiers, repos, func(pr searchedPR) (bool, error) {
+		// Busy pull requests have more than a page of reviews
+		for page := 1; ; page++ {
+			var reviews []struct {
+				HTMLURL     string    `json:"html_url"`
+				SubmittedAt time.Time `json:"submitted_at"`
+				User        struct {
+					Login string `json:"login"`
+				} `json:"user"`
+			}
+			endpoint := fmt.Sprintf("%s/repos/%s/pulls/%d/reviews?per_page=100&page=%d", a.baseURL, pr.repository(), pr.Number, page)
+			if err := githubJSON(a.client, http.MethodGet, endpoint, a.options.GitHubToken, nil, &reviews); err != nil {
+				return false, fmt.Errorf("on %s: %w", pr.HTMLURL, err)
+			}
+			for _, review := range reviews {
+				if !strings.EqualFold(review.User.Login, handle) {
+					continue
+				}
+				if latest == nil || review.SubmittedAt.After(latest.Date) {
+					latest = &ActivityEvidence{Kind: "review", Date: review.SubmittedAt, Repository: pr.repository(), URL: review.HTMLURL}
+				}
+			}
+			if len(reviews) < 100 {
+				break
+			}
+		}
+		return latest != nil && !latest.Date.Before(cutoff), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reviews of %s: %w", handle, err)
+	}
+	return latest, nil
+}
+
+// FormatActivityReports formats activity reports as human-readable text
+func FormatActivityReports(reports []ActivityReport) string {
+	var b strings.Builder
+	b.WriteString("Maintainer Activity Report\n")
+	b.WriteString("==========================\n")
+
+	total, inactive, unknown := 0, 0, 0
+	for _, report := range reports {
+		b.WriteString(fmt.Sprintf("\n%s (activity since %s in %s)\n", report.ProjectID, report.Cutoff.Format("2006-01-02"), strings.Join(report.Repositories, ", ")))
+		for _, m := range report.Maintainers {
+			total++
+			status := "ACTIVE  "
+			switch {
+			case m.Error != "":
+				unknown++
+				status = "UNKNOWN "
+			case m.Inactive:
+				inactive++
+				status = "INACTIVE"
+			}
+			line := fmt.Sprintf("  %s %s (%s)", status, m.Handle, strings.Join(m.Teams, ", "))
+			if e := m.LastActivity; e != nil {
+				line += fmt.Sprintf(": last %s on %s in %s %s", strings.ReplaceAll(e.Kind, "_", " "), e.Date.Format("2006-01-02"), e.Repository, e.URL)
+			} else if m.Error == "" {
+				line += ": no activity found"
+			}
+			if m.Error != "" {
+				line += ": " + m.Error
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	b.WriteString(fmt.Sprintf("\nSummary: %d maintainers checked, %d inactive, %d unknown\n", total, inactive, unknown))
+	return b.String()
+}
@@ -0,0 +1,212 @@
+package projects
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestActivityAnalyser(t *testing.T) {
+	day := func(n int) string {
+		return time.Now().AddDate(0, 0, -n).UTC().Format(time.RFC3339)
+	}
+	// Latest commit per "repo author"
+	commits := map[string]int{
+		"test/one alice": 30,
+		"test/two carol": 400,
+		"test/one carol": 700,
+	}
+	var mu sync.Mutex
+	var searches []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		switch {
+		case strings.HasSuffix(path, "/commits"):
+			repo := strings.TrimSuffix(strings.TrimPrefix(path, "/repos/"), "/commits")
+			author := r.URL.Query().Get("author")
+			if author == "erin" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			if days, ok := commits[repo+" "+author]; ok {
+				fmt.Fprintf(w, `[{"html_url":"https://github.com/%s/commit/abc","commit":{"author":{"date":%q}}}]`, repo, day(days))
+				return
+			}
+			w.Write([]byte("[]"))
+		case path == "/search/issues":
+			q := r.URL.Query().Get("q")
+			mu.Lock()
+			searches = append(searches, q)
+			mu.Unlock()
+			if strings.Contains(q, "repo:") {
+				t.Errorf("searches should be bounded by date, not by repository: %s", q)
+			}
+			cutoff := time.Now().AddDate(-1, 0, 0).Format("2006-01-02")
+			switch {
+			case strings.Contains(q, "is:merged author:bob merged:>="+cutoff):
+				// bob's only merged pull request is 800 days old, outside the window
+				w.Write([]byte(`{"items":[]}`))
+			case strings.Contains(q, "is:merged author:carol merged:>="+cutoff):
+				// A merge in another project's repository does not count
+				fmt.Fprintf(w, `{"items":[{"number":9,"html_url":"https://github.com/other/repo/pull/9","repository_url":"https://api.github.com/repos/other/repo","closed_at":%q,"pull_request":{"merged_at":%q}},`+
+					`{"number":2,"html_url":"https://github.com/test/two/pull/2","repository_url":"https://api.github.com/repos/test/two","closed_at":%q,"pull_request":{"merged_at":%q}}]}`, day(10), day(10), day(60), day(60))
+			case strings.Contains(q, "reviewed-by:bob -author:bob updated:>="+cutoff):
+				w.Write([]byte(`{"items":[{"number":3,"html_url":"https://github.com/test/two/pull/3","repository_url":"https://api.github.com/repos/test/two"}]}`))
+			case strings.Contains(q, "is:merged"), strings.Contains(q, "reviewed-by:"):
+				if !strings.Contains(q, ":>="+cutoff) {
+					t.Errorf("search is not bounded by the cutoff: %s", q)
+				}
+				w.Write([]byte(`{"items":[]}`))
+			default:
+				t.Errorf("unexpected search: %s", q)
+				w.Write([]byte(`{"items":[]}`))
+			}
+		case path == "/repos/test/two/pulls/3/reviews":
+			fmt.Fprintf(w, `[{"html_url":"https://github.com/test/two/pull/3#review-1","submitted_at":%q,"user":{"login":"Bob"}},{"html_url":"x","submitted_at":%q,"user":{"login":"someone"}}]`, day(500), day(1))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	opts := DefaultActivityOptions()
+	opts.GitHubAPIURL = server.URL
+	opts.SearchInterval = 0
+	analyser := NewActivityAnalyser(server.Client(), opts)
+	entry := MaintainerEntry{
+		ProjectID: "test-project",
+		Teams: []Team{
+			{Name: "maintainers", Members: []string{"alice", "@bob", "carol", "dave", "erin"}},
+			{Name: "reviewers", Members: []string{"Carol"}},
+		},
+	}
+	report := analyser.AnalyseEntry(entry, []string{"https://github.com/test/one", "https://github.com/test/two.git", "https://gitlab.com/test/three"})
+
+	if strings.Join(report.Repositories, ",") != "test/one,test/two" {
+		t.Errorf("unexpected repositories %v", report.Repositories)
+	}
+	byHandle := make(map[string]MaintainerActivity)
+	for _, m := range report.Maintainers {
+		byHandle[m.Handle] = m
+	}
+	if len(report.Maintainers) != 5 {
+		t.Fatalf("expected 5 maintainers, got %+v", report.Maintainers)
+	}
+
+	check := func(handle string, inactive bool, kind string, days int) {
+		t.Helper()
+		m := byHandle[handle]
+		if m.Inactive != inactive {
+			t.Errorf("%s: inactive = %v, want %v (%+v)", handle, m.Inactive, inactive, m)
+		}
+		if kind == "" {
+			if m.LastActivity != nil {
+				t.Errorf("%s: expected no activity, got %+v", handle, m.LastActivity)
+			}
+			return
+		}
+		if m.LastActivity == nil || m.LastActivity.Kind != kind || daysAgo(m.LastActivity.Date) != days {
+			t.Errorf("%s: expected a %s %d days ago, got %+v", handle, kind, days, m.LastActivity)
+		}
+	}
+	check("alice", false, "commit", 30)
+	check("bob", true, "review", 500)
+	check("carol", false, "merged_pr", 60)
+	if byHandle["carol"].LastActivity.Repository != "test/two" {
+		t.Errorf("carol's evidence should come from the project's repositories: %+v", byHandle["carol"].LastActivity)
+	}
+	check("dave", true, "", 0)
+
+	if byHandle["bob"].LastActivity.URL != "https://github.com/test/two/pull/3#review-1" || byHandle["bob"].LastActivity.Repository != "test/two" {
+		t.Errorf("review evidence should link the review: %+v", byHandle["bob"].LastActivity)
+	}
+	if strings.Join(byHandle["carol"].Teams, ",") != "maintainers,reviewers" {
+		t.Errorf("carol should be looked up once for both teams: %+v", byHandle["carol"])
+	}
+	if erin := byHandle["erin"]; erin.Error == "" || erin.Inactive {
+		t.Errorf("a failed lookup should be unknown, not inactive: %+v", erin)
+	}
+	if report.InactiveCount != 2 {
+		t.Errorf("expected 2 inactive maintainers, got %d", report.InactiveCount)
+	}
+	for _, q := range searches {
+		if strings.Contains(q, "alice") {
+			t.Errorf("a recent commit should end the lookups for alice, got search %q", q)
+		}
+	}
+
+	output := FormatActivityReports([]ActivityReport{report})
+	for _, want := range []string{
+		"INACTIVE bob (maintainers): last review on",
+		"INACTIVE dave (maintainers): no activity found",
+		"UNKNOWN  erin (maintainers): commits of erin in test/one",
+		"Summary: 5 maintainers checked, 2 inactive, 1 unknown",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestActivityAnalyserWithoutRepositories(t *testing.T) {
+	analyser := NewActivityAnalyser(http.DefaultClient, DefaultActivityOptions())
+	report := analyser.AnalyseEntry(MaintainerEntry{ProjectID: "p", Teams: []Team{{Name: "m", Members: []string{"alice"}}}}, []string{"https://gitlab.com/p/p"})
+	if len(report.Maintainers) != 1 || report.Maintainers[0].Error == "" || report.InactiveCount != 0 {
+		t.Errorf("expected an unknown maintainer without GitHub repositories, got %+v", report)
+	}
+}
+
+func TestActivityAnalyserSearchLimits(t *testing.T) {
+	recent := time.Now().AddDate(0, 0, -5).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/commits"):
+			w.Write([]byte("[]"))
+		case r.URL.Path == "/search/issues" && strings.Contains(q, "reviewed-by:frank"):
+			// More results than the search returns, none in the project
+			items := make([]string, searchPageSize)
+			for i := range items {
+				items[i] = fmt.Sprintf(`{"number":%d,"repository_url":"https://api.github.com/repos/other/repo"}`, i)
+			}
+			fmt.Fprintf(w, `{"total_count":5000,"items":[%s]}`, strings.Join(items, ","))
+		case r.URL.Path == "/search/issues" && strings.Contains(q, "reviewed-by:grace"):
+			w.Write([]byte(`{"total_count":1,"items":[{"number":7,"html_url":"https://github.com/test/one/pull/7","repository_url":"https://api.github.com/repos/test/one"}]}`))
+		case r.URL.Path == "/search/issues":
+			w.Write([]byte(`{"total_count":0,"items":[]}`))
+		case r.URL.Path == "/repos/test/one/pulls/7/reviews":
+			// grace's review is on the second page
+			if r.URL.Query().Get("page") == "2" {
+				fmt.Fprintf(w, `[{"html_url":"https://github.com/test/one/pull/7#review-101","submitted_at":%q,"user":{"login":"grace"}}]`, recent)
+				return
+			}
+			reviews := make([]string, 100)
+			for i := range reviews {
+				reviews[i] = fmt.Sprintf(`{"submitted_at":%q,"user":{"login":"bot"}}`, recent)
+			}
+			fmt.Fprintf(w, "[%s]", strings.Join(reviews, ","))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	opts := DefaultActivityOptions()
+	opts.GitHubAPIURL = server.URL
+	opts.SearchInterval = 0
+	report := NewActivityAnalyser(server.Client(), opts).AnalyseEntry(
+		MaintainerEntry{ProjectID: "p", Teams: []Team{{Name: "m", Members: []string{"frank", "grace"}}}},
+		[]string{"https://github.com/test/one"})
+
+	frank, grace := report.Maintainers[0], report.Maintainers[1]
+	if frank.Inactive || !strings.Contains(frank.Error, "more pull requests than") {
+		t.Errorf("a truncated search should be unknown, not inactive: %+v", frank)
+	}
+	if grace.Inactive || grace.LastActivity == nil || grace.LastActivity.URL != "https://github.com/test/one/pull/7#review-101" {
+		t.Errorf("expected grace's review from the second page, got %+v", grace)
+	}
+}
@@ -2,6 +2,7 @@ package projects
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -32,6 +33,9 @@ func (pv *ProjectValidator) ValidateMaintainersFileWithExclusion(path string, ve
 	if len(config.Maintainers) == 0 {
 		return nil, fmt.Errorf("maintainers file %s does not contain any entries", path)
 	}
+	if verify && pv.handleVerifier() == nil {
+		return nil, errNoHandleVerifier
+	}
 
 	var results []MaintainerValidationResult
 	for _, entry := range config.Maintainers {
@@ -108,7 +112,11 @@ func (pv *ProjectValidator) validateMaintainerEntry(entry MaintainerEntry, verif
 				if excludedHandles != nil && excludedHandles[strings.ToLower(handle)] {
 					continue
 				}
-				if err := pv.verifyHandleWithExternalService(entry.ProjectID, handle); err != nil {
+				check, err := pv.verifyHandleWithExternalService(entry.Org, handle)
+				if check != nil {
+					result.Handles = append(result.Handles, *check)
+				}
+				if err != nil {
 					result.Errors = append(result.Errors, fmt.Sprintf("verification failed for %s (team %s): %v", handle, team.Name, err))
 					allPassed = false
 				} else {
@@ -154,28 +162,27 @@ func normalizeHandles(handles []string) ([]string, []string) {
 	return cleaned, errors
 }
 
-func (pv *ProjectValidator) verifyHandleWithExternalService(projectID, handle string) error {
-	// If LFX_AUTH_TOKEN is set, perform LFX validation
-	if os.Getenv("LFX_AUTH_TOKEN") != "" {
-		if !checkMaintainerInLFX(handle) {
-			return fmt.Errorf("handle %s not found in LFX", handle)
-		}
-		return nil
-	}
+// errNoHandleVerifier is returned when verification is requested without a
+// verifier, so unchecked handles are never reported as verified
+var errNoHandleVerifier = errors.New("maintainer verification requested but no handle verifier is configured (set one with SetHandleVerifier, or set LFX_AUTH_TOKEN)")
 
-	endpoint := os.Getenv("MAINTAINER_API_ENDPOINT")
-	if endpoint == "" {
-		log.Printf("[maintainers] Skipping external verification for %s (no MAINTAINER_API_ENDPOINT set)", handle)
-		return nil
+// verifyHandleWithExternalService checks a handle with the configured
+// HandleVerifier. It returns an error when no verifier is configured, when
+// the handle failed verification or when it could not be checked.
+func (pv *ProjectValidator) verifyHandleWithExternalService(org, handle string) (*HandleCheck, error) {
+	verifier := pv.handleVerifier()
+	if verifier == nil {
+		return nil, errNoHandleVerifier
 	}
 
-	log.Printf("[maintainers] Stub verifying handle %s for project %s via %s", handle, projectID, endpoint)
-
-	if strings.EqualFold(os.Getenv("MAINTAINER_API_STUB"), "fail") {
-		return fmt.Errorf("stubbed failure for handle %s", handle)
+	check, err := verifier.Verify(HandleRequest{Handle: handle, Org: org})
+	if err != nil {
+		return nil, fmt.Errorf("%s verifier could not check %s: %w", verifier.Name(), handle, err)
 	}
-
-	return nil
+	if !check.Verified {
+		return &check, fmt.Errorf("%s", check.Message)
+	}
+	return &check, nil
 }
 
 // FormatMaintainersResults formats maintainer validation results
@@ -220,47 +227,18 @@ func formatMaintainersText(results []MaintainerValidationResult) string {
 	return b.String()
 }
 
+// checkMaintainerInLFX reports whether a handle has an LFX account, using
+// LFX_AUTH_TOKEN and MAINTAINER_API_ENDPOINT
 func checkMaintainerInLFX(handle string) bool {
 	token := os.Getenv("LFX_AUTH_TOKEN")
 	if token == "" {
 		log.Printf("LFX_AUTH_TOKEN environment variable is not set")
 		return false
 	}
-	apiURL := "https://api-gw.platform.linuxfoundation.org/user-service/v1/users/search"
-
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		log.Printf("Error creating request: %v", err)
-		return false
-	}
-
-	q := req.URL.Query()
-	q.Add("githubID", handle)
-	req.URL.RawQuery = q.Encode()
-
-	req.Header.Add("Authorization", "Bearer "+token)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	check, err := NewLFXVerifier(&http.Client{}, os.Getenv("MAINTAINER_API_ENDPOINT"), token).Verify(HandleRequest{Handle: handle})
 	if err != nil {
-		log.Printf("Error making request to LFX: %v", err)
-		return false
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("LFX API returned status: %d", resp.StatusCode)
+		log.Printf("Error checking LFX: %v", err)
 		return false
 	}
-
-	var result struct {
-		Data []interface{} `json:"data"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		log.Printf("Error decoding response: %v", err)
-		return false
-	}
-
-	return len(result.Data) > 0
+	return check.Verified
 }
@@ -2,6 +2,8 @@ package projects
 
 import (
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -281,60 +283,95 @@ func TestCheckMaintainerInLFX(t *testing.T) {
 // verifyHandleWithExternalService
 // ---------------------------------------------------------------------------
 
+// newTestAllowList returns an allow-list verifier of handles
+func newTestAllowList(t *testing.T, handles ...string) *AllowListVerifier {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "allow.txt")
+	writeFile(t, path, "# test handles\n"+strings.Join(handles, "\n")+"\n")
+	verifier, err := LoadAllowList(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return verifier
+}
+
 func TestVerifyHandle(t *testing.T) {
 	cacheDir := filepath.Join(t.TempDir(), "cache")
 	pv := NewValidator(cacheDir)
 
-	t.Run("skip when no env vars set", func(t *testing.T) {
+	lfx := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("githubID") == "alice" {
+			w.Write([]byte(`{"data":[{"id":"1"}]}`))
+			return
+		}
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer lfx.Close()
+
+	t.Run("error when no verifier is configured", func(t *testing.T) {
 		t.Setenv("LFX_AUTH_TOKEN", "")
 		t.Setenv("MAINTAINER_API_ENDPOINT", "")
-		t.Setenv("MAINTAINER_API_STUB", "")
 
-		err := pv.verifyHandleWithExternalService("proj", "alice")
-		if err != nil {
-			t.Errorf("expected nil error when no env vars set, got: %v", err)
+		check, err := pv.verifyHandleWithExternalService("", "alice")
+		if err != errNoHandleVerifier || check != nil {
+			t.Errorf("expected no check and errNoHandleVerifier without a verifier, got %+v, %v", check, err)
 		}
 	})
 
-	t.Run("stub success when endpoint set and stub not fail", func(t *testing.T) {
-		t.Setenv("LFX_AUTH_TOKEN", "")
-		t.Setenv("MAINTAINER_API_ENDPOINT", "https://example.com/api")
-		t.Setenv("MAINTAINER_API_STUB", "")
+	t.Run("LFX_AUTH_TOKEN selects the LFX verifier", func(t *testing.T) {
+		t.Setenv("LFX_AUTH_TOKEN", "test-token")
+		t.Setenv("MAINTAINER_API_ENDPOINT", lfx.URL)
 
-		err := pv.verifyHandleWithExternalService("proj", "alice")
+		check, err := pv.verifyHandleWithExternalService("", "alice")
 		if err != nil {
-			t.Errorf("expected nil error for stub success, got: %v", err)
+			t.Fatalf("expected alice to verify, got: %v", err)
+		}
+		if check == nil || !check.Verified || check.Source != "lfx" {
+			t.Errorf("expected a verified LFX check, got %+v", check)
 		}
 	})
 
-	t.Run("stub failure when MAINTAINER_API_STUB=fail", func(t *testing.T) {
-		t.Setenv("LFX_AUTH_TOKEN", "")
-		t.Setenv("MAINTAINER_API_ENDPOINT", "https://example.com/api")
-		t.Setenv("MAINTAINER_API_STUB", "fail")
+	t.Run("handle not in LFX returns error", func(t *testing.T) {
+		t.Setenv("LFX_AUTH_TOKEN", "test-token")
+		t.Setenv("MAINTAINER_API_ENDPOINT", lfx.URL)
 
-		err := pv.verifyHandleWithExternalService("proj", "alice")
+		check, err := pv.verifyHandleWithExternalService("", "nonexistent-handle")
 		if err == nil {
-			t.Fatal("expected error for stubbed failure")
+			t.Fatal("expected error when LFX does not know the handle")
 		}
-		if !strings.Contains(err.Error(), "stubbed failure") {
-			t.Errorf("error should mention stubbed failure, got: %v", err)
+		if !strings.Contains(err.Error(), "not found in LFX") {
+			t.Errorf("expected 'not found in LFX' error, got: %v", err)
+		}
+		if check == nil || check.Verified {
+			t.Errorf("expected a failed check to be returned, got %+v", check)
 		}
 	})
 
-	t.Run("LFX_AUTH_TOKEN set but handle not in LFX returns error", func(t *testing.T) {
-		// When LFX_AUTH_TOKEN is set, it tries to call the real LFX API.
-		// Since the token is invalid, the API will return non-200, so
-		// checkMaintainerInLFX returns false, and we get an error.
+	t.Run("rejected token is inconclusive", func(t *testing.T) {
 		t.Setenv("LFX_AUTH_TOKEN", "invalid-token-for-test")
-		t.Setenv("MAINTAINER_API_ENDPOINT", "")
-		t.Setenv("MAINTAINER_API_STUB", "")
+		t.Setenv("MAINTAINER_API_ENDPOINT", lfx.URL)
 
-		err := pv.verifyHandleWithExternalService("proj", "nonexistent-handle")
-		if err == nil {
-			t.Fatal("expected error when LFX returns false")
+		check, err := pv.verifyHandleWithExternalService("", "alice")
+		if err == nil || !strings.Contains(err.Error(), "could not check") {
+			t.Errorf("expected a 'could not check' error, got: %v", err)
 		}
-		if !strings.Contains(err.Error(), "not found in LFX") {
-			t.Errorf("expected 'not found in LFX' error, got: %v", err)
+		if check != nil {
+			t.Errorf("an inconclusive check should not be recorded, got %+v", check)
+		}
+	})
+
+	t.Run("configured verifier takes precedence over the environment", func(t *testing.T) {
+		t.Setenv("LFX_AUTH_TOKEN", "test-token")
+		t.Setenv("MAINTAINER_API_ENDPOINT", lfx.URL)
+		pv := NewValidator(cacheDir)
+		pv.SetHandleVerifier(newTestAllowList(t, "bob"))
+
+		if _, err := pv.verifyHandleWithExternalService("", "alice"); err == nil || !strings.Contains(err.Error(), "not in the allow-list") {
+			t.Errorf("expected the allow-list to reject alice, got: %v", err)
 		}
 	})
 }
@@ -362,10 +399,16 @@ func TestValidateMaintainersWithExclusion(t *testing.T) {
 		t.Fatalf("write failed: %v", err)
 	}
 
-	// Use stub endpoint so verification runs but does not call real API
 	t.Setenv("LFX_AUTH_TOKEN", "")
-	t.Setenv("MAINTAINER_API_ENDPOINT", "https://example.com/stub")
-	t.Setenv("MAINTAINER_API_STUB", "")
+	t.Setenv("MAINTAINER_API_ENDPOINT", "")
+
+	t.Run("no verifier configured", func(t *testing.T) {
+		if _, err := pv.ValidateMaintainersFileWithExclusion(path, true, nil); err != errNoHandleVerifier {
+			t.Errorf("expected errNoHandleVerifier, got %v", err)
+		}
+	})
+
+	pv.SetHandleVerifier(newTestAllowList(t, "alice", "bob", "carol"))
 
 	t.Run("no exclusions: all handles verified", func(t *testing.T) {
 		results, err := pv.ValidateMaintainersFileWithExclusion(path, true, nil)
@@ -470,7 +513,6 @@ func TestValidateMaintainerEntry(t *testing.T) {
 	// Ensure stub verification so we control outcomes
 	t.Setenv("LFX_AUTH_TOKEN", "")
 	t.Setenv("MAINTAINER_API_ENDPOINT", "")
-	t.Setenv("MAINTAINER_API_STUB", "")
 
 	t.Run("empty project_id", func(t *testing.T) {
 		entry := MaintainerEntry{
@@ -585,9 +627,8 @@ func TestValidateMaintainerEntry(t *testing.T) {
 	})
 
 	t.Run("verification with exclusion skips excluded handles", func(t *testing.T) {
-		t.Setenv("LFX_AUTH_TOKEN", "")
-		t.Setenv("MAINTAINER_API_ENDPOINT", "https://example.com/api")
-		t.Setenv("MAINTAINER_API_STUB", "")
+		pv := NewValidator(cacheDir)
+		pv.SetHandleVerifier(newTestAllowList(t, "alice", "bob", "carol"))
 
 		entry := MaintainerEntry{
 			ProjectID: "proj",
@@ -607,9 +648,8 @@ func TestValidateMaintainerEntry(t *testing.T) {
 	})
 
 	t.Run("verification failure marks result as failed", func(t *testing.T) {
-		t.Setenv("LFX_AUTH_TOKEN", "")
-		t.Setenv("MAINTAINER_API_ENDPOINT", "https://example.com/api")
-		t.Setenv("MAINTAINER_API_STUB", "fail")
+		pv := NewValidator(cacheDir)
+		pv.SetHandleVerifier(newTestAllowList(t))
 
 		entry := MaintainerEntry{
 			ProjectID: "proj",
@@ -619,7 +659,7 @@ func TestValidateMaintainerEntry(t *testing.T) {
 		}
 		result := pv.validateMaintainerEntry(entry, true, nil)
 		if result.VerificationPassed {
-			t.Error("expected verification to fail with stubbed failure")
+			t.Error("expected verification to fail for a handle not in the allow-list")
 		}
 		if result.Valid {
 			t.Error("expected invalid result when verification fails")
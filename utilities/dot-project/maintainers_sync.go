@@ -0,0 +1,409 @@
+package projects
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// TeamDrift compares a team declared in maintainers.yaml with the GitHub team
+// of the same name in the entry's org
+type TeamDrift struct {
+	Team       string   `json:"team"`
+	Slug       string   `json:"slug"`              // GitHub team slug
+	TeamExists bool     `json:"team_exists"`       // The org has the team
+	Missing    []string `json:"missing,omitempty"` // Declared, but not on the GitHub team
+	Extra      []string `json:"extra,omitempty"`   // On the GitHub team, but not declared
+	Pending    []string `json:"pending,omitempty"` // Declared, with an open invitation to the team
+	InSync     int      `json:"in_sync"`           // Members on both sides
+	Error      string   `json:"error,omitempty"`   // Team could not be read
+}
+
+// Drifted reports whether the GitHub team differs from the declared one
+func (d TeamDrift) Drifted() bool {
+	return d.Error == "" && (!d.TeamExists || len(d.Missing) > 0 || len(d.Extra) > 0)
+}
+
+// SyncAction is a change that makes a GitHub team match maintainers.yaml
+type SyncAction struct {
+	Org    string `json:"org"`
+	Team   string `json:"team"`
+	Slug   string `json:"slug"`
+	Action string `json:"action"`           // "create_team", "add_member" or "remove_member"
+	Member string `json:"member,omitempty"` // Handle added or removed
+	Status string `json:"status"`           // "planned", "done", "invited" or "failed"
+	Error  string `json:"error,omitempty"`
+}
+
+// TeamSyncReport is the drift of the teams of one maintainers entry, and the
+// actions planned or taken to reconcile it
+type TeamSyncReport struct {
+	ProjectID string       `json:"project_id"`
+	Org       string       `json:"org"`
+	Teams     []TeamDrift  `json:"teams"`
+	Actions   []SyncAction `json:"actions,omitempty"`
+	Error     string       `json:"error,omitempty"` // Entry could not be compared
+}
+
+// Drifted reports whether any team of the entry drifted
+func (r TeamSyncReport) Drifted() bool {
+	for _, team := range r.Teams {
+		if team.Drifted() {
+			return true
+		}
+	}
+	return false
+}
+
+// TeamSyncer compares the teams of maintainers entries with GitHub org teams
+// and reconciles GitHub to match. maintainers.yaml is the source of truth:
+// reconciling creates missing teams, adds missing members (GitHub invites
+// handles that are not yet org members) and removes members that are not
+// declared.
+type TeamSyncer struct {
+	client  *http.Client
+	baseURL string
+	token   string
+}
+
+// NewTeamSyncer creates a team syncer. baseURL overrides the GitHub API URL
+// (use "" for default).
+func NewTeamSyncer(client *http.Client, baseURL, token string) *TeamSyncer {
+	if baseURL == "" {
+		baseURL = defaultGitHubAPIURL
+	}
+	return &TeamSyncer{client: client, baseURL: strings.TrimSuffix(baseURL, "/"), token: token}
+}
+
+// Drift compares each team of a maintainers entry with its GitHub team. Use
+// DriftEntries for several entries, so teams they share are detected.
+func (s *TeamSyncer) Drift(entry MaintainerEntry) TeamSyncReport {
+	return s.drift(entry, teamDeclarations([]MaintainerEntry{entry}))
+}
+
+// DriftEntries compares the teams of maintainers entries with their GitHub
+// teams. A GitHub team (org and slug) declared by more than one team, e.g.
+// project-maintainers in two entries of the same org, is reported as an error
+// instead: reconciling it for one entry would undo the other, so it is never
+// planned. projectID, when set, limits the reports to that entry; shared
+// teams are still detected across all entries.
+func (s *TeamSyncer) DriftEntries(entries []MaintainerEntry, projectID string) []TeamSyncReport {
+	declarations := teamDeclarations(entries)
+	var reports []TeamSyncReport
+	for _, entry := range entries {
+		if projectID == "" || entry.ProjectID == projectID {
+			reports = append(reports, s.drift(entry, declarations))
+		}
+	}
+	return reports
+}
+
+// drift compares the teams of an entry, given the declarations of every
+// GitHub team
+func (s *TeamSyncer) drift(entry MaintainerEntry, declarations map[string][]string) TeamSyncReport {
+	report := TeamSyncReport{ProjectID: entry.ProjectID, Org: entry.Org}
+	if entry.Org == "" {
+		report.Error = "maintainers entry has no org"
+		return report
+	}
+	for _, team := range entry.Teams {
+		slug := teamSlug(team.Name)
+		if declared := declarations[teamKey(entry.Org, slug)]; len(declared) > 1 {
+			report.Teams = append(report.Teams, TeamDrift{
+				Team:  team.Name,
+				Slug:  slug,
+				Error: fmt.Sprintf("team %s/%s is declared more than once (%s); give the teams distinct names", entry.Org, slug, strings.Join(declared, ", ")),
+			})
+			continue
+		}
+		report.Teams = append(report.Teams, s.teamDrift(entry.Org, team))
+	}
+	return report
+}
+
+// teamDeclarations lists, for each GitHub team, the "project_id team name"
+// of the declarations that map to it
+func teamDeclarations(entries []MaintainerEntry) map[string][]string {
+	declarations := make(map[string][]string)
+	for _, entry := range entries {
+		if entry.Org == "" {
+			continue
+		}
+		for _, team := range entry.Teams {
+			key := teamKey(entry.Org, teamSlug(team.Name))
+			declarations[key] = append(declarations[key], fmt.Sprintf("%s %q", entry.ProjectID, team.Name))
+		}
+	}
+	return declarations
+}
+
+// teamKey identifies a GitHub team; orgs and slugs are case-insensitive
+func teamKey(org, slug string) string {
+	return strings.ToLower(org) + "/" + slug
+}
+
+// teamDrift compares one declared team with its GitHub team
+func (s *TeamSyncer) teamDrift(org string, team Team) TeamDrift {
+	drift := TeamDrift{Team: team.Name, Slug: teamSlug(team.Name)}
+	declared, _ := normalizeHandles(team.Members)
+
+	teamsURL := fmt.Sprintf("%s/orgs/%s/teams", s.baseURL, url.PathEscape(org))
+	teamURL := teamsURL + "/" + drift.Slug
+	if err := githubJSON(s.client, http.MethodGet, teamURL, s.token, nil, nil); err != nil {
+		if isAPINotFound(err) {
+			drift.Missing = declared
+			return drift
+		}
+		drift.Error = err.Error()
+		return drift
+	}
+	drift.TeamExists = true
+
+	members, err := s.logins(teamURL + "/members")
+	if err != nil {
+		drift.Error = err.Error()
+		return drift
+	}
+	invited, err := s.logins(teamURL + "/invitations")
+	if err != nil {
+		drift.Error = err.Error()
+		return drift
+	}
+	// The members list includes members of child teams, who are managed
+	// through those teams
+	inherited, err := s.childTeamLogins(teamsURL, drift.Slug)
+	if err != nil {
+		drift.Error = err.Error()
+		return drift
+	}
+
+	isDeclared := make(map[string]bool)
+	for _, handle := range declared {
+		key := strings.ToLower(handle)
+		isDeclared[key] = true
+		switch {
+		case members[key] != "":
+			drift.InSync++
+		case invited[key] != "":
+			drift.Pending = append(drift.Pending, handle)
+		default:
+			drift.Missing = append(drift.Missing, handle)
+		}
+	}
+	for key, login := range members {
+		if !isDeclared[key] && inherited[key] == "" {
+			drift.Extra = append(drift.Extra, login)
+		}
+	}
+	sort.Slice(drift.Extra, func(i, j int) bool { return strings.ToLower(drift.Extra[i]) < strings.ToLower(drift.Extra[j]) })
+	return drift
+}
+
+// childTeamLogins returns the members of a team's child teams, keyed by
+// lowercase login. A child team's members include its own children's.
+func (s *TeamSyncer) childTeamLogins(teamsURL, slug string) (map[string]string, error) {
+	logins := make(map[string]string)
+	for page := 1; ; page++ {
+		var teams []struct {
+			Slug string `json:"slug"`
+		}
+		if err := githubJSON(s.client, http.MethodGet, fmt.Sprintf("%s/%s/teams?per_page=100&page=%d", teamsURL, slug, page), s.token, nil, &teams); err != nil {
+			return nil, err
+		}
+		for _, team := range teams {
+			members, err := s.logins(teamsURL + "/" + team.Slug + "/members")
+			if err != nil {
+				return nil, err
+			}
+			for key, login := range members {
+				logins[key] = login
+			}
+		}
+		if len(teams) < 100 {
+			return logins, nil
+		}
+	}
+}
+
+// logins pages through a list of GitHub users, keyed by lowercase login
+func (s *TeamSyncer) logins(endpoint string) (map[string]string, error) {
+	logins := make(map[string]string)
+	for page := 1; ; page++ {
+		var users []struct {
+			Login string `json:"login"`
+		}
+		if err := githubJSON(s.client, http.MethodGet, fmt.Sprintf("%s?per_page=100&page=%d", endpoint, page), s.token, nil, &users); err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			logins[strings.ToLower(user.Login)] = user.Login
+		}
+		if len(users) < 100 {
+			return logins, nil
+		}
+	}
+}
+
+// Plan lists the actions that make GitHub match the report's entry. Teams
+// that could not be read are left alone.
+func (s *TeamSyncer) Plan(report *TeamSyncReport) []SyncAction {
+	var actions []SyncAction
+	for _, drift := range report.Teams {
+		if drift.Error != "" {
+			continue
+		}
+		action := SyncAction{Org: report.Org, Team: drift.Team, Slug: drift.Slug, Status: "planned"}
+		if !drift.TeamExists {
+			create := action
+			create.Action = "create_team"
+			actions = append(actions, create)
+		}
+		for _, handle := range drift.Missing {
+			add := action
+			add.Action = "add_member"
+			add.Member = handle
+			actions = append(actions, add)
+		}
+		for _, login := range drift.Extra {
+			remove := action
+			remove.Action = "remove_member"
+			remove.Member = login
+			actions = append(actions, remove)
+		}
+	}
+	report.Actions = actions
+	return actions
+}
+
+// Apply carries out the planned actions of a report, recording the outcome
+// of each. Members of a team that could not be created are not added.
+func (s *TeamSyncer) Apply(report *TeamSyncReport) {
+	failedTeams := make(map[string]bool)
+	for i := range report.Actions {
+		action := &report.Actions[i]
+		if failedTeams[action.Slug] {
+			action.Status = "failed"
+			action.Error = "team was not created"
+			continue
+		}
+		state, err := s.apply(*action)
+		if err != nil {
+			action.Status = "failed"
+			action.Error = err.Error()
+			if action.Action == "create_team" {
+				failedTeams[action.Slug] = true
+			}
+			continue
+		}
+		action.Status = "done"
+		if state == "pending" {
+			action.Status = "invited"
+		}
+	}
+}
+
+// apply carries out one action and returns the membership state of an added
+// member
+func (s *TeamSyncer) apply(action SyncAction) (string, error) {
+	orgURL := fmt.Sprintf("%s/orgs/%s", s.baseURL, url.PathEscape(action.Org))
+	switch action.Action {
+	case "create_team":
+		team := map[string]string{"name": action.Team, "privacy": "closed"}
+		return "", githubJSON(s.client, http.MethodPost, orgURL+"/teams", s.token, team, nil)
+	case "add_member":
+		var membership struct {
+			State string `json:"state"`
+		}
+		endpoint := fmt.Sprintf("%s/teams/%s/memberships/%s", orgURL, action.Slug, url.PathEscape(action.Member))
+		err := githubJSON(s.client, http.MethodPut, endpoint, s.token, map[string]string{"role": "member"}, &membership)
+		return membership.State, err
+	case "remove_member":
+		endpoint := fmt.Sprintf("%s/teams/%s/memberships/%s", orgURL, action.Slug, url.PathEscape(action.Member))
+		return "", githubJSON(s.client, http.MethodDelete, endpoint, s.token, nil, nil)
+	default:
+		return "", fmt.Errorf("unknown sync action %q", action.Action)
+	}
+}
+
+var teamSlugInvalid = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// teamSlug derives the GitHub slug of a team name
+func teamSlug(name string) string {
+	return strings.Trim(teamSlugInvalid.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// FormatTeamSyncReports formats team sync reports as human-readable text
+func FormatTeamSyncReports(reports []TeamSyncReport) string {
+	var b strings.Builder
+	b.WriteString("Maintainers Team Sync Report\n")
+	b.WriteString("============================\n")
+
+	checked, drifted, failed := 0, 0, 0
+	var actions []SyncAction
+	for _, report := range reports {
+		b.WriteString(fmt.Sprintf("\n%s (org %s)\n", report.ProjectID, report.Org))
+		if report.Error != "" {
+			failed++
+			b.WriteString(fmt.Sprintf("  ERROR: %s\n", report.Error))
+			continue
+		}
+		for _, drift := range report.Teams {
+			checked++
+			switch {
+			case drift.Error != "":
+				failed++
+				b.WriteString(fmt.Sprintf("  %s: ERROR: %s\n", drift.Team, drift.Error))
+				continue
+			case !drift.TeamExists:
+				drifted++
+				b.WriteString(fmt.Sprintf("  %s: team %s does not exist in the org\n", drift.Team, drift.Slug))
+			case drift.Drifted():
+				drifted++
+				b.WriteString(fmt.Sprintf("  %s: %d missing on GitHub, %d not in maintainers.yaml\n", drift.Team, len(drift.Missing), len(drift.Extra)))
+			default:
+				b.WriteString(fmt.Sprintf("  %s: in sync (%d members)\n", drift.Team, drift.InSync))
+			}
+			for _, handle := range drift.Missing {
+				b.WriteString(fmt.Sprintf("    + %s (in maintainers.yaml, not on GitHub)\n", handle))
+			}
+			for _, login := range drift.Extra {
+				b.WriteString(fmt.Sprintf("    - %s (on GitHub, not in maintainers.yaml)\n", login))
+			}
+			for _, handle := range drift.Pending {
+				b.WriteString(fmt.Sprintf("    ~ %s (invitation pending)\n", handle))
+			}
+		}
+		actions = append(actions, report.Actions...)
+	}
+
+	if len(actions) > 0 {
+		b.WriteString("\nActions:\n")
+		for _, action := range actions {
+			line := fmt.Sprintf("  [%s] %s", action.Status, describeSyncAction(action))
+			if action.Error != "" {
+				line += ": " + action.Error
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	b.WriteString(fmt.Sprintf("\nSummary: %d teams checked, %d drifted, %d errors\n", checked, drifted, failed))
+	return b.String()
+}
+
+// describeSyncAction renders an action as a sentence
+func describeSyncAction(action SyncAction) string {
+	team := action.Org + "/" + action.Slug
+	switch action.Action {
+	case "create_team":
+		return "create team " + team
+	case "add_member":
+		return fmt.Sprintf("add %s to %s", action.Member, team)
+	case "remove_member":
+		return fmt.Sprintf("remove %s from %s", action.Member, team)
+	}
+	return action.Action + " " + team
+}
@@ -0,0 +1,305 @@
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeGitHubTeams serves the GitHub org team endpoints used by TeamSyncer,
+// keeping teams and invitations in memory
+type fakeGitHubTeams struct {
+	mu       sync.Mutex
+	teams    map[string][]string // Direct members by "org/slug"
+	children map[string][]string // Child team slugs by "org/slug"
+	invites  map[string][]string // Open invitations by "org/slug"
+	orgUsers map[string]bool     // Logins that are org members; others are invited
+	failing  map[string]bool     // "org/slug" answering HTTP 500
+	writes   []string
+}
+
+func newFakeGitHubTeams(t *testing.T) (*fakeGitHubTeams, *httptest.Server) {
+	t.Helper()
+	f := &fakeGitHubTeams{teams: map[string][]string{}, children: map[string][]string{}, invites: map[string][]string{}, orgUsers: map[string]bool{}, failing: map[string]bool{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if r.Method != http.MethodGet {
+			f.writes = append(f.writes, r.Method+" "+r.URL.Path)
+		}
+		if len(parts) < 3 || parts[0] != "orgs" || parts[2] != "teams" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		org := parts[1]
+		if len(parts) == 3 && r.Method == http.MethodPost {
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			f.teams[org+"/"+teamSlug(body["name"])] = []string{}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+			return
+		}
+		key := org + "/" + parts[3]
+		if f.failing[key] {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		members, ok := f.teams[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch {
+		case len(parts) == 4:
+			w.Write([]byte(`{}`))
+		case len(parts) == 5 && parts[4] == "teams":
+			var teams []string
+			for _, child := range f.children[key] {
+				teams = append(teams, fmt.Sprintf(`{"slug":%q}`, child))
+			}
+			fmt.Fprintf(w, "[%s]", strings.Join(teams, ","))
+		case len(parts) == 5 && (parts[4] == "members" || parts[4] == "invitations"):
+			// Like GitHub, members include those of child teams
+			logins := f.allMembers(key)
+			if parts[4] == "invitations" {
+				logins = f.invites[key]
+			}
+			var users []string
+			for _, login := range logins {
+				users = append(users, fmt.Sprintf(`{"login":%q}`, login))
+			}
+			fmt.Fprintf(w, "[%s]", strings.Join(users, ","))
+		case len(parts) == 6 && parts[4] == "memberships" && r.Method == http.MethodPut:
+			login := parts[5]
+			if f.orgUsers[strings.ToLower(login)] {
+				f.teams[key] = append(members, login)
+				w.Write([]byte(`{"state":"active"}`))
+			} else {
+				f.invites[key] = append(f.invites[key], login)
+				w.Write([]byte(`{"state":"pending"}`))
+			}
+		case len(parts) == 6 && parts[4] == "memberships" && r.Method == http.MethodDelete:
+			var kept []string
+			for _, m := range members {
+				if !strings.EqualFold(m, parts[5]) {
+					kept = append(kept, m)
+				}
+			}
+			f.teams[key] = kept
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return f, server
+}
+
+// allMembers returns the members of a team and of its child teams; the
+// caller holds the lock
+func (f *fakeGitHubTeams) allMembers(key string) []string {
+	members := append([]string(nil), f.teams[key]...)
+	org := key[:strings.Index(key, "/")]
+	for _, child := range f.children[key] {
+		members = append(members, f.allMembers(org+"/"+child)...)
+	}
+	return members
+}
+
+func TestTeamSync(t *testing.T) {
+	fake, server := newFakeGitHubTeams(t)
+	fake.teams["cncf/project-maintainers"] = []string{"Alice", "mallory"}
+	fake.teams["cncf/reviewers"] = []string{"dave"}
+	fake.invites["cncf/reviewers"] = []string{"erin"}
+	fake.orgUsers = map[string]bool{"alice": true, "bob": true, "carol": true}
+
+	entry := MaintainerEntry{
+		ProjectID: "test-project",
+		Org:       "cncf",
+		Teams: []Team{
+			{Name: "project-maintainers", Members: []string{"@alice", "bob"}},
+			{Name: "reviewers", Members: []string{"dave", "erin"}},
+			{Name: "Security Team", Members: []string{"carol", "frank"}},
+		},
+	}
+	syncer := NewTeamSyncer(server.Client(), server.URL, "")
+
+	report := syncer.Drift(entry)
+	if len(report.Teams) != 3 {
+		t.Fatalf("expected 3 teams, got %+v", report.Teams)
+	}
+	maintainers, reviewers, security := report.Teams[0], report.Teams[1], report.Teams[2]
+	if strings.Join(maintainers.Missing, ",") != "bob" || strings.Join(maintainers.Extra, ",") != "mallory" || maintainers.InSync != 1 {
+		t.Errorf("unexpected project-maintainers drift: %+v", maintainers)
+	}
+	if reviewers.Drifted() || strings.Join(reviewers.Pending, ",") != "erin" {
+		t.Errorf("an invited member should not count as drift: %+v", reviewers)
+	}
+	if security.TeamExists || security.Slug != "security-team" || strings.Join(security.Missing, ",") != "carol,frank" {
+		t.Errorf("unexpected drift for a missing team: %+v", security)
+	}
+	if !report.Drifted() {
+		t.Error("expected the entry to have drifted")
+	}
+
+	actions := syncer.Plan(&report)
+	var planned []string
+	for _, action := range actions {
+		planned = append(planned, describeSyncAction(action))
+		if action.Status != "planned" {
+			t.Errorf("expected planned actions, got %+v", action)
+		}
+	}
+	want := []string{
+		"add bob to cncf/project-maintainers",
+		"remove mallory from cncf/project-maintainers",
+		"create team cncf/security-team",
+		"add carol to cncf/security-team",
+		"add frank to cncf/security-team",
+	}
+	if strings.Join(planned, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected plan:\n%s", strings.Join(planned, "\n"))
+	}
+	if len(fake.writes) != 0 {
+		t.Fatalf("planning must not change GitHub, got %v", fake.writes)
+	}
+
+	output := FormatTeamSyncReports([]TeamSyncReport{report})
+	for _, s := range []string{
+		"project-maintainers: 1 missing on GitHub, 1 not in maintainers.yaml",
+		"    - mallory (on GitHub, not in maintainers.yaml)",
+		"reviewers: in sync (1 members)",
+		"    ~ erin (invitation pending)",
+		"Security Team: team security-team does not exist in the org",
+		"[planned] create team cncf/security-team",
+		"Summary: 3 teams checked, 2 drifted, 0 errors",
+	} {
+		if !strings.Contains(output, s) {
+			t.Errorf("expected %q in output:\n%s", s, output)
+		}
+	}
+
+	syncer.Apply(&report)
+	statuses := make(map[string]string)
+	for _, action := range report.Actions {
+		statuses[describeSyncAction(action)] = action.Status
+	}
+	if statuses["add bob to cncf/project-maintainers"] != "done" || statuses["add frank to cncf/security-team"] != "invited" {
+		t.Errorf("unexpected action outcomes: %v", statuses)
+	}
+
+	after := syncer.Drift(entry)
+	if after.Drifted() {
+		t.Errorf("expected no drift after applying the plan, got %+v", after.Teams)
+	}
+	if plan := syncer.Plan(&after); len(plan) != 0 {
+		t.Errorf("expected an empty plan after applying, got %+v", plan)
+	}
+}
+
+func TestTeamSyncErrors(t *testing.T) {
+	fake, server := newFakeGitHubTeams(t)
+	fake.teams["cncf/broken"] = []string{"alice"}
+	fake.failing["cncf/broken"] = true
+	syncer := NewTeamSyncer(server.Client(), server.URL, "")
+
+	report := syncer.Drift(MaintainerEntry{ProjectID: "p", Org: "cncf", Teams: []Team{{Name: "broken", Members: []string{"bob"}}}})
+	if report.Teams[0].Error == "" || report.Drifted() {
+		t.Errorf("expected an unreadable team to be an error, not drift: %+v", report.Teams[0])
+	}
+	if plan := syncer.Plan(&report); len(plan) != 0 {
+		t.Errorf("expected unreadable teams to be left alone, got %+v", plan)
+	}
+
+	noOrg := syncer.Drift(MaintainerEntry{ProjectID: "p", Teams: []Team{{Name: "m", Members: []string{"alice"}}}})
+	if noOrg.Error == "" {
+		t.Error("expected an error for an entry without org")
+	}
+}
+
+func TestTeamSyncSharedTeams(t *testing.T) {
+	fake, server := newFakeGitHubTeams(t)
+	fake.teams["cncf/project-maintainers"] = []string{"alice"}
+	fake.teams["cncf/alpha-reviewers"] = []string{"carol"}
+	syncer := NewTeamSyncer(server.Client(), server.URL, "")
+
+	entries := []MaintainerEntry{
+		{ProjectID: "alpha", Org: "cncf", Teams: []Team{
+			{Name: "project-maintainers", Members: []string{"alice"}},
+			{Name: "alpha-reviewers", Members: []string{"carol", "dave"}},
+		}},
+		{ProjectID: "beta", Org: "CNCF", Teams: []Team{{Name: "Project Maintainers", Members: []string{"bob"}}}},
+		{ProjectID: "gamma", Org: "other", Teams: []Team{{Name: "project-maintainers", Members: []string{"erin"}}}},
+	}
+
+	// Shared teams are detected even when only one entry is synced
+	reports := syncer.DriftEntries(entries, "alpha")
+	if len(reports) != 1 || reports[0].ProjectID != "alpha" {
+		t.Fatalf("expected only alpha's report, got %+v", reports)
+	}
+	shared, own := reports[0].Teams[0], reports[0].Teams[1]
+	if shared.Error == "" || !strings.Contains(shared.Error, `beta "Project Maintainers"`) {
+		t.Errorf("expected the shared team to be refused, got %+v", shared)
+	}
+	if own.Error != "" || strings.Join(own.Missing, ",") != "dave" {
+		t.Errorf("a team of one entry should still be compared, got %+v", own)
+	}
+
+	syncer.Plan(&reports[0])
+	syncer.Apply(&reports[0])
+	for _, action := range reports[0].Actions {
+		if action.Slug == "project-maintainers" {
+			t.Errorf("a shared team must not be reconciled, got %+v", action)
+		}
+	}
+	if got := strings.Join(fake.teams["cncf/project-maintainers"], ","); got != "alice" {
+		t.Errorf("shared team changed to %s", got)
+	}
+
+	// The same team name in another org is a different team
+	all := syncer.DriftEntries(entries, "")
+	if len(all) != 3 || all[2].Teams[0].Error != "" || all[2].Teams[0].TeamExists {
+		t.Errorf("expected gamma's team to be compared on its own, got %+v", all)
+	}
+}
+
+func TestTeamSlug(t *testing.T) {
+	for name, want := range map[string]string{
+		"project-maintainers": "project-maintainers",
+		"Security Team":       "security-team",
+		"sig_release.leads":   "sig_release-leads",
+		" Docs & Website ":    "docs-website",
+	} {
+		if got := teamSlug(name); got != want {
+			t.Errorf("teamSlug(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestTeamSyncChildTeams(t *testing.T) {
+	fake, server := newFakeGitHubTeams(t)
+	fake.teams["cncf/project-maintainers"] = []string{"alice", "mallory"}
+	fake.teams["cncf/project-approvers"] = []string{"bob"}
+	fake.teams["cncf/project-docs"] = []string{"carol"}
+	fake.children["cncf/project-maintainers"] = []string{"project-approvers"}
+	fake.children["cncf/project-approvers"] = []string{"project-docs"}
+
+	entry := MaintainerEntry{ProjectID: "test-project", Org: "cncf", Teams: []Team{{Name: "project-maintainers", Members: []string{"alice"}}}}
+	syncer := NewTeamSyncer(server.Client(), server.URL, "")
+	report := syncer.Drift(entry)
+	drift := report.Teams[0]
+	if drift.Error != "" || strings.Join(drift.Extra, ",") != "mallory" || drift.InSync != 1 {
+		t.Errorf("members of child teams should not be extra, got %+v", drift)
+	}
+	for _, action := range syncer.Plan(&report) {
+		if action.Action == "remove_member" && action.Member != "mallory" {
+			t.Errorf("planned to remove child team member %s", action.Member)
+		}
+	}
+}
@@ -0,0 +1,359 @@
+package projects
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultLFXAPIURL is the LFX API gateway used to look up GitHub handles
+const defaultLFXAPIURL = "https://api-gw.platform.linuxfoundation.org"
+
+// HandleRequest asks a verifier about one maintainer handle
+type HandleRequest struct {
+	Handle   string
+	Org      string       // GitHub org of the maintainers entry
+	Previous *HandleCheck // Last known answer for the handle, e.g. from an expired cache entry
+}
+
+// HandleCheck is a verifier's answer about one maintainer handle
+type HandleCheck struct {
+	Handle    string    `json:"handle" yaml:"handle"`
+	Verified  bool      `json:"verified" yaml:"verified"`
+	Source    string    `json:"source" yaml:"source"`                             // Verifier that answered
+	UserID    int64     `json:"user_id,omitempty" yaml:"user_id,omitempty"`       // GitHub account ID, kept to follow renames
+	Login     string    `json:"login,omitempty" yaml:"login,omitempty"`           // Current login of the account, when it is no longer Handle
+	OrgMember *bool     `json:"org_member,omitempty" yaml:"org_member,omitempty"` // Membership of the entry's org; nil when not checked
+	Message   string    `json:"message,omitempty" yaml:"message,omitempty"`
+	CheckedAt time.Time `json:"checked_at" yaml:"checked_at"`
+}
+
+// HandleVerifier checks that maintainer handles name real accounts. Verify
+// returns an error when it could not reach an answer; a handle that was
+// checked and failed is a HandleCheck with Verified false.
+type HandleVerifier interface {
+	Name() string
+	Verify(req HandleRequest) (HandleCheck, error)
+}
+
+// SetHandleVerifier sets the verifier used by maintainer validation. Without
+// one, handles are verified against LFX when LFX_AUTH_TOKEN is set, and not
+// verified otherwise.
+func (pv *ProjectValidator) SetHandleVerifier(v HandleVerifier) {
+	pv.verifier = v
+}
+
+// handleVerifier returns the configured verifier, or the one the
+// environment selects
+func (pv *ProjectValidator) handleVerifier() HandleVerifier {
+	if pv.verifier != nil {
+		return pv.verifier
+	}
+	if token := os.Getenv("LFX_AUTH_TOKEN"); token != "" {
+		return NewLFXVerifier(&http.Client{Timeout: 30 * time.Second}, os.Getenv("MAINTAINER_API_ENDPOINT"), token)
+	}
+	return nil
+}
+
+// ForgeVerifier checks handles against a forge's API: the user must exist
+// and be a user rather than an organization. With a previous answer carrying
+// the account ID, renamed accounts are followed and reported under their new
+// login. Membership of the entry's org is recorded, and fails the handle when
+// RequireOrgMembership is set.
+type ForgeVerifier struct {
+	RequireOrgMembership bool
+	forge                Forge
+}
+
+// NewForgeVerifier creates a verifier of the handles of a forge
+func NewForgeVerifier(forge Forge) *ForgeVerifier {
+	return &ForgeVerifier{forge: forge}
+}
+
+// NewGitHubVerifier creates a GitHub verifier. baseURL overrides the GitHub
+// API URL (use "" for default).
+func NewGitHubVerifier(client *http.Client, baseURL, token string) *ForgeVerifier {
+	return NewForgeVerifier(NewGitHubForge(client, baseURL, token))
+}
+
+// Name implements HandleVerifier
+func (v *ForgeVerifier) Name() string { return v.forge.Name() }
+
+// cacheScope implements cacheScoped: whether membership was required changes
+// the answer for handles outside the org
+func (v *ForgeVerifier) cacheScope() string {
+	if v.RequireOrgMembership {
+		return "org-member"
+	}
+	return ""
+}
+
+// Verify implements HandleVerifier
+func (v *ForgeVerifier) Verify(req HandleRequest) (HandleCheck, error) {
+	check := HandleCheck{Handle: req.Handle, Source: v.Name(), CheckedAt: time.Now().UTC()}
+	title := v.forge.Title()
+	var knownID int64
+	if req.Previous != nil {
+		knownID = req.Previous.UserID
+	}
+
+	user, found, err := v.forge.UserByLogin(req.Handle)
+	if err != nil {
+		return check, err
+	}
+	if !found || (knownID != 0 && user.ID != knownID) {
+		if knownID == 0 {
+			check.Message = fmt.Sprintf("no %s user %s", title, req.Handle)
+			return check, nil
+		}
+		// The account behind the handle was renamed (and the handle possibly
+		// taken by someone else); find it by ID
+		renamed, found, err := v.forge.UserByID(knownID)
+		if err != nil {
+			return check, err
+		}
+		check.UserID = knownID
+		switch {
+		case !found:
+			check.Message = fmt.Sprintf("no %s user %s; the account it named (ID %d) no longer exists", title, req.Handle, knownID)
+		case user.ID != 0:
+			check.Login = renamed.Login
+			check.Message = fmt.Sprintf("%s user %s was renamed to %s, and %s now names a different account", title, req.Handle, renamed.Login, req.Handle)
+		default:
+			check.Login = renamed.Login
+			check.Message = fmt.Sprintf("%s user %s was renamed to %s", title, req.Handle, renamed.Login)
+		}
+		return check, nil
+	}
+
+	check.UserID = user.ID
+	if !strings.EqualFold(user.Login, req.Handle) {
+		check.Login = user.Login
+	}
+	if user.Organization {
+		check.Message = fmt.Sprintf("%s is a %s organization, not a user", req.Handle, title)
+		return check, nil
+	}
+
+	if req.Org != "" {
+		member, err := v.forge.IsMember(req.Org, user)
+		if err != nil {
+			return check, err
+		}
+		check.OrgMember = &member
+		if !member {
+			check.Message = fmt.Sprintf("%s is not a public member of %s org %s", req.Handle, title, req.Org)
+			if v.RequireOrgMembership {
+				return check, nil
+			}
+		}
+	}
+	check.Verified = true
+	return check, nil
+}
+
+// LFXVerifier checks that handles belong to a Linux Foundation (LFX) account
+type LFXVerifier struct {
+	client  *http.Client
+	baseURL string
+	token   string
+}
+
+// NewLFXVerifier creates an LFX verifier. baseURL overrides the LFX API
+// gateway URL (use "" for default).
+func NewLFXVerifier(client *http.Client, baseURL, token string) *LFXVerifier {
+	if baseURL == "" {
+		baseURL = defaultLFXAPIURL
+	}
+	return &LFXVerifier{client: client, baseURL: strings.TrimSuffix(baseURL, "/"), token: token}
+}
+
+// Name implements HandleVerifier
+func (v *LFXVerifier) Name() string { return "lfx" }
+
+// Verify implements HandleVerifier
+func (v *LFXVerifier) Verify(req HandleRequest) (HandleCheck, error) {
+	check := HandleCheck{Handle: req.Handle, Source: v.Name(), CheckedAt: time.Now().UTC()}
+	users, err := v.search(req.Handle)
+	if err != nil {
+		return check, err
+	}
+	if len(users) == 0 {
+		check.Message = fmt.Sprintf("handle %s not found in LFX", req.Handle)
+		return check, nil
+	}
+	check.Verified = true
+	return check, nil
+}
+
+// lfxUser is the part of an LFX user the tools read
+type lfxUser struct {
+	Emails []struct {
+		EmailAddress string `json:"EmailAddress"`
+		IsPrimary    bool   `json:"IsPrimary"`
+	} `json:"Emails"`
+}
+
+// search looks up the LFX users linked to a GitHub handle
+func (v *LFXVerifier) search(handle string) ([]lfxUser, error) {
+	if v.token == "" {
+		return nil, fmt.Errorf("LFX_AUTH_TOKEN is not set")
+	}
+
+	req, err := http.NewRequest(http.MethodGet, v.baseURL+"/user-service/v1/users/search", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = url.Values{"githubID": {handle}}.Encode()
+	req.Header.Set("Authorization", "Bearer "+v.token)
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("LFX request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("LFX API returned HTTP %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Data []lfxUser `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("parsing LFX response: %w", err)
+	}
+	return result.Data, nil
+}
+
+// AllowListVerifier checks handles against a static list, for offline use
+type AllowListVerifier struct {
+	path    string
+	handles map[string]bool
+}
+
+// LoadAllowList reads an allow-list file: one handle per line, with an
+// optional leading "@"; blank lines and "#" comments are ignored
+func LoadAllowList(path string) (*AllowListVerifier, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read allow-list: %w", err)
+	}
+	defer f.Close()
+
+	v := &AllowListVerifier{path: path, handles: make(map[string]bool)}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		handle := strings.TrimPrefix(strings.TrimSpace(line), "@")
+		if handle != "" {
+			v.handles[strings.ToLower(handle)] = true
+		}
+	}
+	return v, scanner.Err()
+}
+
+// Name implements HandleVerifier
+func (v *AllowListVerifier) Name() string { return "allowlist" }
+
+// Verify implements HandleVerifier
+func (v *AllowListVerifier) Verify(req HandleRequest) (HandleCheck, error) {
+	check := HandleCheck{Handle: req.Handle, Source: v.Name(), CheckedAt: time.Now().UTC()}
+	if v.handles[strings.ToLower(req.Handle)] {
+		check.Verified = true
+	} else {
+		check.Message = fmt.Sprintf("%s is not in the allow-list %s", req.Handle, v.path)
+	}
+	return check, nil
+}
+
+// CachedVerifier remembers the verified answers of another verifier for a
+// TTL, keyed by handle, org (membership depends on the org) and the
+// verifier's settings. Failed checks are asked again on every run, so a fixed
+// handle or a new org member is picked up at once. Expired answers are passed
+// to the verifier as the previous answer, which lets the GitHub verifier
+// follow renames. With a path, the cache is kept in a JSON file across runs.
+type CachedVerifier struct {
+	inner   HandleVerifier
+	ttl     time.Duration
+	path    string
+	mu      sync.Mutex
+	entries map[string]HandleCheck
+}
+
+// NewCachedVerifier wraps a verifier with a cache. path "" keeps the cache in
+// memory; a missing file is an empty cache.
+func NewCachedVerifier(inner HandleVerifier, ttl time.Duration, path string) (*CachedVerifier, error) {
+	v := &CachedVerifier{inner: inner, ttl: ttl, path: path, entries: make(map[string]HandleCheck)}
+	if path == "" {
+		return v, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return v, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &v.entries); err != nil {
+		return nil, fmt.Errorf("failed to parse handle cache %s: %w", path, err)
+	}
+	return v, nil
+}
+
+// cacheScoped is implemented by verifiers whose answers depend on their
+// settings, so answers given under other settings are not reused
+type cacheScoped interface {
+	cacheScope() string
+}
+
+// Name implements HandleVerifier
+func (v *CachedVerifier) Name() string { return v.inner.Name() }
+
+// Verify implements HandleVerifier
+func (v *CachedVerifier) Verify(req HandleRequest) (HandleCheck, error) {
+	key := strings.ToLower(req.Handle) + "|" + strings.ToLower(req.Org)
+	if scoped, ok := v.inner.(cacheScoped); ok && scoped.cacheScope() != "" {
+		key += "|" + scoped.cacheScope()
+	}
+
+	v.mu.Lock()
+	cached, ok := v.entries[key]
+	v.mu.Unlock()
+	if ok && cached.Source == v.inner.Name() {
+		if time.Since(cached.CheckedAt) < v.ttl {
+			return cached, nil
+		}
+		req.Previous = &cached
+	}
+
+	check, err := v.inner.Verify(req)
+	if err != nil || !check.Verified {
+		return check, err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.entries[key] = check
+	return check, v.save()
+}
+
+// save writes the cache file; the caller holds the lock
+func (v *CachedVerifier) save() error {
+	if v.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(v.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(v.path, data, 0644)
+}
@@ -0,0 +1,234 @@
+package projects
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeGitHubUsers serves the GitHub user and org membership endpoints
+type fakeGitHubUsers struct {
+	mu       sync.Mutex
+	users    map[string]gitHubUser // By lowercase login
+	members  map[string]bool       // "org/login"
+	requests int
+}
+
+func newFakeGitHubUsers(t *testing.T) (*fakeGitHubUsers, *httptest.Server) {
+	t.Helper()
+	f := &fakeGitHubUsers{users: make(map[string]gitHubUser), members: make(map[string]bool)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.requests++
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		switch {
+		case len(parts) == 2 && parts[0] == "users":
+			if parts[1] == "broken" {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			if user, ok := f.users[strings.ToLower(parts[1])]; ok {
+				fmt.Fprintf(w, `{"login":%q,"id":%d,"type":%q}`, user.Login, user.ID, user.Type)
+				return
+			}
+		case len(parts) == 2 && parts[0] == "user":
+			for _, user := range f.users {
+				if fmt.Sprint(user.ID) == parts[1] {
+					fmt.Fprintf(w, `{"login":%q,"id":%d,"type":%q}`, user.Login, user.ID, user.Type)
+					return
+				}
+			}
+		case len(parts) == 4 && parts[0] == "orgs" && parts[2] == "members":
+			if f.members[parts[1]+"/"+parts[3]] {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+	return f, server
+}
+
+func (f *fakeGitHubUsers) add(login string, id int64, kind string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.users[strings.ToLower(login)] = gitHubUser{Login: login, ID: id, Type: kind}
+}
+
+func (f *fakeGitHubUsers) remove(login string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.users, strings.ToLower(login))
+}
+
+func TestGitHubVerifier(t *testing.T) {
+	fake, server := newFakeGitHubUsers(t)
+	fake.add("Alice", 1, "User")
+	fake.add("bob", 2, "User")
+	fake.add("cncf", 3, "Organization")
+	fake.add("alyce", 4, "User")
+	fake.members["cncf/Alice"] = true
+
+	verifier := NewGitHubVerifier(server.Client(), server.URL, "")
+
+	tests := []struct {
+		name     string
+		req      HandleRequest
+		verified bool
+		message  string
+		login    string
+	}{
+		{"existing user", HandleRequest{Handle: "alice"}, true, "", ""},
+		{"org member", HandleRequest{Handle: "alice", Org: "cncf"}, true, "", ""},
+		{"non-member is only noted", HandleRequest{Handle: "bob", Org: "cncf"}, true, "not a public member of GitHub org cncf", ""},
+		{"typo", HandleRequest{Handle: "alcie"}, false, "no GitHub user alcie", ""},
+		{"organization", HandleRequest{Handle: "cncf"}, false, "is a GitHub organization", ""},
+		{"renamed account", HandleRequest{Handle: "alicia", Previous: &HandleCheck{UserID: 1}}, false, "alicia was renamed to Alice", "Alice"},
+		{"handle taken by another account", HandleRequest{Handle: "alyce", Previous: &HandleCheck{UserID: 1}}, false, "now names a different account", "Alice"},
+		{"deleted account", HandleRequest{Handle: "gone", Previous: &HandleCheck{UserID: 99}}, false, "(ID 99) no longer exists", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check, err := verifier.Verify(tt.req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if check.Verified != tt.verified || !strings.Contains(check.Message, tt.message) || check.Login != tt.login {
+				t.Errorf("got %+v, want verified=%v message %q login %q", check, tt.verified, tt.message, tt.login)
+			}
+			if check.Source != "github" || check.CheckedAt.IsZero() {
+				t.Errorf("expected source and time to be set, got %+v", check)
+			}
+		})
+	}
+
+	t.Run("org membership recorded", func(t *testing.T) {
+		check, _ := verifier.Verify(HandleRequest{Handle: "alice", Org: "cncf"})
+		if check.OrgMember == nil || !*check.OrgMember || check.UserID != 1 {
+			t.Errorf("expected alice (ID 1) to be an org member, got %+v", check)
+		}
+	})
+
+	t.Run("membership required", func(t *testing.T) {
+		strict := NewGitHubVerifier(server.Client(), server.URL, "")
+		strict.RequireOrgMembership = true
+		check, err := strict.Verify(HandleRequest{Handle: "bob", Org: "cncf"})
+		if err != nil || check.Verified {
+			t.Errorf("expected bob to fail without org membership, got %+v, %v", check, err)
+		}
+	})
+
+	t.Run("API failure is inconclusive", func(t *testing.T) {
+		if _, err := verifier.Verify(HandleRequest{Handle: "broken"}); err == nil || !strings.Contains(err.Error(), "HTTP 502") {
+			t.Errorf("expected an HTTP 502 error, got %v", err)
+		}
+	})
+}
+
+func TestAllowListVerifier(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "maintainers-allowlist.txt")
+	writeFile(t, path, "# Reviewed handles\n@Alice\n  bob   # added 2025\n\n")
+	verifier, err := LoadAllowList(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for handle, want := range map[string]bool{"alice": true, "BOB": true, "carol": false, "#": false} {
+		check, err := verifier.Verify(HandleRequest{Handle: handle})
+		if err != nil || check.Verified != want {
+			t.Errorf("%s: got %+v, %v; want verified=%v", handle, check, err, want)
+		}
+	}
+
+	if _, err := LoadAllowList(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected an error for a missing allow-list")
+	}
+}
+
+func TestCachedVerifier(t *testing.T) {
+	fake, server := newFakeGitHubUsers(t)
+	fake.add("alice", 1, "User")
+	cacheFile := filepath.Join(t.TempDir(), "handles.json")
+
+	cached, err := NewCachedVerifier(NewGitHubVerifier(server.Client(), server.URL, ""), time.Hour, cacheFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if check, err := cached.Verify(HandleRequest{Handle: "Alice"}); err != nil || !check.Verified {
+			t.Fatalf("expected alice to verify, got %+v, %v", check, err)
+		}
+	}
+	if fake.requests != 1 {
+		t.Errorf("expected one lookup within the TTL, got %d", fake.requests)
+	}
+
+	t.Run("inconclusive results are not cached", func(t *testing.T) {
+		before := fake.requests
+		cached.Verify(HandleRequest{Handle: "broken"})
+		cached.Verify(HandleRequest{Handle: "broken"})
+		if fake.requests-before != 2 {
+			t.Errorf("expected errors to be retried, got %d lookups", fake.requests-before)
+		}
+	})
+
+	t.Run("failed checks are not cached", func(t *testing.T) {
+		before := fake.requests
+		cached.Verify(HandleRequest{Handle: "bobb"})
+		fake.add("bobb", 2, "User")
+		if check, err := cached.Verify(HandleRequest{Handle: "bobb"}); err != nil || !check.Verified {
+			t.Errorf("expected bobb to verify once the account exists, got %+v, %v", check, err)
+		}
+		if fake.requests-before != 2 {
+			t.Errorf("expected a failed check to be asked again, got %d lookups", fake.requests-before)
+		}
+	})
+
+	t.Run("answers are scoped to the org membership setting", func(t *testing.T) {
+		if check, err := cached.Verify(HandleRequest{Handle: "alice", Org: "test-org"}); err != nil || !check.Verified {
+			t.Fatalf("expected alice to verify without required membership, got %+v, %v", check, err)
+		}
+		strict := NewGitHubVerifier(server.Client(), server.URL, "")
+		strict.RequireOrgMembership = true
+		strictCache, err := NewCachedVerifier(strict, time.Hour, cacheFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if check, err := strictCache.Verify(HandleRequest{Handle: "alice", Org: "test-org"}); err != nil || check.Verified {
+			t.Errorf("an answer cached without required membership should not be reused, got %+v, %v", check, err)
+		}
+	})
+
+	t.Run("cache file survives runs", func(t *testing.T) {
+		reloaded, err := NewCachedVerifier(NewGitHubVerifier(server.Client(), server.URL, ""), time.Hour, cacheFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		before := fake.requests
+		if check, err := reloaded.Verify(HandleRequest{Handle: "alice"}); err != nil || check.UserID != 1 {
+			t.Errorf("expected the cached check, got %+v, %v", check, err)
+		}
+		if fake.requests != before {
+			t.Error("expected the cache file to answer without a lookup")
+		}
+	})
+
+	t.Run("expired entries follow renames", func(t *testing.T) {
+		fake.remove("alice")
+		fake.add("alice-new", 1, "User")
+		expired, err := NewCachedVerifier(NewGitHubVerifier(server.Client(), server.URL, ""), 0, cacheFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check, err := expired.Verify(HandleRequest{Handle: "alice"})
+		if err != nil || check.Verified || check.Login != "alice-new" {
+			t.Errorf("expected alice to be reported as renamed to alice-new, got %+v, %v", check, err)
+		}
+	})
+}
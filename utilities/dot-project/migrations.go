@@ -0,0 +1,202 @@
+package projects
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Migration upgrades a project.yaml document from one schema version to the
+// next. Apply edits the top-level mapping node in place so comments and key
+// order survive; the migrator updates schema_version after Apply succeeds.
+type Migration struct {
+	From        string
+	To          string
+	Description string
+	Apply       func(doc *yaml.Node) error
+}
+
+// builtinMigrations lists the upgrade steps between the versions in
+// SupportedSchemaVersions. Add a step here whenever a new schema version is
+// appended there.
+var builtinMigrations = []Migration{}
+
+// LatestSchemaVersion returns the newest schema version this tool writes
+func LatestSchemaVersion() string {
+	return SupportedSchemaVersions[len(SupportedSchemaVersions)-1]
+}
+
+// Migrator chains registered migrations to bring project.yaml files up to the
+// latest schema version
+type Migrator struct {
+	latest     string
+	migrations map[string]Migration // keyed by From
+}
+
+// MigrationResult describes the outcome of migrating a project.yaml document
+type MigrationResult struct {
+	From    string      // schema_version before migration
+	To      string      // schema_version after migration
+	Applied []Migration // steps that ran, in order
+	Content []byte      // migrated document; the input unchanged when no step ran
+}
+
+// Changed reports whether any migration step ran
+func (r MigrationResult) Changed() bool {
+	return len(r.Applied) > 0
+}
+
+// NewMigrator creates a migrator targeting latest. Each version may only be
+// migrated from once.
+func NewMigrator(latest string, migrations ...Migration) (*Migrator, error) {
+	m := &Migrator{latest: latest, migrations: make(map[string]Migration)}
+	for _, mig := range migrations {
+		if mig.From == "" || mig.To == "" || mig.From == mig.To {
+			return nil, fmt.Errorf("invalid migration %q -> %q", mig.From, mig.To)
+		}
+		if mig.Apply == nil {
+			return nil, fmt.Errorf("migration %s -> %s has no Apply function", mig.From, mig.To)
+		}
+		if _, exists := m.migrations[mig.From]; exists {
+			return nil, fmt.Errorf("duplicate migration from %s", mig.From)
+		}
+		m.migrations[mig.From] = mig
+	}
+	return m, nil
+}
+
+// DefaultMigrator returns a migrator with the built-in migrations targeting
+// LatestSchemaVersion
+func DefaultMigrator() *Migrator {
+	m, err := NewMigrator(LatestSchemaVersion(), builtinMigrations...)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in migrations: %v", err))
+	}
+	return m
+}
+
+// Latest returns the schema version the migrator upgrades to
+func (m *Migrator) Latest() string {
+	return m.latest
+}
+
+// Path returns the migrations needed to go from a schema version to the latest
+func (m *Migrator) Path(from string) ([]Migration, error) {
+	var path []Migration
+	version := from
+	for version != m.latest {
+		mig, ok := m.migrations[version]
+		if !ok {
+			return nil, fmt.Errorf("no migration path from schema_version %s to %s", from, m.latest)
+		}
+		if len(path) > len(m.migrations) {
+			return nil, fmt.Errorf("migration cycle detected starting at schema_version %s", from)
+		}
+		path = append(path, mig)
+		version = mig.To
+	}
+	return path, nil
+}
+
+// Check reports the document's schema_version and whether it is already at
+// the latest version
+func (m *Migrator) Check(data []byte) (string, bool, error) {
+	_, version, err := parseMigrationDocument(data)
+	if err != nil {
+		return "", false, err
+	}
+	if _, err := m.Path(version); err != nil {
+		return version, false, err
+	}
+	return version, version == m.latest, nil
+}
+
+// Migrate upgrades a project.yaml document to the latest schema version
+func (m *Migrator) Migrate(data []byte) (MigrationResult, error) {
+	root, version, err := parseMigrationDocument(data)
+	if err != nil {
+		return MigrationResult{}, err
+	}
+	path, err := m.Path(version)
+	if err != nil {
+		return MigrationResult{}, err
+	}
+
+	result := MigrationResult{From: version, To: version, Content: data}
+	if len(path) == 0 {
+		return result, nil
+	}
+
+	doc := root.Content[0]
+	for _, mig := range path {
+		if err := mig.Apply(doc); err != nil {
+			return MigrationResult{}, fmt.Errorf("migration %s -> %s failed: %w", mig.From, mig.To, err)
+		}
+		setSchemaVersion(doc, mig.To)
+		result.Applied = append(result.Applied, mig)
+		result.To = mig.To
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return MigrationResult{}, fmt.Errorf("failed to encode migrated YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return MigrationResult{}, fmt.Errorf("failed to encode migrated YAML: %w", err)
+	}
+	result.Content = buf.Bytes()
+	return result, nil
+}
+
+// MigrateFile upgrades a project.yaml file in place. The file is only
+// rewritten when a migration ran.
+func (m *Migrator) MigrateFile(path string) (MigrationResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return MigrationResult{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	result, err := m.Migrate(data)
+	if err != nil {
+		return MigrationResult{}, err
+	}
+	if result.Changed() {
+		if err := os.WriteFile(path, result.Content, 0644); err != nil {
+			return MigrationResult{}, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return result, nil
+}
+
+// parseMigrationDocument decodes a project.yaml into a node tree and returns
+// its schema_version
+func parseMigrationDocument(data []byte) (*yaml.Node, string, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, "", fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil, "", fmt.Errorf("project.yaml must be a YAML mapping")
+	}
+	versionNode := childNode(root.Content[0], "schema_version")
+	if versionNode == nil || versionNode.Kind != yaml.ScalarNode || versionNode.Value == "" {
+		return nil, "", fmt.Errorf("schema_version is missing; set it before migrating")
+	}
+	return &root, versionNode.Value, nil
+}
+
+// setSchemaVersion sets schema_version in the top-level mapping, adding it
+// as the first key when a migration step removed it
+func setSchemaVersion(doc *yaml.Node, version string) {
+	if node := childNode(doc, "schema_version"); node != nil {
+		node.Kind, node.Tag, node.Value, node.Content = yaml.ScalarNode, "!!str", version, nil
+		return
+	}
+	doc.Content = append([]*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "schema_version"},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Style: yaml.DoubleQuotedStyle, Value: version},
+	}, doc.Content...)
+}
@@ -0,0 +1,178 @@
+package projects
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// testMigration renames cncf_slack_channel to slack, standing in for a
+// future 1.0.0 -> 1.1.0 step
+var testMigration = Migration{
+	From:        "1.0.0",
+	To:          "1.1.0",
+	Description: "rename cncf_slack_channel to slack",
+	Apply: func(doc *yaml.Node) error {
+		for i := 0; i+1 < len(doc.Content); i += 2 {
+			if doc.Content[i].Value == "cncf_slack_channel" {
+				doc.Content[i].Value = "slack"
+			}
+		}
+		return nil
+	},
+}
+
+const migrationInput = `# Project metadata
+schema_version: "1.0.0"
+slug: test-project # keep this comment
+name: Test Project
+cncf_slack_channel: "#test"
+type: platform
+repositories:
+  - https://github.com/test/repo
+`
+
+func TestDefaultMigrator(t *testing.T) {
+	m := DefaultMigrator()
+	if m.Latest() != LatestSchemaVersion() {
+		t.Errorf("expected latest %s, got %s", LatestSchemaVersion(), m.Latest())
+	}
+	// Every supported version must have a path to the latest one
+	for _, v := range SupportedSchemaVersions {
+		if _, err := m.Path(v); err != nil {
+			t.Errorf("no migration path from supported version %s: %v", v, err)
+		}
+	}
+
+	data, err := os.ReadFile("example/project.yaml")
+	if err != nil {
+		t.Fatalf("read example: %v", err)
+	}
+	if _, upToDate, err := m.Check(data); err != nil || !upToDate {
+		t.Errorf("expected example project to be up to date, got upToDate=%v err=%v", upToDate, err)
+	}
+}
+
+func TestMigratorMigrate(t *testing.T) {
+	m, err := NewMigrator("1.1.0", testMigration)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+
+	version, upToDate, err := m.Check([]byte(migrationInput))
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if version != "1.0.0" || upToDate {
+		t.Errorf("expected 1.0.0 to be behind, got version=%s upToDate=%v", version, upToDate)
+	}
+
+	result, err := m.Migrate([]byte(migrationInput))
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if !result.Changed() || result.From != "1.0.0" || result.To != "1.1.0" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	out := string(result.Content)
+	for _, want := range []string{"# Project metadata", "# keep this comment", `schema_version: "1.1.0"`, `slack: "#test"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected migrated output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "cncf_slack_channel") {
+		t.Errorf("expected cncf_slack_channel to be renamed, got:\n%s", out)
+	}
+	// Renamed keys keep their position
+	if strings.Index(out, "slack:") > strings.Index(out, "repositories:") {
+		t.Errorf("expected renamed key to keep its position, got:\n%s", out)
+	}
+
+	// Migrating again is a no-op
+	again, err := m.Migrate(result.Content)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if again.Changed() || string(again.Content) != out {
+		t.Error("expected migrating an up-to-date document to leave it unchanged")
+	}
+}
+
+func TestMigrateRestoresSchemaVersion(t *testing.T) {
+	dropVersion := Migration{
+		From:        "1.0.0",
+		To:          "1.1.0",
+		Description: "rebuild the document without schema_version",
+		Apply: func(doc *yaml.Node) error {
+			doc.Content = doc.Content[2:]
+			return nil
+		},
+	}
+	m, err := NewMigrator("1.1.0", dropVersion)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+	result, err := m.Migrate([]byte(migrationInput))
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if version, upToDate, err := m.Check(result.Content); err != nil || version != "1.1.0" || !upToDate {
+		t.Errorf("expected schema_version 1.1.0 to be restored, got version=%s err=%v:\n%s", version, err, result.Content)
+	}
+}
+
+func TestMigratorErrors(t *testing.T) {
+	if _, err := NewMigrator("1.1.0", testMigration, testMigration); err == nil {
+		t.Error("expected error for duplicate migration")
+	}
+	if _, err := NewMigrator("1.1.0", Migration{From: "1.0.0", To: "1.1.0"}); err == nil {
+		t.Error("expected error for migration without Apply")
+	}
+
+	m, err := NewMigrator("1.1.0", testMigration)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"missing schema_version", "slug: test\n", "schema_version is missing"},
+		{"unknown version", "schema_version: \"0.9.0\"\n", "no migration path from schema_version 0.9.0"},
+		{"not a mapping", "- a\n- b\n", "must be a YAML mapping"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.Migrate([]byte(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestMigrateFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "project.yaml")
+	writeFile(t, path, migrationInput)
+
+	m, err := NewMigrator("1.1.0", testMigration)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+	if _, err := m.MigrateFile(path); err != nil {
+		t.Fatalf("MigrateFile: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if !strings.Contains(string(data), `schema_version: "1.1.0"`) {
+		t.Errorf("expected file to be upgraded in place, got:\n%s", data)
+	}
+}
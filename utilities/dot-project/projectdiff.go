@@ -0,0 +1,270 @@
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ChangeKind says how a field differs between two versions of a project
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// FieldChange is a single field-level difference between two Project values.
+// For lists of URLs and similar scalars, each added or removed item is its
+// own change on the list's path.
+type FieldChange struct {
+	Path string     `json:"path" yaml:"path"`
+	Kind ChangeKind `json:"kind" yaml:"kind"`
+	Old  string     `json:"old,omitempty" yaml:"old,omitempty"`
+	New  string     `json:"new,omitempty" yaml:"new,omitempty"`
+}
+
+// String renders the change as a single line, e.g. "~ project_lead: alice -> bob"
+func (c FieldChange) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s: %s", c.Path, c.New)
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s: %s", c.Path, c.Old)
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Path, c.Old, c.New)
+	}
+}
+
+// DiffProjects compares two versions of a project field by field, following
+// yaml tags so paths match project.yaml. List entries such as maturity_log
+// and audits are compared by position, so appended entries show up as added.
+func DiffProjects(old, new Project) []FieldChange {
+	var changes []FieldChange
+	diffValues(&changes, "", reflect.ValueOf(old), reflect.ValueOf(new))
+	return changes
+}
+
+// diffProjectContent decodes the cached and current project.yaml and diffs
+// them. Old content is decoded leniently since it may predate schema changes.
+func diffProjectContent(oldContent string, current Project) ([]FieldChange, error) {
+	var old Project
+	if err := yaml.Unmarshal([]byte(oldContent), &old); err != nil {
+		return nil, fmt.Errorf("failed to parse cached project: %w", err)
+	}
+	return DiffProjects(old, current), nil
+}
+
+func diffValues(changes *[]FieldChange, path string, old, new reflect.Value) {
+	old, new = derefValue(old), derefValue(new)
+
+	if isDiffLeaf(old.Type()) {
+		oldText, newText := renderDiffValue(old), renderDiffValue(new)
+		switch {
+		case oldText == newText:
+		case oldText == "":
+			*changes = append(*changes, FieldChange{Path: path, Kind: ChangeAdded, New: newText})
+		case newText == "":
+			*changes = append(*changes, FieldChange{Path: path, Kind: ChangeRemoved, Old: oldText})
+		default:
+			*changes = append(*changes, FieldChange{Path: path, Kind: ChangeModified, Old: oldText, New: newText})
+		}
+		return
+	}
+
+	switch old.Kind() {
+	case reflect.Struct:
+		for i := 0; i < old.NumField(); i++ {
+			name := yamlFieldName(old.Type().Field(i))
+			if name == "" {
+				continue
+			}
+			diffValues(changes, joinDiffPath(path, name), old.Field(i), new.Field(i))
+		}
+	case reflect.Map:
+		keys := make(map[string]bool)
+		for _, k := range old.MapKeys() {
+			keys[k.String()] = true
+		}
+		for _, k := range new.MapKeys() {
+			keys[k.String()] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		zero := reflect.Zero(old.Type().Elem())
+		for _, k := range sorted {
+			o, n := old.MapIndex(reflect.ValueOf(k)), new.MapIndex(reflect.ValueOf(k))
+			if !o.IsValid() {
+				o = zero
+			}
+			if !n.IsValid() {
+				n = zero
+			}
+			diffValues(changes, joinDiffPath(path, k), o, n)
+		}
+	case reflect.Slice:
+		if isDiffLeaf(old.Type().Elem()) {
+			diffSets(changes, path, old, new)
+			return
+		}
+		for i := 0; i < old.Len() || i < new.Len(); i++ {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= old.Len():
+				*changes = append(*changes, FieldChange{Path: itemPath, Kind: ChangeAdded, New: summarizeDiffValue(new.Index(i))})
+			case i >= new.Len():
+				*changes = append(*changes, FieldChange{Path: itemPath, Kind: ChangeRemoved, Old: summarizeDiffValue(old.Index(i))})
+			default:
+				diffValues(changes, itemPath, old.Index(i), new.Index(i))
+			}
+		}
+	}
+}
+
+// diffSets reports items added to or removed from a list of scalars,
+// ignoring reordering
+func diffSets(changes *[]FieldChange, path string, old, new reflect.Value) {
+	oldItems, newItems := make(map[string]bool), make(map[string]bool)
+	for i := 0; i < old.Len(); i++ {
+		oldItems[renderDiffValue(old.Index(i))] = true
+	}
+	for i := 0; i < new.Len(); i++ {
+		newItems[renderDiffValue(new.Index(i))] = true
+	}
+	for i := 0; i < old.Len(); i++ {
+		if item := renderDiffValue(old.Index(i)); !newItems[item] {
+			*changes = append(*changes, FieldChange{Path: path, Kind: ChangeRemoved, Old: item})
+		}
+	}
+	for i := 0; i < new.Len(); i++ {
+		if item := renderDiffValue(new.Index(i)); !oldItems[item] {
+			*changes = append(*changes, FieldChange{Path: path, Kind: ChangeAdded, New: item})
+		}
+	}
+}
+
+// derefValue follows pointers, using the zero value for nil so a section
+// that was added or removed is compared field by field against nothing
+func derefValue(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v = reflect.Zero(v.Type().Elem())
+		} else {
+			v = v.Elem()
+		}
+	}
+	return v
+}
+
+// isDiffLeaf reports whether values of a type are compared as a whole
+func isDiffLeaf(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t {
+	case reflect.TypeOf(time.Time{}), reflect.TypeOf(PathRef{}):
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice:
+		return false
+	}
+	return true
+}
+
+// renderDiffValue renders a leaf value, returning "" for unset values
+func renderDiffValue(v reflect.Value) string {
+	v = derefValue(v)
+	switch val := v.Interface().(type) {
+	case time.Time:
+		if val.IsZero() {
+			return ""
+		}
+		return val.Format("2006-01-02")
+	case PathRef:
+		return val.Path
+	case bool:
+		if !val {
+			return ""
+		}
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// summarizeDiffValue renders a whole list entry on one line, e.g.
+// "phase=incubating, date=2025-01-15, issue=https://..."
+func summarizeDiffValue(v reflect.Value) string {
+	v = derefValue(v)
+	if isDiffLeaf(v.Type()) || v.Kind() != reflect.Struct {
+		return renderDiffValue(v)
+	}
+	var parts []string
+	for i := 0; i < v.NumField(); i++ {
+		name := yamlFieldName(v.Type().Field(i))
+		if name == "" || !isDiffLeaf(v.Type().Field(i).Type) {
+			continue
+		}
+		if text := renderDiffValue(v.Field(i)); text != "" {
+			parts = append(parts, name+"="+text)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func joinDiffPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// FormatFieldChanges renders field changes as text, json or markdown
+func FormatFieldChanges(changes []FieldChange, format string) (string, error) {
+	switch format {
+	case "json":
+		if changes == nil {
+			changes = []FieldChange{}
+		}
+		data, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	case "markdown":
+		if len(changes) == 0 {
+			return "_No field changes._\n", nil
+		}
+		var b strings.Builder
+		b.WriteString("| Field | Change | Old | New |\n")
+		b.WriteString("|-------|--------|-----|-----|\n")
+		for _, c := range changes {
+			b.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s |\n", c.Path, c.Kind, markdownCell(c.Old), markdownCell(c.New)))
+		}
+		return b.String(), nil
+	case "text", "":
+		var b strings.Builder
+		for _, c := range changes {
+			b.WriteString(c.String() + "\n")
+		}
+		return b.String(), nil
+	default:
+		return "", fmt.Errorf("unsupported format %q (use text, json or markdown)", format)
+	}
+}
+
+// markdownCell escapes a value for use in a Markdown table cell
+func markdownCell(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + strings.ReplaceAll(s, "|", "\\|") + "`"
+}
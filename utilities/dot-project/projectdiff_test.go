@@ -0,0 +1,125 @@
+package projects
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiffProjects(t *testing.T) {
+	old := validBaseProject()
+	old.ProjectLead = "alice"
+	old.Repositories = []string{"https://github.com/test/repo", "https://github.com/test/old"}
+	old.Social = map[string]string{"twitter": "https://twitter.com/old"}
+
+	new := validBaseProject()
+	new.ProjectLead = "bob"
+	new.Repositories = []string{"https://github.com/test/new", "https://github.com/test/repo"}
+	new.Social = map[string]string{"twitter": "https://twitter.com/new", "bluesky": "https://bsky.app/profile/test"}
+	new.MaturityLog = append(new.MaturityLog, MaturityEntry{
+		Phase: "incubating",
+		Date:  time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+		Issue: "https://github.com/cncf/toc/issues/456",
+	})
+	new.Audits = []Audit{{Date: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), Type: "security", URL: "https://example.com/audit.pdf"}}
+	new.Security = &SecurityConfig{Policy: &PathRef{Path: "SECURITY.md"}}
+
+	changes := DiffProjects(old, new)
+
+	expected := []FieldChange{
+		{Path: "maturity_log[1]", Kind: ChangeAdded, New: "phase=incubating, date=2025-01-15, issue=https://github.com/cncf/toc/issues/456"},
+		{Path: "repositories", Kind: ChangeRemoved, Old: "https://github.com/test/old"},
+		{Path: "repositories", Kind: ChangeAdded, New: "https://github.com/test/new"},
+		{Path: "social.bluesky", Kind: ChangeAdded, New: "https://bsky.app/profile/test"},
+		{Path: "social.twitter", Kind: ChangeModified, Old: "https://twitter.com/old", New: "https://twitter.com/new"},
+		{Path: "audits[0]", Kind: ChangeAdded, New: "date=2025-02-01, type=security, url=https://example.com/audit.pdf"},
+		{Path: "project_lead", Kind: ChangeModified, Old: "alice", New: "bob"},
+		{Path: "security.policy", Kind: ChangeAdded, New: "SECURITY.md"},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %d: %v", len(expected), len(changes), changes)
+	}
+	for i, exp := range expected {
+		if changes[i] != exp {
+			t.Errorf("change %d: expected %+v, got %+v", i, exp, changes[i])
+		}
+	}
+
+	if got := DiffProjects(old, old); len(got) != 0 {
+		t.Errorf("expected no changes for identical projects, got %v", got)
+	}
+}
+
+func TestFormatFieldChanges(t *testing.T) {
+	changes := []FieldChange{
+		{Path: "project_lead", Kind: ChangeModified, Old: "alice", New: "bob"},
+		{Path: "repositories", Kind: ChangeAdded, New: "https://github.com/test/new"},
+	}
+
+	text, err := FormatFieldChanges(changes, "text")
+	if err != nil {
+		t.Fatalf("text: %v", err)
+	}
+	if text != "~ project_lead: alice -> bob\n+ repositories: https://github.com/test/new\n" {
+		t.Errorf("unexpected text output:\n%s", text)
+	}
+
+	md, err := FormatFieldChanges(changes, "markdown")
+	if err != nil {
+		t.Fatalf("markdown: %v", err)
+	}
+	if !strings.Contains(md, "| `project_lead` | modified | `alice` | `bob` |") {
+		t.Errorf("unexpected markdown output:\n%s", md)
+	}
+
+	js, err := FormatFieldChanges(changes, "json")
+	if err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	var decoded []FieldChange
+	if err := json.Unmarshal([]byte(js), &decoded); err != nil || len(decoded) != 2 {
+		t.Errorf("expected JSON round trip of 2 changes, got %v (err %v)", decoded, err)
+	}
+
+	if _, err := FormatFieldChanges(changes, "html"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestValidateProjectFieldChanges(t *testing.T) {
+	dir := t.TempDir()
+	projPath := filepath.Join(dir, "project.yaml")
+	writeFile(t, projPath, validProjectYAML())
+
+	pv := newTestValidator(t)
+	first, err := pv.validateProject("file://" + projPath)
+	if err != nil {
+		t.Fatalf("validateProject: %v", err)
+	}
+	if len(first.FieldChanges) != 0 {
+		t.Errorf("new project should have no field changes, got %v", first.FieldChanges)
+	}
+
+	writeFile(t, projPath, validProjectYAML()+"project_lead: alice\n")
+	second, err := pv.validateProject("file://" + projPath)
+	if err != nil {
+		t.Fatalf("validateProject: %v", err)
+	}
+	if len(second.FieldChanges) != 1 || second.FieldChanges[0].Path != "project_lead" {
+		t.Fatalf("expected project_lead change, got %v", second.FieldChanges)
+	}
+
+	report := pv.GenerateDiff([]ValidationResult{second})
+	if !strings.Contains(report, "  + project_lead: alice") {
+		t.Errorf("text report should list field changes, got:\n%s", report)
+	}
+	md, err := pv.FormatResults([]ValidationResult{second}, "markdown")
+	if err != nil {
+		t.Fatalf("FormatResults: %v", err)
+	}
+	if !strings.Contains(md, "| `project_lead` | added |  | `alice` |") {
+		t.Errorf("markdown report should contain a field change table, got:\n%s", md)
+	}
+}
@@ -0,0 +1,191 @@
+package projects
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultProfilesYAML holds the CNCF Due Diligence requirement profiles shipped with the validator
+//
+//go:embed profiles/due-diligence.yaml
+var defaultProfilesYAML []byte
+
+// RequirementLevel says whether a Due Diligence item must or should be present
+type RequirementLevel string
+
+const (
+	RequirementRequired  RequirementLevel = "required"
+	RequirementSuggested RequirementLevel = "suggested"
+)
+
+// Requirement is a single Due Diligence item within a maturity profile
+type Requirement struct {
+	Field  string           `json:"field" yaml:"field"`                         // project.yaml field path (e.g., "governance.code_of_conduct")
+	Level  RequirementLevel `json:"level" yaml:"level"`                         // required or suggested
+	Item   string           `json:"item,omitempty" yaml:"item,omitempty"`       // Due Diligence item name (e.g., "Code of Conduct")
+	IfUsed string           `json:"if_used,omitempty" yaml:"if_used,omitempty"` // Group that only applies once any of its fields is set (e.g., "subprojects")
+}
+
+// RequirementProfiles maps maturity phases to their Due Diligence requirements
+type RequirementProfiles struct {
+	Profiles map[string][]Requirement `json:"profiles" yaml:"profiles"`
+}
+
+// DefaultRequirementProfiles returns the embedded Due Diligence profiles
+func DefaultRequirementProfiles() (*RequirementProfiles, error) {
+	return ParseRequirementProfiles(defaultProfilesYAML)
+}
+
+// LoadRequirementProfiles reads requirement profiles from a YAML file
+func LoadRequirementProfiles(path string) (*RequirementProfiles, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read requirement profiles: %w", err)
+	}
+	return ParseRequirementProfiles(data)
+}
+
+// ParseRequirementProfiles parses and checks requirement profiles YAML. Every
+// phase must be a known maturity phase and every field must exist in Project.
+func ParseRequirementProfiles(data []byte) (*RequirementProfiles, error) {
+	var rp RequirementProfiles
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&rp); err != nil {
+		return nil, fmt.Errorf("failed to parse requirement profiles: %w", err)
+	}
+
+	var errs []string
+	zero := reflect.ValueOf(Project{})
+	for phase, reqs := range rp.Profiles {
+		if !ValidMaturityPhases[phase] {
+			errs = append(errs, fmt.Sprintf("unknown maturity phase %q", phase))
+		}
+		for i, req := range reqs {
+			if _, ok := resolveFieldPath(zero, req.Field); !ok {
+				errs = append(errs, fmt.Sprintf("%s[%d]: unknown field %q", phase, i, req.Field))
+			}
+			if req.Level != RequirementRequired && req.Level != RequirementSuggested {
+				errs = append(errs, fmt.Sprintf("%s[%d]: level must be required or suggested, got %q", phase, i, req.Level))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return nil, fmt.Errorf("invalid requirement profiles: %s", strings.Join(errs, "; "))
+	}
+	return &rp, nil
+}
+
+// CurrentPhase returns the project's maturity phase from the last maturity_log entry
+func CurrentPhase(project Project) string {
+	if len(project.MaturityLog) == 0 {
+		return ""
+	}
+	return project.MaturityLog[len(project.MaturityLog)-1].Phase
+}
+
+// CheckRequirements evaluates the embedded Due Diligence profile for a phase.
+// Pass "" to use the project's current phase.
+func CheckRequirements(project Project, phase string) ([]Diagnostic, error) {
+	rp, err := DefaultRequirementProfiles()
+	if err != nil {
+		return nil, err
+	}
+	return rp.Check(project, phase), nil
+}
+
+// Check reports missing required items as errors and missing suggested items
+// as warnings for the given phase. Pass "" to use the project's current phase.
+// Phases without a profile produce no diagnostics.
+func (rp *RequirementProfiles) Check(project Project, phase string) []Diagnostic {
+	if phase == "" {
+		phase = CurrentPhase(project)
+	}
+	reqs, ok := rp.Profiles[phase]
+	if !ok {
+		return nil
+	}
+
+	value := reflect.ValueOf(project)
+
+	// A group is in use once any of its fields is set
+	groupsInUse := make(map[string]bool)
+	for _, req := range reqs {
+		if req.IfUsed == "" {
+			continue
+		}
+		if v, ok := resolveFieldPath(value, req.Field); ok && isFieldSet(v) {
+			groupsInUse[req.IfUsed] = true
+		}
+	}
+
+	var diags diagnosticSet
+	for _, req := range reqs {
+		v, ok := resolveFieldPath(value, req.Field)
+		if ok && isFieldSet(v) {
+			continue
+		}
+
+		item := ""
+		if req.Item != "" {
+			item = fmt.Sprintf(" (Due Diligence: %s)", req.Item)
+		}
+
+		if req.IfUsed != "" && !groupsInUse[req.IfUsed] {
+			diags.add(SeverityInfo, requirementProfileRuleID, req.Field, "%s is %s for %s projects that use %s%s", req.Field, req.Level, phase, req.IfUsed, item)
+			continue
+		}
+
+		severity := SeverityWarning
+		if req.Level == RequirementRequired {
+			severity = SeverityError
+		}
+		diags.add(severity, requirementProfileRuleID, req.Field, "%s is %s for %s projects%s", req.Field, req.Level, phase, item)
+	}
+	return diags
+}
+
+// requirementProfileRuleID is the rule ID of requirement profile findings
+const requirementProfileRuleID = "requirement-profile"
+
+// requirementProfileRule runs the validator's Due Diligence profiles as a rule
+// so they can be listed, disabled and suppressed like the built-in checks
+type requirementProfileRule struct {
+	pv *ProjectValidator
+}
+
+func (r requirementProfileRule) ID() string { return requirementProfileRuleID }
+
+func (r requirementProfileRule) Description() string {
+	return "Due Diligence items required or suggested for the maturity phase"
+}
+
+func (r requirementProfileRule) Check(project Project) []Diagnostic {
+	if r.pv.profiles == nil {
+		return nil
+	}
+	return r.pv.profiles.Check(project, r.pv.targetPhase)
+}
+
+// SetRequirementProfiles replaces the Due Diligence profiles used during
+// validation. Pass nil to disable requirement checks.
+func (pv *ProjectValidator) SetRequirementProfiles(rp *RequirementProfiles) {
+	pv.profiles = rp
+}
+
+// SetTargetPhase evaluates requirement profiles for the given phase instead of
+// each project's current phase, e.g. "graduated" to check graduation readiness
+func (pv *ProjectValidator) SetTargetPhase(phase string) error {
+	if phase != "" && !ValidMaturityPhases[phase] {
+		return fmt.Errorf("invalid target phase %q (allowed: sandbox, incubating, graduated, archived)", phase)
+	}
+	pv.targetPhase = phase
+	return nil
+}
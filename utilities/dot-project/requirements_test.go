@@ -0,0 +1,228 @@
+package projects
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDefaultRequirementProfiles(t *testing.T) {
+	rp, err := DefaultRequirementProfiles()
+	if err != nil {
+		t.Fatalf("embedded profiles should parse: %v", err)
+	}
+	for _, phase := range []string{"sandbox", "incubating", "graduated", "archived"} {
+		if _, ok := rp.Profiles[phase]; !ok {
+			t.Errorf("expected a profile for phase %q", phase)
+		}
+	}
+}
+
+func TestCheckRequirements(t *testing.T) {
+	t.Run("sandbox has no requirements", func(t *testing.T) {
+		diags, err := CheckRequirements(validBaseProject(), "")
+		if err != nil {
+			t.Fatalf("CheckRequirements: %v", err)
+		}
+		if len(diags) != 0 {
+			t.Errorf("expected no diagnostics for sandbox project, got: %v", diags)
+		}
+	})
+
+	t.Run("current phase comes from last maturity_log entry", func(t *testing.T) {
+		project := validBaseProject()
+		project.MaturityLog = append(project.MaturityLog, MaturityEntry{
+			Phase: "incubating",
+			Date:  time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+			Issue: "https://github.com/cncf/toc/issues/456",
+		})
+		if got := CurrentPhase(project); got != "incubating" {
+			t.Fatalf("expected current phase incubating, got %q", got)
+		}
+
+		diags, err := CheckRequirements(project, "")
+		if err != nil {
+			t.Fatalf("CheckRequirements: %v", err)
+		}
+		counts := CountDiagnostics(diags)
+		// Incubating: 5 required, 4 suggested (sub_project_list/docs only apply if used)
+		if counts[SeverityError] != 5 {
+			t.Errorf("expected 5 errors, got %d: %v", counts[SeverityError], diags)
+		}
+		if counts[SeverityWarning] != 4 {
+			t.Errorf("expected 4 warnings, got %d: %v", counts[SeverityWarning], diags)
+		}
+		if counts[SeverityInfo] != 2 {
+			t.Errorf("expected 2 info diagnostics for unused subprojects, got %d: %v", counts[SeverityInfo], diags)
+		}
+	})
+
+	t.Run("present items are not reported", func(t *testing.T) {
+		project := validBaseProject()
+		project.Governance = &GovernanceConfig{
+			CodeOfConduct: &PathRef{Path: "CODE_OF_CONDUCT.md"},
+			ChangeProcess: &PathRef{Path: ""}, // empty path does not satisfy the requirement
+		}
+		diags, err := CheckRequirements(project, "incubating")
+		if err != nil {
+			t.Fatalf("CheckRequirements: %v", err)
+		}
+		for _, d := range diags {
+			if d.Path == "governance.code_of_conduct" {
+				t.Errorf("code_of_conduct is set and should not be reported: %v", d)
+			}
+		}
+		found := false
+		for _, d := range diags {
+			if d.Path == "governance.change_process" && d.Severity == SeverityError {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected change_process with empty path to be reported as an error, got: %v", diags)
+		}
+	})
+
+	t.Run("if_used group is enforced once any field is set", func(t *testing.T) {
+		project := validBaseProject()
+		project.Governance = &GovernanceConfig{
+			SubProjectDocs: &PathRef{Path: "subprojects.md"},
+		}
+		diags, err := CheckRequirements(project, "graduated")
+		if err != nil {
+			t.Fatalf("CheckRequirements: %v", err)
+		}
+		found := false
+		for _, d := range diags {
+			if d.Path == "governance.sub_project_list" {
+				found = true
+				if d.Severity != SeverityError {
+					t.Errorf("expected sub_project_list to be an error once subprojects are used, got %s", d.Severity)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("expected sub_project_list to be reported, got: %v", diags)
+		}
+	})
+
+	t.Run("example project is ready for graduation", func(t *testing.T) {
+		project, err := LoadProjectFromFile("example/project.yaml")
+		if err != nil {
+			t.Fatalf("LoadProjectFromFile: %v", err)
+		}
+		diags, err := CheckRequirements(project, "graduated")
+		if err != nil {
+			t.Fatalf("CheckRequirements: %v", err)
+		}
+		if HasSeverity(diags, SeverityWarning) {
+			t.Errorf("expected example project to meet graduated profile, got: %v", diags)
+		}
+	})
+}
+
+func TestParseRequirementProfiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			yaml: `profiles:
+  incubating:
+    - field: security.policy
+      level: required
+`,
+		},
+		{
+			name: "unknown field",
+			yaml: `profiles:
+  incubating:
+    - field: governance.not_a_field
+      level: required
+`,
+			wantErr: `unknown field "governance.not_a_field"`,
+		},
+		{
+			name: "unknown phase",
+			yaml: `profiles:
+  mature: []
+`,
+			wantErr: `unknown maturity phase "mature"`,
+		},
+		{
+			name: "bad level",
+			yaml: `profiles:
+  graduated:
+    - field: website
+      level: mandatory
+`,
+			wantErr: "level must be required or suggested",
+		},
+		{
+			name:    "unknown key",
+			yaml:    "phases: {}\n",
+			wantErr: "failed to parse requirement profiles",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRequirementProfiles([]byte(tt.yaml))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateProjectTargetPhase(t *testing.T) {
+	dir := t.TempDir()
+	projPath := filepath.Join(dir, "project.yaml")
+	writeFile(t, projPath, validProjectYAML())
+
+	pv := newTestValidator(t)
+	result, err := pv.validateProject("file://" + projPath)
+	if err != nil {
+		t.Fatalf("validateProject: %v", err)
+	}
+	if !result.Valid || len(result.Diagnostics) != 0 {
+		t.Fatalf("sandbox project should pass its own profile, got: %v", result.Diagnostics)
+	}
+
+	if err := pv.SetTargetPhase("graduated"); err != nil {
+		t.Fatalf("SetTargetPhase: %v", err)
+	}
+	result, err = pv.validateProject("file://" + projPath)
+	if err != nil {
+		t.Fatalf("validateProject: %v", err)
+	}
+	if result.Valid {
+		t.Error("sandbox project should not be ready for graduation")
+	}
+	for _, d := range result.Diagnostics {
+		if d.Rule == "requirement-profile" && d.Line == 0 {
+			t.Errorf("expected requirement diagnostics to carry a source position: %+v", d)
+		}
+	}
+
+	pv.SetRequirementProfiles(nil)
+	result, err = pv.validateProject("file://" + projPath)
+	if err != nil {
+		t.Fatalf("validateProject: %v", err)
+	}
+	if !result.Valid {
+		t.Errorf("disabling profiles should skip requirement checks, got: %v", result.Errors)
+	}
+
+	if err := pv.SetTargetPhase("mature"); err == nil {
+		t.Error("expected error for unknown target phase")
+	}
+}
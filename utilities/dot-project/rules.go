@@ -0,0 +1,549 @@
+package projects
+
+import (
+	"fmt"
+	"net/mail"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// suppressionRule is the rule ID used for findings about validation.ignore
+// entries themselves. It cannot be disabled or suppressed.
+const suppressionRule = "suppression"
+
+// Rule is a named project.yaml check. Every diagnostic a rule reports carries
+// the rule's ID so it can be disabled or suppressed per project.
+type Rule interface {
+	ID() string
+	Description() string
+	Check(project Project) []Diagnostic
+}
+
+// ruleContext carries the state of one validation call to the rules that
+// need more than the project, so concurrent calls on a validator do not share it
+type ruleContext struct {
+	checkout       *LocalCheckout // Working trees PathRefs are resolved against; nil outside -repo-root
+	advisoryForges []Forge        // Forges whose advisory URLs are accepted besides GitHub and gitlab.com
+}
+
+// contextRule is a Rule whose findings depend on the ruleContext of the call.
+// The registry calls checkContext instead of Check.
+type contextRule interface {
+	Rule
+	checkContext(project Project, ctx ruleContext) []Diagnostic
+}
+
+// ruleFunc adapts a check function to the Rule interface
+type ruleFunc struct {
+	id          string
+	description string
+	check       func(project Project, diags *diagnosticSet)
+}
+
+func (r ruleFunc) ID() string          { return r.id }
+func (r ruleFunc) Description() string { return r.description }
+
+func (r ruleFunc) Check(project Project) []Diagnostic {
+	var diags diagnosticSet
+	r.check(project, &diags)
+	return diags
+}
+
+// contextRuleFunc adapts a check function that reads the ruleContext to the
+// contextRule interface. Check runs it with an empty context.
+type contextRuleFunc struct {
+	id          string
+	description string
+	check       func(project Project, ctx ruleContext, diags *diagnosticSet)
+}
+
+func (r contextRuleFunc) ID() string          { return r.id }
+func (r contextRuleFunc) Description() string { return r.description }
+
+func (r contextRuleFunc) Check(project Project) []Diagnostic {
+	return r.checkContext(project, ruleContext{})
+}
+
+func (r contextRuleFunc) checkContext(project Project, ctx ruleContext) []Diagnostic {
+	var diags diagnosticSet
+	r.check(project, ctx, &diags)
+	return diags
+}
+
+// builtinRules lists the structural checks in the order they are run
+var builtinRules = []Rule{
+	ruleFunc{"required-field", "Top-level required fields are present", checkRequiredFields},
+	ruleFunc{"slug-format", "slug is lowercase alphanumeric with hyphens", checkSlugFormat},
+	ruleFunc{"project-lead-format", "project_lead is a GitHub handle or org/team-name", checkProjectLeadFormat},
+	ruleFunc{"slack-channel-prefix", "cncf_slack_channel starts with '#'", checkSlackChannelPrefix},
+	ruleFunc{"schema-version-supported", "schema_version is supported by this validator", checkSchemaVersionSupported},
+	ruleFunc{"maturity-log-phase", "maturity_log phases are valid maturity phases", checkMaturityLogPhase},
+	ruleFunc{"maturity-log-date", "maturity_log entries have a date", checkMaturityLogDate},
+	ruleFunc{"maturity-log-issue", "maturity_log entries name their TOC issue", checkMaturityLogIssue},
+	ruleFunc{"maturity-log-order", "maturity_log entries are in chronological order", checkMaturityLogOrder},
+	ruleFunc{"url-format", "URL fields hold valid http(s) URLs", checkURLFormat},
+	ruleFunc{"audit-entry", "audits entries have a date, type and URL", checkAuditEntries},
+	ruleFunc{"pathref-path", "Path references have a non-empty path", checkPathRefs},
+	ruleFunc{"security-contact", "security.contact has a valid email or advisory URL", checkSecurityContact},
+	contextRuleFunc{"advisory-url-pattern", "security.contact.advisory_url is a GitHub Security Advisory or GitLab confidential issue URL", checkAdvisoryURLPattern},
+	ruleFunc{"identity-type-consistency", "legal.identity_type DCO/CLA flags are consistent", checkIdentityTypeConsistency},
+	ruleFunc{"landscape-required", "landscape has a category and subcategory", checkLandscapeRequired},
+}
+
+// ruleAliases maps other accepted IDs to the ID a built-in rule is registered
+// under, so suppressions and -disable-rules written against them keep working
+var ruleAliases = map[string]string{
+	"maturity-log-issue-url": "maturity-log-issue",
+}
+
+// canonicalRuleID resolves a rule ID alias
+func canonicalRuleID(id string) string {
+	if canonical, ok := ruleAliases[id]; ok {
+		return canonical
+	}
+	return id
+}
+
+// RuleRegistry holds the rules run during validation and which of them are
+// enabled
+type RuleRegistry struct {
+	rules    []Rule
+	disabled map[string]bool
+}
+
+// NewRuleRegistry creates an empty rule registry
+func NewRuleRegistry() *RuleRegistry {
+	return &RuleRegistry{disabled: make(map[string]bool)}
+}
+
+// DefaultRuleRegistry creates a registry with every built-in structural rule enabled
+func DefaultRuleRegistry() *RuleRegistry {
+	r := NewRuleRegistry()
+	for _, rule := range builtinRules {
+		r.rules = append(r.rules, rule)
+	}
+	return r
+}
+
+// Register adds a rule to the registry. Rule IDs must be unique.
+func (r *RuleRegistry) Register(rule Rule) error {
+	if rule.ID() == "" || rule.ID() == suppressionRule || ruleAliases[rule.ID()] != "" {
+		return fmt.Errorf("invalid rule ID %q", rule.ID())
+	}
+	if r.Rule(rule.ID()) != nil {
+		return fmt.Errorf("rule %q is already registered", rule.ID())
+	}
+	r.rules = append(r.rules, rule)
+	return nil
+}
+
+// Rules returns the registered rules in the order they are run
+func (r *RuleRegistry) Rules() []Rule {
+	return append([]Rule(nil), r.rules...)
+}
+
+// Rule returns the rule with the given ID or alias, or nil if it is not
+// registered
+func (r *RuleRegistry) Rule(id string) Rule {
+	id = canonicalRuleID(id)
+	for _, rule := range r.rules {
+		if rule.ID() == id {
+			return rule
+		}
+	}
+	return nil
+}
+
+// Enabled reports whether the rule with the given ID is registered and enabled
+func (r *RuleRegistry) Enabled(id string) bool {
+	return r.Rule(id) != nil && !r.disabled[canonicalRuleID(id)]
+}
+
+// Enable turns a previously disabled rule back on
+func (r *RuleRegistry) Enable(id string) error {
+	if r.Rule(id) == nil {
+		return fmt.Errorf("unknown rule %q", id)
+	}
+	delete(r.disabled, canonicalRuleID(id))
+	return nil
+}
+
+// Disable stops a rule from running
+func (r *RuleRegistry) Disable(id string) error {
+	if r.Rule(id) == nil {
+		return fmt.Errorf("unknown rule %q", id)
+	}
+	r.disabled[canonicalRuleID(id)] = true
+	return nil
+}
+
+// Run checks a project against every enabled rule, then drops findings
+// waived by the project's validation.ignore entries and any extra
+// suppressions (e.g., from its projectlist.yaml entry)
+func (r *RuleRegistry) Run(project Project, extra ...RuleSuppression) []Diagnostic {
+	return r.run(project, ruleContext{}, extra)
+}
+
+// run is Run with the context passed to contextRules
+func (r *RuleRegistry) run(project Project, ctx ruleContext, extra []RuleSuppression) []Diagnostic {
+	var diags []Diagnostic
+	for _, rule := range r.rules {
+		if r.disabled[rule.ID()] {
+			continue
+		}
+		if cr, ok := rule.(contextRule); ok {
+			diags = append(diags, cr.checkContext(project, ctx)...)
+		} else {
+			diags = append(diags, rule.Check(project)...)
+		}
+	}
+
+	var suppressions []RuleSuppression
+	var sources []string
+	if project.Validation != nil {
+		for i, s := range project.Validation.Ignore {
+			suppressions = append(suppressions, s)
+			sources = append(sources, fmt.Sprintf("validation.ignore[%d]", i))
+		}
+	}
+	for i, s := range extra {
+		suppressions = append(suppressions, s)
+		sources = append(sources, fmt.Sprintf("projectlist validation.ignore[%d]", i))
+	}
+	return r.applySuppressions(diags, suppressions, sources)
+}
+
+// applySuppressions removes diagnostics matched by a suppression and reports
+// suppressions that name unknown rules (warning) or carry no reason (info).
+// sources names where each suppression came from for those reports.
+func (r *RuleRegistry) applySuppressions(diags []Diagnostic, suppressions []RuleSuppression, sources []string) []Diagnostic {
+	if len(suppressions) == 0 {
+		return diags
+	}
+
+	var kept []Diagnostic
+	for _, d := range diags {
+		suppressed := false
+		for _, s := range suppressions {
+			if s.Matches(d) {
+				suppressed = true
+				break
+			}
+		}
+		if !suppressed {
+			kept = append(kept, d)
+		}
+	}
+
+	var notes diagnosticSet
+	for i, s := range suppressions {
+		// Suppressions from project.yaml are located on the entry itself;
+		// projectlist.yaml entries are not part of the document
+		path := ""
+		if strings.HasPrefix(sources[i], "validation.") {
+			path = sources[i]
+		}
+		// Rules that need a ProjectValidator are not in every registry, but
+		// suppressing them is still valid
+		if r.Rule(s.Rule) == nil && !isValidatorRule(s.Rule) {
+			notes.add(SeverityWarning, suppressionRule, path, "%s suppresses unknown rule %q", sources[i], s.Rule)
+		} else if strings.TrimSpace(s.Reason) == "" {
+			notes.add(SeverityInfo, suppressionRule, path, "%s suppresses %s without a reason", sources[i], s.Rule)
+		}
+	}
+	return append(kept, notes...)
+}
+
+// Matches reports whether the suppression waives a diagnostic. A suppression
+// with a path only waives findings at that path or below it.
+func (s RuleSuppression) Matches(d Diagnostic) bool {
+	if d.Rule != canonicalRuleID(s.Rule) || d.Rule == suppressionRule {
+		return false
+	}
+	if s.Path == "" {
+		return true
+	}
+	return d.Path == s.Path || strings.HasPrefix(d.Path, s.Path+".") || strings.HasPrefix(d.Path, s.Path+"[")
+}
+
+// UnmarshalYAML accepts either a bare rule ID or a mapping with rule, path and reason
+func (s *RuleSuppression) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*s = RuleSuppression{Rule: node.Value}
+		return nil
+	}
+	type plain RuleSuppression
+	var p plain
+	if err := node.Decode(&p); err != nil {
+		return err
+	}
+	*s = RuleSuppression(p)
+	return nil
+}
+
+func checkRequiredFields(project Project, diags *diagnosticSet) {
+	if project.Name == "" {
+		diags.errorf("required-field", "name", "name is required")
+	}
+	if project.Description == "" {
+		diags.errorf("required-field", "description", "description is required")
+	}
+	if project.Slug == "" {
+		diags.errorf("required-field", "slug", "slug is required")
+	}
+	if project.SchemaVersion == "" {
+		diags.errorf("required-field", "schema_version", "schema_version is required")
+	}
+	if len(project.MaturityLog) == 0 {
+		diags.errorf("required-field", "maturity_log", "maturity_log is required and cannot be empty")
+	}
+	if len(project.Repositories) == 0 {
+		diags.errorf("required-field", "repositories", "repositories is required and cannot be empty")
+	}
+}
+
+func checkSlugFormat(project Project, diags *diagnosticSet) {
+	if project.Slug != "" && !isValidSlug(project.Slug) {
+		diags.errorf("slug-format", "slug", "slug must be lowercase alphanumeric with hyphens, got: %s", project.Slug)
+	}
+}
+
+// checkProjectLeadFormat accepts either a GitHub handle (e.g., "jdoe") or a
+// GitHub team (e.g., "org/team-name")
+func checkProjectLeadFormat(project Project, diags *diagnosticSet) {
+	if project.ProjectLead == "" {
+		return
+	}
+	lead := strings.TrimSpace(project.ProjectLead)
+	lead = strings.TrimPrefix(lead, "@")
+	if lead == "" {
+		diags.errorf("project-lead-format", "project_lead", "project_lead cannot be empty or just '@'")
+	} else if strings.Contains(lead, "/") {
+		parts := strings.Split(lead, "/")
+		if len(parts) != 2 {
+			diags.errorf("project-lead-format", "project_lead", "project_lead team format must be org/team-name (got too many segments): %s", project.ProjectLead)
+		} else if parts[0] == "" {
+			diags.errorf("project-lead-format", "project_lead", "project_lead team format requires a non-empty org (expected org/team-name): %s", project.ProjectLead)
+		} else if parts[1] == "" {
+			diags.errorf("project-lead-format", "project_lead", "project_lead team format requires a non-empty team name (expected org/team-name): %s", project.ProjectLead)
+		}
+	}
+}
+
+func checkSlackChannelPrefix(project Project, diags *diagnosticSet) {
+	if project.CNCFSlackChannel != "" && !strings.HasPrefix(project.CNCFSlackChannel, "#") {
+		diags.errorf("slack-channel-prefix", "cncf_slack_channel", "cncf_slack_channel must start with '#', got: %s", project.CNCFSlackChannel)
+	}
+}
+
+func checkSchemaVersionSupported(project Project, diags *diagnosticSet) {
+	if project.SchemaVersion == "" {
+		return
+	}
+	for _, v := range SupportedSchemaVersions {
+		if project.SchemaVersion == v {
+			return
+		}
+	}
+	diags.errorf("schema-version-supported", "schema_version", "unsupported schema_version: %s (supported: %v)", project.SchemaVersion, SupportedSchemaVersions)
+}
+
+func checkMaturityLogPhase(project Project, diags *diagnosticSet) {
+	for i, entry := range project.MaturityLog {
+		path := fmt.Sprintf("maturity_log[%d].phase", i)
+		if entry.Phase == "" {
+			diags.errorf("maturity-log-phase", path, "maturity_log[%d].phase is required", i)
+		} else if !ValidMaturityPhases[entry.Phase] {
+			diags.errorf("maturity-log-phase", path, "maturity_log[%d].phase has invalid value %q (allowed: sandbox, incubating, graduated, archived)", i, entry.Phase)
+		}
+	}
+}
+
+func checkMaturityLogDate(project Project, diags *diagnosticSet) {
+	for i, entry := range project.MaturityLog {
+		if entry.Date.IsZero() {
+			diags.errorf("maturity-log-date", fmt.Sprintf("maturity_log[%d].date", i), "maturity_log[%d].date is required", i)
+		}
+	}
+}
+
+func checkMaturityLogIssue(project Project, diags *diagnosticSet) {
+	for i, entry := range project.MaturityLog {
+		if entry.Issue == "" {
+			diags.errorf("maturity-log-issue", fmt.Sprintf("maturity_log[%d].issue", i), "maturity_log[%d].issue is required", i)
+		}
+	}
+}
+
+func checkMaturityLogOrder(project Project, diags *diagnosticSet) {
+	for i := 1; i < len(project.MaturityLog); i++ {
+		prev, cur := project.MaturityLog[i-1].Date, project.MaturityLog[i].Date
+		if !prev.IsZero() && !cur.IsZero() && cur.Before(prev) {
+			diags.errorf("maturity-log-order", fmt.Sprintf("maturity_log[%d].date", i), "maturity_log[%d].date (%s) is before maturity_log[%d].date (%s); entries must be in chronological order",
+				i, cur.Format("2006-01-02"), i-1, prev.Format("2006-01-02"))
+		}
+	}
+}
+
+func checkURLFormat(project Project, diags *diagnosticSet) {
+	for i, repo := range project.Repositories {
+		if !isValidURL(repo) {
+			diags.errorf("url-format", fmt.Sprintf("repositories[%d]", i), "repositories[%d] is not a valid URL: %s", i, repo)
+		}
+	}
+	if project.Website != "" && !isValidURL(project.Website) {
+		diags.errorf("url-format", "website", "website is not a valid URL: %s", project.Website)
+	}
+	if project.Artwork != "" && !isValidURL(project.Artwork) {
+		diags.errorf("url-format", "artwork", "artwork is not a valid URL: %s", project.Artwork)
+	}
+	for _, platform := range sortedKeys(project.Social) {
+		url := project.Social[platform]
+		if !isValidURL(url) {
+			diags.errorf("url-format", "social."+platform, "social.%s is not a valid URL: %s", platform, url)
+		}
+	}
+	for i, audit := range project.Audits {
+		if audit.URL != "" && !isValidURL(audit.URL) {
+			diags.errorf("url-format", fmt.Sprintf("audits[%d].url", i), "audits[%d].url is not a valid URL: %s", i, audit.URL)
+		}
+	}
+	if project.Governance != nil {
+		for i, u := range project.Governance.MaintainerLifecycle.MentoringProgram {
+			if !isValidURL(u) {
+				diags.errorf("url-format", fmt.Sprintf("governance.maintainer_lifecycle.mentoring_program[%d]", i), "governance.maintainer_lifecycle.mentoring_program[%d] is not a valid URL: %s", i, u)
+			}
+		}
+	}
+}
+
+func checkAuditEntries(project Project, diags *diagnosticSet) {
+	for i, audit := range project.Audits {
+		path := fmt.Sprintf("audits[%d]", i)
+		if audit.Date.IsZero() {
+			diags.errorf("audit-entry", path+".date", "audits[%d].date is required", i)
+		}
+		if audit.Type == "" {
+			diags.errorf("audit-entry", path+".type", "audits[%d].type is required", i)
+		}
+		if audit.URL == "" {
+			diags.errorf("audit-entry", path+".url", "audits[%d].url is required", i)
+		}
+	}
+}
+
+func checkPathRefs(project Project, diags *diagnosticSet) {
+	checkPathRef(diags, "adopters", project.Adopters)
+
+	if project.Security != nil {
+		checkPathRef(diags, "security.policy", project.Security.Policy)
+		checkPathRef(diags, "security.threat_model", project.Security.ThreatModel)
+	}
+
+	if project.Governance != nil {
+		checkPathRef(diags, "governance.contributing", project.Governance.Contributing)
+		checkPathRef(diags, "governance.codeowners", project.Governance.Codeowners)
+		checkPathRef(diags, "governance.governance_doc", project.Governance.GovernanceDoc)
+
+		// Governance DD PathRef fields
+		checkPathRef(diags, "governance.vendor_neutrality_statement", project.Governance.VendorNeutralityStatement)
+		checkPathRef(diags, "governance.decision_making_process", project.Governance.DecisionMakingProcess)
+		checkPathRef(diags, "governance.roles_and_teams", project.Governance.RolesAndTeams)
+		checkPathRef(diags, "governance.code_of_conduct", project.Governance.CodeOfConduct)
+		checkPathRef(diags, "governance.sub_project_list", project.Governance.SubProjectList)
+		checkPathRef(diags, "governance.sub_project_docs", project.Governance.SubProjectDocs)
+		checkPathRef(diags, "governance.contributor_ladder", project.Governance.ContributorLadder)
+		checkPathRef(diags, "governance.change_process", project.Governance.ChangeProcess)
+		checkPathRef(diags, "governance.comms_channels", project.Governance.CommsChannels)
+		checkPathRef(diags, "governance.community_calendar", project.Governance.CommunityCalendar)
+		checkPathRef(diags, "governance.contributor_guide", project.Governance.ContributorGuide)
+
+		ml := project.Governance.MaintainerLifecycle
+		checkPathRef(diags, "governance.maintainer_lifecycle.onboarding_doc", ml.OnboardingDoc)
+		checkPathRef(diags, "governance.maintainer_lifecycle.progression_ladder", ml.ProgressionLadder)
+		checkPathRef(diags, "governance.maintainer_lifecycle.offboarding_policy", ml.OffboardingPolicy)
+	}
+
+	if project.Legal != nil {
+		checkPathRef(diags, "legal.license", project.Legal.License)
+		if project.Legal.IdentityType != nil {
+			checkPathRef(diags, "legal.identity_type.dco_url", project.Legal.IdentityType.DCOURL)
+			checkPathRef(diags, "legal.identity_type.cla_url", project.Legal.IdentityType.CLAURL)
+		}
+	}
+
+	if project.Documentation != nil {
+		checkPathRef(diags, "documentation.readme", project.Documentation.Readme)
+		checkPathRef(diags, "documentation.support", project.Documentation.Support)
+		checkPathRef(diags, "documentation.architecture", project.Documentation.Architecture)
+		checkPathRef(diags, "documentation.api", project.Documentation.API)
+	}
+}
+
+// checkPathRef reports a PathRef that is present but has an empty path
+func checkPathRef(diags *diagnosticSet, field string, ref *PathRef) {
+	if ref != nil && ref.Path == "" {
+		diags.errorf("pathref-path", field+".path", "%s.path is required", field)
+	}
+}
+
+func checkSecurityContact(project Project, diags *diagnosticSet) {
+	if project.Security == nil || project.Security.Contact == nil {
+		return
+	}
+	contact := project.Security.Contact
+	if contact.Email == "" && contact.AdvisoryURL == "" {
+		diags.errorf("security-contact", "security.contact", "security.contact must have at least one of email or advisory_url")
+	}
+	if contact.Email != "" {
+		if _, err := mail.ParseAddress(contact.Email); err != nil {
+			diags.errorf("security-contact", "security.contact.email", "security.contact.email is not a valid email: %s", contact.Email)
+		}
+	}
+}
+
+func checkAdvisoryURLPattern(project Project, ctx ruleContext, diags *diagnosticSet) {
+	if project.Security == nil || project.Security.Contact == nil || project.Security.Contact.AdvisoryURL == "" {
+		return
+	}
+	if !isAdvisoryURL(project.Security.Contact.AdvisoryURL, ctx.advisoryForges...) {
+		diags.errorf("advisory-url-pattern", "security.contact.advisory_url", "security.contact.advisory_url must be a valid GitHub Security Advisory URL (https://github.com/{org}/{repo}/security/advisories/new) or GitLab confidential issue URL (https://gitlab.com/{group}/{project}/-/issues/new?issue[confidential]=true), got: %s", project.Security.Contact.AdvisoryURL)
+	}
+}
+
+func checkIdentityTypeConsistency(project Project, diags *diagnosticSet) {
+	if project.Legal == nil || project.Legal.IdentityType == nil {
+		return
+	}
+	it := project.Legal.IdentityType
+	if it.HasCLA && !it.HasDCO && !it.CLAOnly {
+		diags.errorf("identity-type-consistency", "legal.identity_type.has_cla", "legal.identity_type: has_cla requires has_dco (CLA cannot be used without DCO; set cla_only: true if this project has an exception)")
+	}
+	if it.CLAOnly && !it.HasCLA {
+		diags.errorf("identity-type-consistency", "legal.identity_type.cla_only", "legal.identity_type: cla_only requires has_cla to be true")
+	}
+}
+
+func checkLandscapeRequired(project Project, diags *diagnosticSet) {
+	if project.Landscape == nil {
+		return
+	}
+	if project.Landscape.Category == "" {
+		diags.errorf("landscape-required", "landscape.category", "landscape.category is required when landscape section is present")
+	}
+	if project.Landscape.Subcategory == "" {
+		diags.errorf("landscape-required", "landscape.subcategory", "landscape.subcategory is required when landscape section is present")
+	}
+}
+
+// sortedKeys returns the keys of a string map in sorted order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,200 @@
+package projects
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestDefaultRuleRegistry(t *testing.T) {
+	r := DefaultRuleRegistry()
+	seen := make(map[string]bool)
+	for _, rule := range r.Rules() {
+		if seen[rule.ID()] {
+			t.Errorf("duplicate rule ID %q", rule.ID())
+		}
+		seen[rule.ID()] = true
+		if rule.Description() == "" {
+			t.Errorf("rule %q has no description", rule.ID())
+		}
+	}
+	for _, id := range []string{"slug-format", "slack-channel-prefix", "identity-type-consistency", "advisory-url-pattern", "maturity-log-issue"} {
+		if !r.Enabled(id) {
+			t.Errorf("expected built-in rule %q to be enabled", id)
+		}
+	}
+
+	if err := r.Register(ruleFunc{id: "slug-format"}); err == nil {
+		t.Error("expected error registering a duplicate rule ID")
+	}
+	if err := r.Disable("not-a-rule"); err == nil {
+		t.Error("expected error disabling an unknown rule")
+	}
+}
+
+func TestRuleRegistryDisable(t *testing.T) {
+	project := validBaseProject()
+	project.Slug = "Bad_Slug"
+	project.CNCFSlackChannel = "general"
+
+	r := DefaultRuleRegistry()
+	if err := r.Disable("slug-format"); err != nil {
+		t.Fatalf("Disable: %v", err)
+	}
+	diags := r.Run(project)
+	if len(diags) != 1 || diags[0].Rule != "slack-channel-prefix" {
+		t.Errorf("expected only slack-channel-prefix, got: %v", diags)
+	}
+
+	if err := r.Enable("slug-format"); err != nil {
+		t.Fatalf("Enable: %v", err)
+	}
+	if got := len(r.Run(project)); got != 2 {
+		t.Errorf("expected 2 diagnostics after re-enabling, got %d", got)
+	}
+}
+
+func TestMaturityLogIssue(t *testing.T) {
+	project := validBaseProject()
+	project.MaturityLog[0].Issue = ""
+
+	diags := validateProjectDiagnostics(project)
+	if len(diags) != 1 || diags[0].Rule != "maturity-log-issue" || diags[0].Path != "maturity_log[0].issue" {
+		t.Errorf("expected maturity-log-issue diagnostic, got: %v", diags)
+	}
+}
+
+func TestRuleSuppressions(t *testing.T) {
+	t.Run("bare rule ID and mapping forms", func(t *testing.T) {
+		var cfg ValidationConfig
+		content := `ignore:
+  - maturity-log-issue
+  - rule: pathref-path
+    path: governance
+    reason: Governance docs are being written
+`
+		if err := yaml.Unmarshal([]byte(content), &cfg); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if len(cfg.Ignore) != 2 {
+			t.Fatalf("expected 2 suppressions, got %d", len(cfg.Ignore))
+		}
+		if cfg.Ignore[0].Rule != "maturity-log-issue" || cfg.Ignore[0].Reason != "" {
+			t.Errorf("unexpected bare suppression: %+v", cfg.Ignore[0])
+		}
+		if cfg.Ignore[1].Rule != "pathref-path" || cfg.Ignore[1].Path != "governance" || cfg.Ignore[1].Reason == "" {
+			t.Errorf("unexpected mapping suppression: %+v", cfg.Ignore[1])
+		}
+	})
+
+	t.Run("suppressed findings are dropped", func(t *testing.T) {
+		project := validBaseProject()
+		project.MaturityLog[0].Issue = ""
+		project.Adopters = &PathRef{}
+		project.Governance = &GovernanceConfig{Contributing: &PathRef{}}
+		project.Validation = &ValidationConfig{Ignore: []RuleSuppression{
+			{Rule: "maturity-log-issue", Reason: "Accepted before TOC issues were tracked"},
+			{Rule: "pathref-path", Path: "governance", Reason: "Governance docs are being written"},
+		}}
+
+		diags := validateProjectDiagnostics(project)
+		if len(diags) != 1 || diags[0].Path != "adopters.path" {
+			t.Errorf("expected only adopters.path to remain, got: %v", diags)
+		}
+	})
+
+	t.Run("rule ID aliases", func(t *testing.T) {
+		project := validBaseProject()
+		project.MaturityLog[0].Issue = ""
+		project.Validation = &ValidationConfig{Ignore: []RuleSuppression{
+			{Rule: "maturity-log-issue-url", Reason: "Accepted before TOC issues were tracked"},
+		}}
+
+		if diags := validateProjectDiagnostics(project); len(diags) != 0 {
+			t.Errorf("expected the alias to suppress maturity-log-issue, got: %v", diags)
+		}
+		registry := DefaultRuleRegistry()
+		if err := registry.Disable("maturity-log-issue-url"); err != nil || registry.Enabled("maturity-log-issue") {
+			t.Errorf("expected the alias to disable maturity-log-issue, got %v", err)
+		}
+	})
+
+	t.Run("suppressions are reported when unknown or unjustified", func(t *testing.T) {
+		project := validBaseProject()
+		project.Validation = &ValidationConfig{Ignore: []RuleSuppression{
+			{Rule: "slug-format"},
+			{Rule: "no-such-rule", Reason: "typo"},
+			{Rule: "requirement-profile", Reason: "known on validators"},
+		}}
+
+		diags := validateProjectDiagnostics(project)
+		counts := CountDiagnostics(diags)
+		if counts[SeverityInfo] != 1 || counts[SeverityWarning] != 1 || counts[SeverityError] != 0 {
+			t.Fatalf("expected 1 info and 1 warning, got: %v", diags)
+		}
+		for _, d := range diags {
+			if d.Rule != "suppression" {
+				t.Errorf("expected suppression diagnostics, got: %v", d)
+			}
+			if d.Severity == SeverityWarning && d.Path != "validation.ignore[1]" {
+				t.Errorf("expected unknown rule to point at its entry, got path %q", d.Path)
+			}
+		}
+		if messages := validateProjectStruct(project); len(messages) != 0 {
+			t.Errorf("suppression notes should not be reported as errors: %v", messages)
+		}
+	})
+}
+
+func TestProjectListSuppressions(t *testing.T) {
+	dir := t.TempDir()
+	projPath := filepath.Join(dir, "project.yaml")
+	writeFile(t, projPath, strings.Replace(validProjectYAML(), "slug: test-project", "slug: Test_Project", 1))
+
+	listPath := filepath.Join(dir, "projectlist.yaml")
+	writeFile(t, listPath, `projects:
+  - url: "file://`+projPath+`"
+    validation:
+      ignore:
+        - rule: slug-format
+          reason: Slug predates the naming rules
+`)
+
+	pv := NewValidator(filepath.Join(dir, "cache"))
+	results, err := pv.ValidateAll(listPath)
+	if err != nil {
+		t.Fatalf("ValidateAll: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if !results[0].Valid || len(results[0].Diagnostics) != 0 {
+		t.Errorf("expected slug-format to be suppressed by the project list entry, got: %v", results[0].Diagnostics)
+	}
+}
+
+func TestValidatorRulesIncludeRequirementProfile(t *testing.T) {
+	pv := newTestValidator(t)
+	if !pv.Rules().Enabled("requirement-profile") {
+		t.Fatal("expected requirement-profile rule on validator registry")
+	}
+	if err := pv.SetTargetPhase("graduated"); err != nil {
+		t.Fatalf("SetTargetPhase: %v", err)
+	}
+	if err := pv.Rules().Disable("requirement-profile"); err != nil {
+		t.Fatalf("Disable: %v", err)
+	}
+
+	dir := t.TempDir()
+	projPath := filepath.Join(dir, "project.yaml")
+	writeFile(t, projPath, validProjectYAML())
+	result, err := pv.validateProject("file://" + projPath)
+	if err != nil {
+		t.Fatalf("validateProject: %v", err)
+	}
+	if !result.Valid {
+		t.Errorf("disabled requirement-profile rule should not run, got: %v", result.Errors)
+	}
+}
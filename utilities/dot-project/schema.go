@@ -0,0 +1,169 @@
+package projects
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// JSONSchema is the subset of JSON Schema (draft 2020-12) used to describe
+// project.yaml
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	ID                   string                 `json:"$id,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"` // false or *JSONSchema
+	AnyOf                []*JSONSchema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*JSONSchema `json:"$defs,omitempty"`
+}
+
+// schemaBuilder collects $defs while walking Go types
+type schemaBuilder struct {
+	defs         map[string]*JSONSchema
+	used         map[string]bool // fieldSchemas and typeSchemas keys that matched a type or field
+	undocumented []string        // fields without a fieldSchemas description
+}
+
+// ProjectJSONSchema derives the project.yaml JSON Schema from the Project
+// type, using fieldSchemas and typeSchemas for descriptions and constraints
+func ProjectJSONSchema() (*JSONSchema, error) {
+	b := &schemaBuilder{defs: make(map[string]*JSONSchema), used: make(map[string]bool)}
+
+	root := b.objectSchema(reflect.TypeOf(Project{}))
+	root.Schema = "https://json-schema.org/draft/2020-12/schema"
+	root.ID = fmt.Sprintf("https://github.com/cncf/automation/utilities/dot-project/schema/v%s/project.json", LatestSchemaVersion())
+	root.Title = "CNCF Project Metadata"
+	root.Description = "Schema for CNCF .project repository project.yaml files"
+	root.Defs = b.defs
+
+	// A new field must be described before it ships
+	if len(b.undocumented) > 0 {
+		sort.Strings(b.undocumented)
+		return nil, fmt.Errorf("fields have no description in fieldSchemas: %v", b.undocumented)
+	}
+
+	// Annotations that match nothing are stale after a field rename
+	var stale []string
+	for key := range fieldSchemas {
+		if !b.used[key] {
+			stale = append(stale, key)
+		}
+	}
+	for key := range typeSchemas {
+		if !b.used[key] {
+			stale = append(stale, key)
+		}
+	}
+	if len(stale) > 0 {
+		sort.Strings(stale)
+		return nil, fmt.Errorf("schema annotations do not match any type or field: %v", stale)
+	}
+	return root, nil
+}
+
+// GenerateProjectSchema renders the project.yaml JSON Schema as indented JSON,
+// the content of schema/project.schema.json
+func GenerateProjectSchema() ([]byte, error) {
+	schema, err := ProjectJSONSchema()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(schema); err != nil {
+		return nil, fmt.Errorf("failed to encode schema: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// objectSchema describes a struct type as a closed object. Unknown keys are
+// rejected to match the validator's strict YAML decoding.
+func (b *schemaBuilder) objectSchema(t reflect.Type) *JSONSchema {
+	schema := &JSONSchema{
+		Type:                 "object",
+		Properties:           make(map[string]*JSONSchema),
+		AdditionalProperties: false,
+	}
+	if ann, ok := typeSchemas[t.Name()]; ok {
+		b.used[t.Name()] = true
+		schema.Description = ann.Description
+		for _, required := range ann.AnyOfRequired {
+			schema.AnyOf = append(schema.AnyOf, &JSONSchema{Required: required})
+		}
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := yamlFieldName(field)
+		if name == "" {
+			continue
+		}
+		key := t.Name() + "." + name
+		ann, ok := fieldSchemas[key]
+		if ok {
+			b.used[key] = true
+		}
+		if ann.Description == "" {
+			b.undocumented = append(b.undocumented, key)
+		}
+		prop := b.valueSchema(field.Type, ann)
+		prop.Description = ann.Description
+		schema.Properties[name] = prop
+		if ann.Required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
+
+// valueSchema describes a field's type. Struct types become $defs entries
+// referenced by name; Format applies to strings and to the string elements of
+// slices and maps.
+func (b *schemaBuilder) valueSchema(t reflect.Type, ann fieldSchema) *JSONSchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return &JSONSchema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		name := t.Name()
+		if _, exists := b.defs[name]; !exists {
+			b.defs[name] = nil // reserve the name before recursing
+			b.defs[name] = b.objectSchema(t)
+		}
+		ref := &JSONSchema{Ref: "#/$defs/" + name}
+		if shorthand := typeSchemas[name].Shorthand; shorthand != "" {
+			return &JSONSchema{AnyOf: []*JSONSchema{{Type: "string", Description: shorthand}, ref}}
+		}
+		return ref
+	case reflect.Slice, reflect.Array:
+		return &JSONSchema{Type: "array", Items: b.valueSchema(t.Elem(), fieldSchema{Format: ann.Format})}
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: b.valueSchema(t.Elem(), fieldSchema{Format: ann.Format})}
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	default:
+		return &JSONSchema{Type: "string", Format: ann.Format, Pattern: ann.Pattern, Enum: ann.Enum}
+	}
+}
@@ -0,0 +1,129 @@
+package projects
+
+// fieldSchema annotates a struct field in the generated JSON Schema
+type fieldSchema struct {
+	Description string
+	Required    bool
+	Format      string // Format of a string, or of the string elements of a slice or map
+	Pattern     string
+	Enum        []string
+	Links       bool // Values that are HTTP URLs are audited as links, for fields also holding non-URLs
+}
+
+// typeSchema annotates a struct type in the generated JSON Schema
+type typeSchema struct {
+	Description   string
+	AnyOfRequired [][]string // Alternative sets of required fields
+	Shorthand     string     // Describes a bare string also accepted in place of the object
+}
+
+// slugPattern mirrors isValidSlug
+const slugPattern = "^[a-z0-9][a-z0-9-]*[a-z0-9]$|^[a-z0-9]$"
+
+// fieldSchemas holds schema annotations keyed by "<Go type>.<yaml key>".
+// Every key must match a field reachable from Project, and every such field
+// needs a described entry; ProjectJSONSchema fails otherwise so renamed fields
+// cannot leave stale entries behind and new fields cannot ship undocumented.
+var fieldSchemas = map[string]fieldSchema{
+	"Project.schema_version":     {Description: "Schema version", Required: true, Enum: SupportedSchemaVersions},
+	"Project.slug":               {Description: "Unique project identifier", Required: true, Pattern: slugPattern},
+	"Project.name":               {Description: "Project display name", Required: true},
+	"Project.description":        {Description: "One-line project description", Required: true},
+	"Project.type":               {Description: "Project type (e.g., project, platform, specification)"},
+	"Project.project_lead":       {Description: "GitHub handle or team (org/team-name) of primary contact"},
+	"Project.cncf_slack_channel": {Description: "CNCF Slack channel", Pattern: "^#"},
+	"Project.maturity_log":       {Description: "Maturity phase transition history (chronological order)", Required: true},
+	"Project.repositories":       {Description: "Repository URLs", Required: true, Format: "uri"},
+	"Project.website":            {Description: "Project website URL", Format: "uri"},
+	"Project.artwork":            {Description: "Artwork/logo URL", Format: "uri"},
+	"Project.social":             {Description: "Social platform URLs", Format: "uri"},
+	"Project.mailing_lists":      {Description: "Mailing list addresses", Links: true},
+	"Project.audits":             {Description: "Security/performance audits"},
+	"Project.adopters":           {Description: "Link to ADOPTERS.md or adopters list"},
+	"Project.package_managers":   {Description: "Registry identifiers (e.g., npm package name, Docker Hub image)"},
+	"Project.security":           {Description: "Security configuration"},
+	"Project.governance":         {Description: "Governance configuration"},
+	"Project.legal":              {Description: "Legal configuration"},
+	"Project.documentation":      {Description: "Documentation configuration"},
+	"Project.landscape":          {Description: "CNCF Landscape location"},
+	"Project.validation":         {Description: "Validator settings"},
+
+	"MaturityEntry.phase": {Description: "Maturity phase", Required: true, Enum: MaturityPhases},
+	"MaturityEntry.date":  {Description: "Date of phase transition", Required: true},
+	"MaturityEntry.issue": {Description: "TOC issue URL", Required: true, Links: true},
+
+	"Audit.date": {Description: "Date of the audit", Required: true},
+	"Audit.type": {Description: "Audit type (e.g., security, performance)", Required: true},
+	"Audit.url":  {Description: "URL to the audit report", Required: true, Format: "uri"},
+
+	"PathRef.path": {Description: "File path or URL", Required: true},
+
+	"SecurityConfig.policy":       {Description: "Security policy (e.g., SECURITY.md)"},
+	"SecurityConfig.threat_model": {Description: "Threat model document"},
+	"SecurityConfig.contact":      {Description: "Security contact information"},
+
+	"SecurityContact.email":        {Description: "Security contact email address", Format: "email"},
+	"SecurityContact.advisory_url": {Description: "GitHub Security Advisory or GitLab confidential issue URL", Format: "uri", Pattern: advisoryURLPattern.String()},
+
+	"GovernanceConfig.contributing":                {Description: "Contributing guide (e.g., CONTRIBUTING.md)"},
+	"GovernanceConfig.codeowners":                  {Description: "CODEOWNERS file"},
+	"GovernanceConfig.governance_doc":              {Description: "Governance document (e.g., GOVERNANCE.md)"},
+	"GovernanceConfig.gitvote_config":              {Description: "GitVote configuration"},
+	"GovernanceConfig.vendor_neutrality_statement": {Description: "Vendor neutrality statement"},
+	"GovernanceConfig.decision_making_process":     {Description: "Decision-making process documentation"},
+	"GovernanceConfig.roles_and_teams":             {Description: "Roles and teams documentation"},
+	"GovernanceConfig.code_of_conduct":             {Description: "Code of conduct"},
+	"GovernanceConfig.sub_project_list":            {Description: "Subproject listing"},
+	"GovernanceConfig.sub_project_docs":            {Description: "Subproject documentation"},
+	"GovernanceConfig.contributor_ladder":          {Description: "Contributor ladder documentation"},
+	"GovernanceConfig.change_process":              {Description: "Change process documentation"},
+	"GovernanceConfig.comms_channels":              {Description: "Communication channels listing"},
+	"GovernanceConfig.community_calendar":          {Description: "Community calendar"},
+	"GovernanceConfig.contributor_guide":           {Description: "Contributor guide"},
+	"GovernanceConfig.maintainer_lifecycle":        {Description: "Maintainer lifecycle documentation"},
+
+	"MaintainerLifecycle.onboarding_doc":     {Description: "URL to maintainer onboarding documentation"},
+	"MaintainerLifecycle.progression_ladder": {Description: "URL to maintainer advancement path documentation (committer → maintainer → lead)"},
+	"MaintainerLifecycle.mentoring_program":  {Description: "URLs to mentoring and onboarding support program documentation", Format: "uri"},
+	"MaintainerLifecycle.offboarding_policy": {Description: "URL to emeritus/offboarding policy documentation"},
+
+	"LegalConfig.license":       {Description: "License file"},
+	"LegalConfig.identity_type": {Description: "Contributor identity agreement (DCO, CLA, or none)"},
+
+	"IdentityType.has_dco":  {Description: "Whether the project uses DCO"},
+	"IdentityType.has_cla":  {Description: "Whether the project uses CLA (requires DCO unless cla_only is true)"},
+	"IdentityType.cla_only": {Description: "Exception: allows CLA without DCO for projects with special approval"},
+	"IdentityType.dco_url":  {Description: "Link to DCO document"},
+	"IdentityType.cla_url":  {Description: "Link to CLA document"},
+
+	"DocumentationConfig.readme":       {Description: "README"},
+	"DocumentationConfig.support":      {Description: "Support document (e.g., SUPPORT.md)"},
+	"DocumentationConfig.architecture": {Description: "Architecture documentation"},
+	"DocumentationConfig.api":          {Description: "API documentation"},
+
+	"LandscapeConfig.category":    {Description: "CNCF Landscape category", Required: true},
+	"LandscapeConfig.subcategory": {Description: "CNCF Landscape subcategory", Required: true},
+
+	"ValidationConfig.ignore": {Description: "Validation rules waived for this project"},
+
+	"RuleSuppression.rule":   {Description: "Rule ID (e.g., maturity-log-issue)", Required: true},
+	"RuleSuppression.path":   {Description: "Only waive findings at or below this field path"},
+	"RuleSuppression.reason": {Description: "Justification for the suppression"},
+}
+
+// typeSchemas holds schema annotations keyed by Go type name
+var typeSchemas = map[string]typeSchema{
+	"SecurityContact": {
+		Description:   "Security contact information. At least one of email or advisory_url must be provided.",
+		AnyOfRequired: [][]string{{"email"}, {"advisory_url"}},
+	},
+	"IdentityType": {
+		Description: "Contributor identity agreements. DCO can be used alone or with CLA. CLA requires DCO unless cla_only is true.",
+	},
+	"MaintainerLifecycle": {
+		Description: "Maintainer lifecycle documentation",
+	},
+	"RuleSuppression": {
+		Shorthand: "Rule ID",
+	},
+}
@@ -0,0 +1,79 @@
+package projects
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestProjectSchemaUpToDate(t *testing.T) {
+	want, err := GenerateProjectSchema()
+	if err != nil {
+		t.Fatalf("GenerateProjectSchema: %v", err)
+	}
+	got, err := os.ReadFile("schema/project.schema.json")
+	if err != nil {
+		t.Fatalf("read schema: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("schema/project.schema.json is stale; run: go run ./cmd/generate-schema -output schema/project.schema.json")
+	}
+}
+
+func TestProjectJSONSchema(t *testing.T) {
+	schema, err := ProjectJSONSchema()
+	if err != nil {
+		t.Fatalf("ProjectJSONSchema: %v", err)
+	}
+
+	if schema.AdditionalProperties != false {
+		t.Error("expected top-level object to reject unknown keys")
+	}
+	if len(schema.Required) != 6 {
+		t.Errorf("expected 6 required top-level fields, got %v", schema.Required)
+	}
+	// Every serialized Project field appears as a property
+	if _, ok := schema.Properties["package_managers"]; !ok {
+		t.Error("expected package_managers property derived from types.go")
+	}
+
+	for name, def := range schema.Defs {
+		if def.AdditionalProperties != false {
+			t.Errorf("expected $defs/%s to reject unknown keys", name)
+		}
+	}
+
+	contact := schema.Defs["SecurityContact"]
+	if contact == nil || contact.Properties["advisory_url"].Pattern != advisoryURLPattern.String() {
+		t.Error("expected advisory_url pattern to match the validator's pattern")
+	}
+	phase := schema.Defs["MaturityEntry"].Properties["phase"]
+	if len(phase.Enum) != len(ValidMaturityPhases) {
+		t.Errorf("expected phase enum to list every maturity phase, got %v", phase.Enum)
+	}
+	ignore := schema.Defs["ValidationConfig"].Properties["ignore"]
+	if ignore.Items == nil || len(ignore.Items.AnyOf) != 2 {
+		t.Errorf("expected ignore items to accept a rule ID or a suppression mapping, got %+v", ignore.Items)
+	}
+}
+
+func TestSchemaAnnotationsMatchFields(t *testing.T) {
+	fieldSchemas["Project.no_such_field"] = fieldSchema{Description: "stale"}
+	defer delete(fieldSchemas, "Project.no_such_field")
+
+	if _, err := ProjectJSONSchema(); err == nil {
+		t.Error("expected error for an annotation that matches no field")
+	}
+}
+
+func TestSchemaFieldsHaveAnnotations(t *testing.T) {
+	ann := fieldSchemas["Project.website"]
+	delete(fieldSchemas, "Project.website")
+	defer func() { fieldSchemas["Project.website"] = ann }()
+
+	_, err := ProjectJSONSchema()
+	if err == nil || !strings.Contains(err.Error(), "Project.website") {
+		t.Errorf("expected error naming the undescribed field, got: %v", err)
+	}
+}
@@ -32,6 +32,16 @@ func TestSecurityContactValidation(t *testing.T) {
 			contact:       &SecurityContact{AdvisoryURL: "https://github.com/my-org/my-repo/security/advisories/new"},
 			expectedError: "",
 		},
+		{
+			name:          "Valid GitLab Confidential Issue URL",
+			contact:       &SecurityContact{AdvisoryURL: "https://gitlab.com/my-group/my-project/-/issues/new?issue[confidential]=true"},
+			expectedError: "",
+		},
+		{
+			name:          "Invalid Advisory URL - GitLab Issue Not Confidential",
+			contact:       &SecurityContact{AdvisoryURL: "https://gitlab.com/my-group/my-project/-/issues/new"},
+			expectedError: "security.contact.advisory_url must be a valid GitHub Security Advisory URL",
+		},
 		{
 			name:          "Invalid Advisory URL - Wrong Domain",
 			contact:       &SecurityContact{AdvisoryURL: "https://evil.com/org/repo/security/advisories/new"},
@@ -15,6 +15,19 @@ type StalenessResult struct {
 	ProjectLead          string    `json:"project_lead,omitempty"`
 	SlackChannel         string    `json:"slack_channel,omitempty"`
 	Message              string    `json:"message"`
+
+	Source  string          `json:"source,omitempty"`  // Where the dates come from, e.g. "git:maintainers.yaml"
+	Shallow bool            `json:"shallow,omitempty"` // Dates come from a truncated git history
+	Teams   []TeamStaleness `json:"teams,omitempty"`   // Per-team staleness, from git history
+}
+
+// TeamStaleness is the staleness of one maintainer team
+type TeamStaleness struct {
+	Team            string    `json:"team"`
+	LastChange      time.Time `json:"last_change"`
+	Commit          string    `json:"commit,omitempty"`
+	DaysSinceChange int       `json:"days_since_change"`
+	IsStale         bool      `json:"is_stale"`
 }
 
 // CheckStaleness checks if a project's maintainer data is stale
@@ -55,6 +68,19 @@ func FormatStalenessResults(results []StalenessResult) string {
 			staleCount++
 			b.WriteString(fmt.Sprintf("STALE: %s\n", r.ProjectSlug))
 			b.WriteString(fmt.Sprintf("  Last updated: %s (%d days ago)\n", r.LastMaintainerUpdate.Format("2006-01-02"), r.DaysSinceUpdate))
+			for _, team := range r.Teams {
+				if !team.IsStale {
+					continue
+				}
+				b.WriteString(fmt.Sprintf("  Stale team: %s, last changed %s (%d days ago", team.Team, team.LastChange.Format("2006-01-02"), team.DaysSinceChange))
+				if team.Commit != "" {
+					b.WriteString(", " + shortCommit(team.Commit))
+				}
+				b.WriteString(")\n")
+			}
+			if r.Shallow {
+				b.WriteString("  Note: git history is shallow; dates may be too recent\n")
+			}
 			if r.ProjectLead != "" {
 				b.WriteString(fmt.Sprintf("  Contact: @%s\n", r.ProjectLead))
 			}
@@ -68,3 +94,11 @@ func FormatStalenessResults(results []StalenessResult) string {
 	b.WriteString(fmt.Sprintf("Summary: %d projects checked, %d stale\n", len(results), staleCount))
 	return b.String()
 }
+
+// shortCommit abbreviates a commit hash
+func shortCommit(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
@@ -0,0 +1,265 @@
+package projects
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"gopkg.in/yaml.v3"
+)
+
+// TeamChange is the last change to a maintainer team found in git history
+type TeamChange struct {
+	Team       string    `json:"team"`
+	LastChange time.Time `json:"last_change"`
+	Commit     string    `json:"commit"` // Commit that gave the team its current members
+}
+
+// MaintainersHistory is what the git history of a maintainers file says
+// about one project's entry
+type MaintainersHistory struct {
+	File       string       `json:"file"` // Path of the maintainers file in the repository
+	ProjectID  string       `json:"project_id"`
+	LastChange time.Time    `json:"last_change"` // Last change to any part of the project's entry
+	Commit     string       `json:"commit"`
+	Teams      []TeamChange `json:"teams"`
+	Shallow    bool         `json:"shallow,omitempty"` // History ends at a shallow clone boundary, so dates may be too recent
+}
+
+// fileVersion is a version of a file and the commit that introduced it
+type fileVersion struct {
+	Commit  string
+	When    time.Time
+	Content string
+}
+
+// GitMaintainersHistory reads the history of a maintainers file from the git
+// repository containing it, and finds when the entry of projectID and each of
+// its current teams last changed. The file may be a project's own
+// maintainers.yaml or a shared file with many entries; a file with a single
+// entry is used whatever its project_id. The first-parent history of HEAD is
+// followed, so changes are dated by the commit that landed them.
+func GitMaintainersHistory(file, projectID string) (MaintainersHistory, error) {
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return MaintainersHistory{}, err
+	}
+	repo, err := git.PlainOpenWithOptions(filepath.Dir(absFile), &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return MaintainersHistory{}, fmt.Errorf("failed to open git repository for %s: %w", file, err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return MaintainersHistory{}, err
+	}
+	root, err := filepath.EvalSymlinks(worktree.Filesystem.Root())
+	if err != nil {
+		return MaintainersHistory{}, err
+	}
+	if resolved, err := filepath.EvalSymlinks(absFile); err == nil {
+		absFile = resolved
+	}
+	rel, err := filepath.Rel(root, absFile)
+	if err != nil {
+		return MaintainersHistory{}, err
+	}
+	rel = filepath.ToSlash(rel)
+
+	versions, shallow, err := fileVersions(repo, rel)
+	if err != nil {
+		return MaintainersHistory{}, err
+	}
+	if len(versions) == 0 {
+		return MaintainersHistory{}, fmt.Errorf("%s is not committed in %s", rel, root)
+	}
+
+	entries := make([]*MaintainerEntry, len(versions))
+	for i, v := range versions {
+		entries[i] = findMaintainerEntry(v.Content, projectID)
+	}
+	current := entries[0]
+	if current == nil {
+		return MaintainersHistory{}, fmt.Errorf("no entry for project %q in %s", projectID, rel)
+	}
+
+	history := MaintainersHistory{File: rel, ProjectID: current.ProjectID, Shallow: shallow}
+	introduced := lastChange(versions, func(i int) string { return yamlFingerprint(entries[i]) })
+	history.LastChange, history.Commit = versions[introduced].When, versions[introduced].Commit
+
+	for _, team := range current.Teams {
+		name := team.Name
+		introduced := lastChange(versions, func(i int) string {
+			if entries[i] == nil {
+				return ""
+			}
+			for _, t := range entries[i].Teams {
+				if t.Name == name {
+					return yamlFingerprint(t.Members)
+				}
+			}
+			return ""
+		})
+		history.Teams = append(history.Teams, TeamChange{
+			Team:       name,
+			LastChange: versions[introduced].When,
+			Commit:     versions[introduced].Commit,
+		})
+	}
+	return history, nil
+}
+
+// lastChange returns the index of the oldest version that still has the same
+// fingerprint as the newest one, i.e., the version that introduced it
+func lastChange(versions []fileVersion, fingerprint func(i int) string) int {
+	want := fingerprint(0)
+	i := 0
+	for i+1 < len(versions) && fingerprint(i+1) == want {
+		i++
+	}
+	return i
+}
+
+// findMaintainerEntry returns the entry of projectID in maintainers file
+// content, or the only entry of a single-project file
+func findMaintainerEntry(content, projectID string) *MaintainerEntry {
+	var config MaintainersConfig
+	if err := yaml.Unmarshal([]byte(content), &config); err != nil {
+		return nil
+	}
+	for i := range config.Maintainers {
+		if config.Maintainers[i].ProjectID == projectID {
+			return &config.Maintainers[i]
+		}
+	}
+	if len(config.Maintainers) == 1 {
+		return &config.Maintainers[0]
+	}
+	return nil
+}
+
+// yamlFingerprint serializes a value so two versions can be compared
+func yamlFingerprint(v interface{}) string {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// fileVersions follows the first-parent history of HEAD and returns the
+// versions of the file at path, newest first. History stops where the file
+// did not exist, or at the boundary of a shallow clone (reported by shallow).
+func fileVersions(repo *git.Repository, path string) (versions []fileVersion, shallow bool, err error) {
+	head, err := repo.Head()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, false, err
+	}
+
+	var current plumbing.Hash
+	var content string
+	var introducing *object.Commit
+	for commit != nil {
+		file, err := commit.File(path)
+		if err != nil && !errors.Is(err, object.ErrFileNotFound) {
+			return nil, false, err
+		}
+		hash := plumbing.ZeroHash
+		if file != nil {
+			hash = file.Hash
+		}
+
+		if introducing == nil || hash != current {
+			// The newer commit visited last introduced the current version
+			if introducing != nil {
+				versions = append(versions, fileVersion{Commit: introducing.Hash.String(), When: introducing.Committer.When, Content: content})
+			}
+			if file == nil {
+				return versions, false, nil
+			}
+			if content, err = file.Contents(); err != nil {
+				return nil, false, err
+			}
+			current = hash
+		}
+		introducing = commit
+
+		if commit.NumParents() == 0 {
+			break
+		}
+		parent, err := commit.Parent(0)
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			shallow = true
+			break
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		commit = parent
+	}
+	versions = append(versions, fileVersion{Commit: introducing.Hash.String(), When: introducing.Committer.When, Content: content})
+	return versions, shallow, nil
+}
+
+// CheckTeamStaleness checks a project's maintainer staleness from the git
+// history of its maintainers entry. Each team is checked on its own; the
+// project is stale when any team is.
+func CheckTeamStaleness(project Project, history MaintainersHistory, thresholdDays int) StalenessResult {
+	result := CheckStaleness(project, history.LastChange, thresholdDays)
+	result.Source = "git:" + history.File
+	result.Shallow = history.Shallow
+
+	var stale []string
+	for _, change := range history.Teams {
+		days := int(time.Since(change.LastChange).Hours() / 24)
+		team := TeamStaleness{
+			Team:            change.Team,
+			LastChange:      change.LastChange,
+			Commit:          change.Commit,
+			DaysSinceChange: days,
+			IsStale:         days > thresholdDays,
+		}
+		if team.IsStale {
+			stale = append(stale, fmt.Sprintf("%s (%d days)", team.Team, days))
+		}
+		result.Teams = append(result.Teams, team)
+	}
+	sort.SliceStable(result.Teams, func(i, j int) bool {
+		return result.Teams[i].DaysSinceChange > result.Teams[j].DaysSinceChange
+	})
+
+	if len(history.Teams) > 0 {
+		result.IsStale = len(stale) > 0
+	}
+	if len(stale) > 0 {
+		result.Message = fmt.Sprintf("Project %s has %d team(s) not updated in more than %d days: %s",
+			project.Slug, len(stale), thresholdDays, joinWithAnd(stale))
+	}
+	if history.Shallow {
+		result.Message += " (git history is shallow; fetch the full history for accurate dates)"
+	}
+	return result
+}
+
+// joinWithAnd joins items as "a, b and c"
+func joinWithAnd(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	}
+	out := items[0]
+	for _, item := range items[1 : len(items)-1] {
+		out += ", " + item
+	}
+	return out + " and " + items[len(items)-1]
+}
@@ -0,0 +1,181 @@
+package projects
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// gitFixture is a temporary repository whose commits are dated by the test
+type gitFixture struct {
+	t        *testing.T
+	dir      string
+	worktree *git.Worktree
+}
+
+func newGitFixture(t *testing.T) *gitFixture {
+	t.Helper()
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &gitFixture{t: t, dir: dir, worktree: worktree}
+}
+
+// commit writes files (name -> content) and commits them daysAgo days ago
+func (f *gitFixture) commit(daysAgo int, files map[string]string) string {
+	f.t.Helper()
+	for name, content := range files {
+		path := filepath.Join(f.dir, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		writeFile(f.t, path, content)
+		if _, err := f.worktree.Add(name); err != nil {
+			f.t.Fatal(err)
+		}
+	}
+	when := time.Now().AddDate(0, 0, -daysAgo)
+	signature := &object.Signature{Name: "Test", Email: "test@example.com", When: when}
+	hash, err := f.worktree.Commit("update", &git.CommitOptions{Author: signature, Committer: signature, AllowEmptyCommits: true})
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	return hash.String()
+}
+
+func maintainersYAML(entries ...string) string {
+	return "maintainers:\n" + strings.Join(entries, "")
+}
+
+func maintainerEntryYAML(projectID string, teams ...string) string {
+	entry := "  - project_id: " + projectID + "\n    org: test-org\n    teams:\n"
+	for _, team := range teams {
+		name, members, _ := strings.Cut(team, "=")
+		entry += "      - name: " + name + "\n        members:\n"
+		for _, member := range strings.Split(members, ",") {
+			entry += "          - " + member + "\n"
+		}
+	}
+	return entry
+}
+
+func TestGitMaintainersHistory(t *testing.T) {
+	f := newGitFixture(t)
+	f.commit(400, map[string]string{"maintainers.yaml": maintainersYAML(
+		maintainerEntryYAML("test-project", "project-maintainers=alice,bob", "reviewers=carol"))})
+	reviewersChanged := f.commit(100, map[string]string{"maintainers.yaml": maintainersYAML(
+		maintainerEntryYAML("test-project", "project-maintainers=alice,bob", "reviewers=carol,dave"))})
+	// Unrelated commits do not move the dates
+	f.commit(10, map[string]string{"README.md": "# Test\n"})
+
+	history, err := GitMaintainersHistory(filepath.Join(f.dir, "maintainers.yaml"), "test-project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if history.File != "maintainers.yaml" || history.Shallow {
+		t.Errorf("unexpected history: %+v", history)
+	}
+	if len(history.Teams) != 2 {
+		t.Fatalf("expected 2 teams, got %+v", history.Teams)
+	}
+	if days := daysAgo(history.Teams[0].LastChange); days != 400 {
+		t.Errorf("project-maintainers should date from 400 days ago, got %d", days)
+	}
+	if days := daysAgo(history.Teams[1].LastChange); days != 100 || history.Teams[1].Commit != reviewersChanged {
+		t.Errorf("reviewers should date from the commit 100 days ago, got %d (%s)", days, history.Teams[1].Commit)
+	}
+	if days := daysAgo(history.LastChange); days != 100 {
+		t.Errorf("the entry should date from its last change 100 days ago, got %d", days)
+	}
+
+	project := validBaseProject()
+	result := CheckTeamStaleness(project, history, 180)
+	if !result.IsStale || result.Source != "git:maintainers.yaml" {
+		t.Errorf("a stale team should make the project stale: %+v", result)
+	}
+	if result.Teams[0].Team != "project-maintainers" || !result.Teams[0].IsStale || result.Teams[1].IsStale {
+		t.Errorf("only project-maintainers should be stale: %+v", result.Teams)
+	}
+	if !strings.Contains(result.Message, "1 team(s)") || !strings.Contains(result.Message, "project-maintainers (400 days)") {
+		t.Errorf("unexpected message %q", result.Message)
+	}
+
+	output := FormatStalenessResults([]StalenessResult{result})
+	if !strings.Contains(output, "Stale team: project-maintainers") || strings.Contains(output, "Stale team: reviewers") {
+		t.Errorf("expected only the stale team in output:\n%s", output)
+	}
+
+	if result := CheckTeamStaleness(project, history, 500); result.IsStale {
+		t.Errorf("no team is stale at a 500-day threshold: %+v", result)
+	}
+}
+
+func TestGitMaintainersHistorySharedFile(t *testing.T) {
+	f := newGitFixture(t)
+	f.commit(300, map[string]string{"projects/maintainers.yaml": maintainersYAML(
+		maintainerEntryYAML("alpha", "maintainers=alice"),
+		maintainerEntryYAML("beta", "maintainers=bob"))})
+	// Another project's change does not touch alpha's entry
+	f.commit(20, map[string]string{"projects/maintainers.yaml": maintainersYAML(
+		maintainerEntryYAML("alpha", "maintainers=alice"),
+		maintainerEntryYAML("beta", "maintainers=bob,carol"))})
+	// A team renamed is a new team
+	f.commit(5, map[string]string{"projects/maintainers.yaml": maintainersYAML(
+		maintainerEntryYAML("alpha", "maintainers=alice", "docs=erin"),
+		maintainerEntryYAML("beta", "core=bob,carol"))})
+
+	file := filepath.Join(f.dir, "projects", "maintainers.yaml")
+	alpha, err := GitMaintainersHistory(file, "alpha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alpha.File != "projects/maintainers.yaml" || len(alpha.Teams) != 2 {
+		t.Fatalf("unexpected history: %+v", alpha)
+	}
+	if days := daysAgo(alpha.Teams[0].LastChange); days != 300 {
+		t.Errorf("alpha maintainers should date from 300 days ago, got %d", days)
+	}
+	if days := daysAgo(alpha.Teams[1].LastChange); days != 5 {
+		t.Errorf("alpha docs should date from 5 days ago, got %d", days)
+	}
+
+	beta, err := GitMaintainersHistory(file, "beta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(beta.Teams) != 1 || beta.Teams[0].Team != "core" || daysAgo(beta.Teams[0].LastChange) != 5 {
+		t.Errorf("renamed team should date from the rename: %+v", beta.Teams)
+	}
+
+	if _, err := GitMaintainersHistory(file, "gamma"); err == nil || !strings.Contains(err.Error(), `"gamma"`) {
+		t.Errorf("expected an error for a project without an entry, got %v", err)
+	}
+}
+
+func TestGitMaintainersHistoryErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "maintainers.yaml"), maintainersYAML(maintainerEntryYAML("test-project", "m=alice")))
+	if _, err := GitMaintainersHistory(filepath.Join(dir, "maintainers.yaml"), "test-project"); err == nil {
+		t.Error("expected an error outside a git repository")
+	}
+
+	f := newGitFixture(t)
+	f.commit(1, map[string]string{"README.md": "# Test\n"})
+	writeFile(t, filepath.Join(f.dir, "maintainers.yaml"), maintainersYAML(maintainerEntryYAML("test-project", "m=alice")))
+	if _, err := GitMaintainersHistory(filepath.Join(f.dir, "maintainers.yaml"), "test-project"); err == nil || !strings.Contains(err.Error(), "not committed") {
+		t.Errorf("expected an error for an uncommitted file, got %v", err)
+	}
+}
+
+func daysAgo(when time.Time) int {
+	return int(time.Since(when).Hours() / 24)
+}
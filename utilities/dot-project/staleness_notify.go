@@ -0,0 +1,332 @@
+package projects
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxIssuePages caps how many pages of open issues are searched for an
+// existing staleness issue
+const maxIssuePages = 10
+
+// StalenessNotifier routes the stale projects of a sweep somewhere people
+// will see them
+type StalenessNotifier interface {
+	Name() string
+	Notify(stale []SweptProject) []Notification
+}
+
+// Notification records what a notifier did for a project
+type Notification struct {
+	Notifier string `json:"notifier"`
+	Project  string `json:"project,omitempty"`
+	Action   string `json:"action"`           // "opened", "updated", "unchanged", "posted", "written", "skipped" or "failed"
+	Target   string `json:"target,omitempty"` // Issue URL, Slack channel or file path
+	Error    string `json:"error,omitempty"`
+}
+
+// GitHubIssueNotifier opens an issue for each stale project in the repository
+// its project file lives in (or its first GitHub repository). Issues carry a
+// hidden marker, so later sweeps update the open issue instead of opening
+// another one.
+type GitHubIssueNotifier struct {
+	Labels  []string // Labels set on opened issues
+	client  *http.Client
+	baseURL string
+	token   string
+}
+
+// NewGitHubIssueNotifier creates a GitHub issue notifier. baseURL overrides
+// the GitHub API URL (use "" for default).
+func NewGitHubIssueNotifier(client *http.Client, baseURL, token string) *GitHubIssueNotifier {
+	if baseURL == "" {
+		baseURL = defaultGitHubAPIURL
+	}
+	return &GitHubIssueNotifier{client: client, baseURL: strings.TrimSuffix(baseURL, "/"), token: token}
+}
+
+// Name implements StalenessNotifier
+func (n *GitHubIssueNotifier) Name() string { return "github-issue" }
+
+// gitHubIssue is the part of a GitHub issue the notifier reads
+type gitHubIssue struct {
+	Number      int       `json:"number"`
+	HTMLURL     string    `json:"html_url"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	PullRequest *struct{} `json:"pull_request,omitempty"`
+}
+
+// Notify implements StalenessNotifier
+func (n *GitHubIssueNotifier) Notify(stale []SweptProject) []Notification {
+	var notifications []Notification
+	for _, p := range stale {
+		notification := Notification{Notifier: n.Name(), Project: p.Result.ProjectSlug}
+		owner, repo, ok := issueRepository(p)
+		if !ok {
+			notification.Action = "skipped"
+			notification.Error = "no GitHub repository to open an issue in"
+			notifications = append(notifications, notification)
+			continue
+		}
+
+		issue, action, err := n.upsertIssue(owner, repo, p)
+		if err != nil {
+			notification.Action = "failed"
+			notification.Target = owner + "/" + repo
+			notification.Error = err.Error()
+		} else {
+			notification.Action = action
+			notification.Target = issue.HTMLURL
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications
+}
+
+// upsertIssue updates the open staleness issue of a project, or opens one
+func (n *GitHubIssueNotifier) upsertIssue(owner, repo string, p SweptProject) (gitHubIssue, string, error) {
+	marker := stalenessIssueMarker(p.Result.ProjectSlug)
+	title := fmt.Sprintf("Maintainer list of %s needs review", p.Result.ProjectSlug)
+	body := stalenessIssueBody(p, marker)
+	repoURL := fmt.Sprintf("%s/repos/%s/%s/issues", n.baseURL, owner, repo)
+
+	existing, err := n.findIssue(repoURL, marker)
+	if err != nil {
+		return gitHubIssue{}, "", err
+	}
+	if existing != nil {
+		if existing.Body == body && existing.Title == title {
+			return *existing, "unchanged", nil
+		}
+		var updated gitHubIssue
+		patch := map[string]string{"title": title, "body": body}
+		if err := githubJSON(n.client, http.MethodPatch, fmt.Sprintf("%s/%d", repoURL, existing.Number), n.token, patch, &updated); err != nil {
+			return gitHubIssue{}, "", err
+		}
+		return updated, "updated", nil
+	}
+
+	var opened gitHubIssue
+	issue := map[string]interface{}{"title": title, "body": body}
+	if len(n.Labels) > 0 {
+		issue["labels"] = n.Labels
+	}
+	if err := githubJSON(n.client, http.MethodPost, repoURL, n.token, issue, &opened); err != nil {
+		return gitHubIssue{}, "", err
+	}
+	return opened, "opened", nil
+}
+
+// findIssue returns the open issue carrying marker, if any
+func (n *GitHubIssueNotifier) findIssue(repoURL, marker string) (*gitHubIssue, error) {
+	for page := 1; page <= maxIssuePages; page++ {
+		var issues []gitHubIssue
+		endpoint := fmt.Sprintf("%s?state=open&per_page=100&page=%d", repoURL, page)
+		if err := githubJSON(n.client, http.MethodGet, endpoint, n.token, nil, &issues); err != nil {
+			return nil, err
+		}
+		for i := range issues {
+			if issues[i].PullRequest == nil && strings.Contains(issues[i].Body, marker) {
+				return &issues[i], nil
+			}
+		}
+		if len(issues) < 100 {
+			break
+		}
+	}
+	return nil, nil
+}
+
+// stalenessIssueMarker identifies the staleness issue of a project
+func stalenessIssueMarker(slug string) string {
+	return fmt.Sprintf("<!-- dot-project:stale-maintainers project=%s -->", slug)
+}
+
+// stalenessIssueBody renders the issue body for a stale project. It holds
+// only dates and teams, not day counts, so a rerun with the same findings
+// renders the same body and leaves the issue alone.
+func stalenessIssueBody(p SweptProject, marker string) string {
+	r := p.Result
+	var b strings.Builder
+	b.WriteString(marker + "\n")
+	b.WriteString(fmt.Sprintf("The maintainer list of %s needs review.\n\n", r.ProjectSlug))
+	b.WriteString(fmt.Sprintf("Last maintainer update: %s\n", r.LastMaintainerUpdate.Format("2006-01-02")))
+	var teams []TeamStaleness
+	for _, team := range r.Teams {
+		if team.IsStale {
+			teams = append(teams, team)
+		}
+	}
+	if len(teams) > 0 {
+		b.WriteString("\nStale teams:\n")
+		for _, team := range teams {
+			b.WriteString(fmt.Sprintf("- `%s`: last changed %s\n", team.Team, team.LastChange.Format("2006-01-02")))
+		}
+	}
+	b.WriteString("\nPlease review `maintainers.yaml` and update it, even if only to confirm that the list is current. ")
+	b.WriteString("This issue is updated by the staleness sweep; close it once the list is updated.\n")
+	if r.ProjectLead != "" {
+		b.WriteString(fmt.Sprintf("\ncc @%s\n", strings.TrimPrefix(r.ProjectLead, "@")))
+	}
+	return b.String()
+}
+
+// issueRepository picks the repository to open a project's issue in: the one
+// its project file is served from, or its first GitHub repository
+func issueRepository(p SweptProject) (owner, repo string, ok bool) {
+	if file, ok := parseGitHubFileURL(p.Source); ok {
+		return file.Owner, file.Repo, true
+	}
+	for _, repository := range p.Project.Repositories {
+		if owner, repo, ok := parseGitHubRepoURL(repository); ok {
+			return owner, repo, true
+		}
+	}
+	return "", "", false
+}
+
+// SlackNotifier posts a message for each stale project through Slack
+// incoming webhooks. A webhook always posts to the channel it was created
+// for, so each cncf_slack_channel needs its own webhook. Projects whose
+// channel has none are posted through the default webhook, if set, with
+// their channel named in the message.
+type SlackNotifier struct {
+	client            *http.Client
+	webhooks          map[string]string // Webhook URL by channel, e.g. "#alpha"
+	defaultWebhookURL string
+}
+
+// NewSlackNotifier creates a Slack notifier posting through per-channel
+// webhooks and an optional default webhook (use "" for none)
+func NewSlackNotifier(client *http.Client, webhooks map[string]string, defaultWebhookURL string) *SlackNotifier {
+	return &SlackNotifier{client: client, webhooks: webhooks, defaultWebhookURL: defaultWebhookURL}
+}
+
+// Name implements StalenessNotifier
+func (n *SlackNotifier) Name() string { return "slack" }
+
+// Notify implements StalenessNotifier
+func (n *SlackNotifier) Notify(stale []SweptProject) []Notification {
+	var notifications []Notification
+	for _, p := range stale {
+		channel := p.Result.SlackChannel
+		notification := Notification{Notifier: n.Name(), Project: p.Result.ProjectSlug, Target: channel}
+		webhook, own := n.webhooks[channel]
+		if !own {
+			webhook = n.defaultWebhookURL
+		}
+		switch {
+		case channel == "":
+			notification.Action = "skipped"
+			notification.Error = "project has no cncf_slack_channel"
+		case webhook == "":
+			notification.Action = "skipped"
+			notification.Error = "no Slack webhook for " + channel
+		default:
+			if err := n.post(webhook, slackMessage(p, !own)); err != nil {
+				notification.Action = "failed"
+				notification.Error = err.Error()
+			} else {
+				notification.Action = "posted"
+			}
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications
+}
+
+// post sends a message to a webhook
+func (n *SlackNotifier) post(webhook, text string) error {
+	data, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	resp, err := n.client.Post(webhook, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Slack webhook returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// slackMessage renders the Slack message for a stale project
+func slackMessage(p SweptProject, nameChannel bool) string {
+	text := ":warning: " + p.Result.Message
+	if p.Result.ProjectLead != "" {
+		text += fmt.Sprintf(" (project lead: %s)", p.Result.ProjectLead)
+	}
+	text += ". Please review maintainers.yaml."
+	if nameChannel {
+		text += fmt.Sprintf(" (for %s)", p.Result.SlackChannel)
+	}
+	return text
+}
+
+// DigestNotifier writes every stale project to one file: JSON when the path
+// ends in .json, Markdown otherwise
+type DigestNotifier struct {
+	path string
+}
+
+// NewDigestNotifier creates a notifier writing a digest file
+func NewDigestNotifier(path string) *DigestNotifier {
+	return &DigestNotifier{path: path}
+}
+
+// Name implements StalenessNotifier
+func (n *DigestNotifier) Name() string { return "digest" }
+
+// Notify implements StalenessNotifier
+func (n *DigestNotifier) Notify(stale []SweptProject) []Notification {
+	notification := Notification{Notifier: n.Name(), Action: "written", Target: n.path}
+	var data []byte
+	if strings.EqualFold(filepath.Ext(n.path), ".json") {
+		results := make([]StalenessResult, 0, len(stale))
+		for _, p := range stale {
+			results = append(results, p.Result)
+		}
+		data, _ = json.MarshalIndent(results, "", "  ")
+		data = append(data, '\n')
+	} else {
+		data = []byte(stalenessDigestMarkdown(stale))
+	}
+	if err := os.WriteFile(n.path, data, 0644); err != nil {
+		notification.Action = "failed"
+		notification.Error = err.Error()
+	}
+	return []Notification{notification}
+}
+
+// stalenessDigestMarkdown renders the stale projects as a Markdown table
+func stalenessDigestMarkdown(stale []SweptProject) string {
+	var b strings.Builder
+	b.WriteString("# Stale Maintainer Lists\n\n")
+	if len(stale) == 0 {
+		b.WriteString("No stale projects.\n")
+		return b.String()
+	}
+	b.WriteString("| Project | Last update | Days | Stale teams | Lead | Slack |\n")
+	b.WriteString("|---------|-------------|------|-------------|------|-------|\n")
+	for _, p := range stale {
+		r := p.Result
+		var teams []string
+		for _, team := range r.Teams {
+			if team.IsStale {
+				teams = append(teams, fmt.Sprintf("%s (%d days)", team.Team, team.DaysSinceChange))
+			}
+		}
+		b.WriteString(fmt.Sprintf("| %s | %s | %d | %s | %s | %s |\n",
+			r.ProjectSlug, r.LastMaintainerUpdate.Format("2006-01-02"), r.DaysSinceUpdate,
+			strings.Join(teams, ", "), r.ProjectLead, r.SlackChannel))
+	}
+	return b.String()
+}
@@ -0,0 +1,231 @@
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeGitHub stands in for the GitHub API: commit lookups and the issues of
+// any repository
+type fakeGitHub struct {
+	mu       sync.Mutex
+	commits  map[string]time.Time      // "owner/repo/path" -> last commit date
+	issues   map[string][]*gitHubIssue // "owner/repo" -> issues
+	requests []string                  // "METHOD path" of write requests
+}
+
+func newFakeGitHub(t *testing.T) (*fakeGitHub, *httptest.Server) {
+	f := &fakeGitHub{commits: make(map[string]time.Time), issues: make(map[string][]*gitHubIssue)}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	return f, server
+}
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 4 || parts[0] != "repos" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	repo := parts[1] + "/" + parts[2]
+	if r.Method != http.MethodGet {
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	}
+
+	switch {
+	case parts[3] == "commits":
+		when, ok := f.commits[repo+"/"+r.URL.Query().Get("path")]
+		if !ok {
+			w.Write([]byte("[]"))
+			return
+		}
+		fmt.Fprintf(w, `[{"sha":"abc","commit":{"committer":{"date":%q}}}]`, when.Format(time.RFC3339))
+	case parts[3] == "issues" && len(parts) == 4 && r.Method == http.MethodGet:
+		json.NewEncoder(w).Encode(f.issues[repo])
+	case parts[3] == "issues" && len(parts) == 4 && r.Method == http.MethodPost:
+		issue := &gitHubIssue{}
+		json.NewDecoder(r.Body).Decode(issue)
+		issue.Number = len(f.issues[repo]) + 1
+		issue.HTMLURL = fmt.Sprintf("https://github.com/%s/issues/%d", repo, issue.Number)
+		f.issues[repo] = append(f.issues[repo], issue)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(issue)
+	case parts[3] == "issues" && len(parts) == 5 && r.Method == http.MethodPatch:
+		number, _ := strconv.Atoi(parts[4])
+		for _, issue := range f.issues[repo] {
+			if issue.Number == number {
+				json.NewDecoder(r.Body).Decode(issue)
+				json.NewEncoder(w).Encode(issue)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// staleProject builds a swept project that is stale
+func staleProject(slug, lead, channel string, repositories ...string) SweptProject {
+	project := validBaseProject()
+	project.Slug = slug
+	project.ProjectLead = lead
+	project.CNCFSlackChannel = channel
+	project.Repositories = repositories
+	return SweptProject{
+		Source:  "testdata/" + slug + ".yaml",
+		Project: project,
+		Result:  CheckStaleness(project, time.Now().AddDate(0, 0, -400), 180),
+	}
+}
+
+func TestGitHubIssueNotifierDeduplicates(t *testing.T) {
+	github, server := newFakeGitHub(t)
+	notifier := NewGitHubIssueNotifier(server.Client(), server.URL, "token")
+	notifier.Labels = []string{"maintainers"}
+	stale := []SweptProject{
+		staleProject("alpha", "alice", "", "https://github.com/alpha/alpha"),
+		staleProject("beta", "", "", "https://gitlab.com/beta/beta"),
+	}
+	// A pull request and another project's issue in the same repository are
+	// not this project's issue
+	github.issues["alpha/alpha"] = []*gitHubIssue{
+		{Number: 1, Body: stalenessIssueMarker("alpha"), PullRequest: &struct{}{}},
+		{Number: 2, Body: stalenessIssueMarker("alpha-extra")},
+	}
+
+	first := notifier.Notify(stale)
+	if len(first) != 2 || first[0].Action != "opened" || first[0].Target != "https://github.com/alpha/alpha/issues/3" {
+		t.Fatalf("expected an opened issue, got %+v", first)
+	}
+	if first[1].Action != "skipped" {
+		t.Errorf("a project without a GitHub repository should be skipped, got %+v", first[1])
+	}
+	opened := github.issues["alpha/alpha"][2]
+	if !strings.Contains(opened.Body, "cc @alice") || opened.Title != "Maintainer list of alpha needs review" {
+		t.Errorf("unexpected issue: %+v", opened)
+	}
+
+	// A rerun with the same findings touches nothing, even days later
+	stale[0].Result.DaysSinceUpdate++
+	stale[0].Result.Message = "Project alpha maintainer list has not been updated in 401 days (threshold: 180 days)"
+	if second := notifier.Notify(stale[:1]); second[0].Action != "unchanged" || second[0].Target != opened.HTMLURL {
+		t.Errorf("expected the open issue to be left alone, got %+v", second)
+	}
+
+	// Newer findings update the open issue instead of opening another
+	updated := time.Now().AddDate(0, 0, -500)
+	stale[0].Result = CheckStaleness(stale[0].Project, updated, 180)
+	if third := notifier.Notify(stale[:1]); third[0].Action != "updated" {
+		t.Errorf("expected the open issue to be updated, got %+v", third)
+	}
+	if len(github.issues["alpha/alpha"]) != 3 || !strings.Contains(opened.Body, updated.Format("2006-01-02")) {
+		t.Errorf("expected one staleness issue with the new dates, got %d issues: %q", len(github.issues["alpha/alpha"]), opened.Body)
+	}
+	if strings.Join(github.requests, ",") != "POST /repos/alpha/alpha/issues,PATCH /repos/alpha/alpha/issues/3" {
+		t.Errorf("unexpected write requests: %v", github.requests)
+	}
+}
+
+func TestIssueRepository(t *testing.T) {
+	tests := []struct {
+		source       string
+		repositories []string
+		want         string
+	}{
+		{"https://raw.githubusercontent.com/alpha/.project/main/project.yaml", []string{"https://github.com/alpha/code"}, "alpha/.project"},
+		{"testdata/alpha.yaml", []string{"https://gitlab.com/alpha/code", "https://github.com/Alpha/Code.git"}, "alpha/code"},
+		{"testdata/alpha.yaml", nil, ""},
+	}
+	for _, tt := range tests {
+		p := SweptProject{Source: tt.source, Project: Project{Repositories: tt.repositories}}
+		owner, repo, _ := issueRepository(p)
+		if got := strings.Trim(owner+"/"+repo, "/"); got != tt.want {
+			t.Errorf("issueRepository(%s, %v) = %q, want %q", tt.source, tt.repositories, got, tt.want)
+		}
+	}
+}
+
+func TestSlackNotifier(t *testing.T) {
+	var mu sync.Mutex
+	posts := make(map[string][]map[string]string) // By webhook path
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var post map[string]string
+		json.NewDecoder(r.Body).Decode(&post)
+		mu.Lock()
+		posts[r.URL.Path] = append(posts[r.URL.Path], post)
+		mu.Unlock()
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	webhooks := map[string]string{"#alpha": server.URL + "/alpha", "#gamma": server.URL + "/broken"}
+	stale := []SweptProject{
+		staleProject("alpha", "alice", "#alpha"),
+		staleProject("beta", "", ""),
+		staleProject("gamma", "", "#gamma"),
+		staleProject("delta", "", "#delta"),
+	}
+
+	notifications := NewSlackNotifier(server.Client(), webhooks, server.URL+"/default").Notify(stale)
+	var actions []string
+	for _, n := range notifications {
+		actions = append(actions, n.Action)
+	}
+	if strings.Join(actions, ",") != "posted,skipped,failed,posted" {
+		t.Errorf("unexpected actions %v: %+v", actions, notifications)
+	}
+	alpha := posts["/alpha"]
+	if len(alpha) != 1 || alpha[0]["channel"] != "" || !strings.Contains(alpha[0]["text"], "alpha maintainer list has not been updated in 400 days") {
+		t.Errorf("unexpected posts to the #alpha webhook: %v", alpha)
+	}
+	// A channel without its own webhook is named in the default webhook's channel
+	if fallback := posts["/default"]; len(fallback) != 1 || !strings.Contains(fallback[0]["text"], "(for #delta)") {
+		t.Errorf("unexpected posts to the default webhook: %v", fallback)
+	}
+
+	notifications = NewSlackNotifier(server.Client(), webhooks, "").Notify(stale[3:])
+	if notifications[0].Action != "skipped" || !strings.Contains(notifications[0].Error, "no Slack webhook for #delta") {
+		t.Errorf("expected a channel without a webhook to be skipped, got %+v", notifications[0])
+	}
+}
+
+func TestDigestNotifier(t *testing.T) {
+	dir := t.TempDir()
+	stale := []SweptProject{staleProject("alpha", "alice", "#alpha")}
+
+	markdown := filepath.Join(dir, "digest.md")
+	if n := NewDigestNotifier(markdown).Notify(stale); n[0].Action != "written" {
+		t.Fatalf("unexpected notification %+v", n)
+	}
+	data, _ := os.ReadFile(markdown)
+	if !strings.Contains(string(data), "| alpha | ") || !strings.Contains(string(data), "| 400 |") {
+		t.Errorf("unexpected digest:\n%s", data)
+	}
+
+	jsonDigest := filepath.Join(dir, "digest.json")
+	NewDigestNotifier(jsonDigest).Notify(stale)
+	var results []StalenessResult
+	data, _ = os.ReadFile(jsonDigest)
+	if err := json.Unmarshal(data, &results); err != nil || len(results) != 1 || results[0].ProjectSlug != "alpha" {
+		t.Errorf("unexpected JSON digest (%v):\n%s", err, data)
+	}
+
+	if n := NewDigestNotifier(filepath.Join(dir, "missing", "digest.md")).Notify(stale); n[0].Action != "failed" {
+		t.Errorf("expected a failed notification, got %+v", n)
+	}
+}
@@ -0,0 +1,213 @@
+package projects
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SweepOptions controls a staleness sweep over a project list
+type SweepOptions struct {
+	ThresholdDays int
+	GitHubAPIURL  string // Overrides the GitHub API URL (use "" for default)
+	GitHubToken   string // Token for the GitHub API; optional, but unauthenticated requests are heavily rate limited
+}
+
+// SweptProject is a project checked by a staleness sweep
+type SweptProject struct {
+	Source  string          `json:"source"` // Project list URL of the project file
+	Project Project         `json:"-"`
+	Result  StalenessResult `json:"result"`
+}
+
+// SweepError is a project list entry the sweep could not check
+type SweepError struct {
+	Source string `json:"source"`
+	Error  string `json:"error"`
+}
+
+// StalenessSweep is the outcome of checking every project in a project list,
+// and of routing the stale ones to notifiers
+type StalenessSweep struct {
+	Projects      []SweptProject `json:"projects"`
+	Errors        []SweepError   `json:"errors,omitempty"`
+	Notifications []Notification `json:"notifications,omitempty"`
+}
+
+// Results returns the staleness result of every swept project
+func (s *StalenessSweep) Results() []StalenessResult {
+	results := make([]StalenessResult, len(s.Projects))
+	for i, p := range s.Projects {
+		results[i] = p.Result
+	}
+	return results
+}
+
+// Stale returns the swept projects that are stale
+func (s *StalenessSweep) Stale() []SweptProject {
+	var stale []SweptProject
+	for _, p := range s.Projects {
+		if p.Result.IsStale {
+			stale = append(stale, p)
+		}
+	}
+	return stale
+}
+
+// Notify hands the stale projects to each notifier and records what they did
+func (s *StalenessSweep) Notify(notifiers ...StalenessNotifier) {
+	stale := s.Stale()
+	for _, notifier := range notifiers {
+		s.Notifications = append(s.Notifications, notifier.Notify(stale)...)
+	}
+}
+
+// NotificationFailures counts the notifications that failed
+func (s *StalenessSweep) NotificationFailures() int {
+	failed := 0
+	for _, n := range s.Notifications {
+		if n.Action == "failed" {
+			failed++
+		}
+	}
+	return failed
+}
+
+// SweepStaleness checks the staleness of every project in a project list.
+// Each project is dated from its maintainers.yaml, the file next to its
+// project.yaml: from the git history of a local checkout (per team), or from
+// the GitHub commits API for project files on GitHub. Local files outside git
+// fall back to their modification time. Entries that cannot be fetched or
+// dated are reported in Errors.
+func (pv *ProjectValidator) SweepStaleness(projectListPath string, opts SweepOptions) (*StalenessSweep, error) {
+	if projectListPath != "" {
+		pv.config.ProjectListURL = projectListPath
+	}
+	entries, err := pv.loadProjectListEntries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project list: %v", err)
+	}
+
+	swept := make([]SweptProject, len(entries))
+	errs := make([]error, len(entries))
+	forEachConcurrently(len(entries), pv.fetchOptions.Concurrency, func(i int) {
+		swept[i], errs[i] = pv.sweepProject(entries[i], opts)
+	})
+
+	sweep := &StalenessSweep{}
+	for i, entry := range entries {
+		if errs[i] != nil {
+			sweep.Errors = append(sweep.Errors, SweepError{Source: entry.URL, Error: errs[i].Error()})
+			continue
+		}
+		sweep.Projects = append(sweep.Projects, swept[i])
+	}
+	return sweep, nil
+}
+
+// sweepProject fetches and dates one project list entry
+func (pv *ProjectValidator) sweepProject(entry ProjectListEntry, opts SweepOptions) (SweptProject, error) {
+	content, err := pv.fetchContent(entry.URL)
+	if err != nil {
+		return SweptProject{}, fmt.Errorf("failed to fetch project: %w", err)
+	}
+	var project Project
+	if err := yaml.Unmarshal([]byte(content), &project); err != nil {
+		return SweptProject{}, fmt.Errorf("failed to parse project: %w", err)
+	}
+	id := entry.ID
+	if id == "" {
+		id = project.Slug
+	}
+	swept := SweptProject{Source: entry.URL, Project: project}
+
+	if isLocalURL(entry.URL) {
+		projectFile := strings.TrimPrefix(entry.URL, "file://")
+		maintainersFile := filepath.Join(filepath.Dir(projectFile), "maintainers.yaml")
+		if _, err := os.Stat(maintainersFile); err == nil {
+			if history, err := GitMaintainersHistory(maintainersFile, id); err == nil {
+				swept.Result = CheckTeamStaleness(project, history, opts.ThresholdDays)
+				return swept, nil
+			}
+		}
+		info, err := os.Stat(projectFile)
+		if err != nil {
+			return SweptProject{}, err
+		}
+		swept.Result = CheckStaleness(project, info.ModTime(), opts.ThresholdDays)
+		swept.Result.Source = "mtime:" + projectFile
+		return swept, nil
+	}
+
+	loc, ok := parseGitHubFileURL(entry.URL)
+	if !ok {
+		return SweptProject{}, fmt.Errorf("cannot date %s: not a local file or a GitHub file URL", entry.URL)
+	}
+	loc.Path = path.Join(path.Dir(loc.Path), "maintainers.yaml")
+	when, err := lastGitHubCommit(pv.client, opts.GitHubAPIURL, opts.GitHubToken, loc)
+	if err != nil {
+		return SweptProject{}, err
+	}
+	swept.Result = CheckStaleness(project, when, opts.ThresholdDays)
+	swept.Result.Source = fmt.Sprintf("github:%s/%s/%s", loc.Owner, loc.Repo, loc.Path)
+	return swept, nil
+}
+
+// gitHubFile locates a file in a GitHub repository
+type gitHubFile struct {
+	Owner, Repo, Ref, Path string
+}
+
+// parseGitHubFileURL parses raw.githubusercontent.com and github.com blob URLs
+func parseGitHubFileURL(rawURL string) (gitHubFile, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return gitHubFile{}, false
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch strings.ToLower(u.Host) {
+	case "raw.githubusercontent.com":
+		if len(parts) < 4 {
+			return gitHubFile{}, false
+		}
+		return gitHubFile{Owner: parts[0], Repo: parts[1], Ref: parts[2], Path: strings.Join(parts[3:], "/")}, true
+	case "github.com", "www.github.com":
+		if len(parts) < 5 || (parts[2] != "blob" && parts[2] != "raw") {
+			return gitHubFile{}, false
+		}
+		return gitHubFile{Owner: parts[0], Repo: parts[1], Ref: parts[3], Path: strings.Join(parts[4:], "/")}, true
+	}
+	return gitHubFile{}, false
+}
+
+// lastGitHubCommit returns the date of the last commit that touched a file
+func lastGitHubCommit(client *http.Client, baseURL, token string, file gitHubFile) (time.Time, error) {
+	if baseURL == "" {
+		baseURL = defaultGitHubAPIURL
+	}
+	query := url.Values{"path": {file.Path}, "sha": {file.Ref}, "per_page": {"1"}}
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/commits?%s", baseURL, file.Owner, file.Repo, query.Encode())
+
+	var commits []struct {
+		SHA    string `json:"sha"`
+		Commit struct {
+			Committer struct {
+				Date time.Time `json:"date"`
+			} `json:"committer"`
+		} `json:"commit"`
+	}
+	if err := githubJSON(client, http.MethodGet, endpoint, token, nil, &commits); err != nil {
+		return time.Time{}, fmt.Errorf("failed to list commits of %s in %s/%s: %w", file.Path, file.Owner, file.Repo, err)
+	}
+	if len(commits) == 0 {
+		return time.Time{}, fmt.Errorf("no commits touch %s in %s/%s", file.Path, file.Owner, file.Repo)
+	}
+	return commits[0].Commit.Committer.Date, nil
+}
@@ -0,0 +1,130 @@
+package projects
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseGitHubFileURL(t *testing.T) {
+	tests := map[string]string{
+		"https://raw.githubusercontent.com/alpha/.project/main/project.yaml":    "alpha .project main project.yaml",
+		"https://github.com/alpha/.project/blob/v1/projects/alpha/project.yaml": "alpha .project v1 projects/alpha/project.yaml",
+		"https://github.com/alpha/.project/tree/main/project.yaml":              "",
+		"https://example.com/alpha/.project/main/project.yaml":                  "",
+	}
+	for in, want := range tests {
+		got := ""
+		if file, ok := parseGitHubFileURL(in); ok {
+			got = strings.Join([]string{file.Owner, file.Repo, file.Ref, file.Path}, " ")
+		}
+		if got != want {
+			t.Errorf("parseGitHubFileURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestLastGitHubCommit(t *testing.T) {
+	github, server := newFakeGitHub(t)
+	when := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	github.commits["alpha/.project/maintainers.yaml"] = when
+
+	got, err := lastGitHubCommit(server.Client(), server.URL, "", gitHubFile{Owner: "alpha", Repo: ".project", Ref: "main", Path: "maintainers.yaml"})
+	if err != nil || !got.Equal(when) {
+		t.Errorf("lastGitHubCommit = %v, %v; want %v", got, err, when)
+	}
+	if _, err := lastGitHubCommit(server.Client(), server.URL, "", gitHubFile{Owner: "beta", Repo: ".project", Ref: "main", Path: "maintainers.yaml"}); err == nil || !strings.Contains(err.Error(), "no commits") {
+		t.Errorf("expected an error for a file without commits, got %v", err)
+	}
+}
+
+// TestStalenessSweepEndToEnd sweeps a project list of local .project
+// checkouts and routes the stale project to GitHub issues, Slack and a digest
+// served by local stand-ins, twice
+func TestStalenessSweepEndToEnd(t *testing.T) {
+	f := newGitFixture(t)
+	projectYAML := func(slug, channel string) string {
+		content := strings.Replace(validProjectYAML(), "slug: test-project", "slug: "+slug, 1)
+		content = strings.Replace(content, "github.com/test/repo", "github.com/"+slug+"/"+slug, 1)
+		return content + "project_lead: " + slug + "-lead\ncncf_slack_channel: \"" + channel + "\"\n"
+	}
+	f.commit(400, map[string]string{
+		"alpha/project.yaml":     projectYAML("alpha", "#alpha"),
+		"alpha/maintainers.yaml": maintainersYAML(maintainerEntryYAML("alpha", "maintainers=alice")),
+		"beta/project.yaml":      projectYAML("beta", "#beta"),
+		"beta/maintainers.yaml":  maintainersYAML(maintainerEntryYAML("beta", "maintainers=bob")),
+	})
+	f.commit(10, map[string]string{
+		"beta/maintainers.yaml": maintainersYAML(maintainerEntryYAML("beta", "maintainers=bob,carol")),
+	})
+
+	projectList := filepath.Join(t.TempDir(), "projectlist.yaml")
+	writeFile(t, projectList, "projects:\n"+
+		"  - url: "+filepath.Join(f.dir, "alpha", "project.yaml")+"\n"+
+		"  - url: "+filepath.Join(f.dir, "beta", "project.yaml")+"\n"+
+		"  - url: "+filepath.Join(f.dir, "missing", "project.yaml")+"\n")
+
+	github, server := newFakeGitHub(t)
+	slackPosts := 0
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slackPosts++
+		w.Write([]byte("ok"))
+	}))
+	defer slack.Close()
+	digest := filepath.Join(t.TempDir(), "digest.md")
+	notifiers := []StalenessNotifier{
+		NewGitHubIssueNotifier(server.Client(), server.URL, "token"),
+		NewSlackNotifier(slack.Client(), nil, slack.URL),
+		NewDigestNotifier(digest),
+	}
+
+	run := func() *StalenessSweep {
+		t.Helper()
+		pv := newTestValidator(t)
+		sweep, err := pv.SweepStaleness(projectList, SweepOptions{ThresholdDays: 180, GitHubAPIURL: server.URL})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sweep.Notify(notifiers...)
+		return sweep
+	}
+
+	sweep := run()
+	if len(sweep.Projects) != 2 || len(sweep.Errors) != 1 || !strings.Contains(sweep.Errors[0].Source, "missing") {
+		t.Fatalf("expected 2 projects and 1 error, got %+v", sweep)
+	}
+	stale := sweep.Stale()
+	if len(stale) != 1 || stale[0].Result.ProjectSlug != "alpha" || stale[0].Result.Teams[0].DaysSinceChange != 400 {
+		t.Fatalf("expected alpha to be stale by 400 days, got %+v", stale)
+	}
+	if sweep.Projects[1].Result.Source != "git:beta/maintainers.yaml" {
+		t.Errorf("beta should be dated from git, got %q", sweep.Projects[1].Result.Source)
+	}
+
+	var actions []string
+	for _, n := range sweep.Notifications {
+		actions = append(actions, n.Notifier+":"+n.Action)
+	}
+	if strings.Join(actions, ",") != "github-issue:opened,slack:posted,digest:written" || sweep.NotificationFailures() != 0 {
+		t.Errorf("unexpected notifications: %+v", sweep.Notifications)
+	}
+	if issues := github.issues["alpha/alpha"]; len(issues) != 1 || !strings.Contains(issues[0].Body, "`maintainers`: last changed") {
+		t.Errorf("expected one issue for alpha with its stale team, got %+v", issues)
+	}
+	if data, _ := os.ReadFile(digest); !strings.Contains(string(data), "| alpha |") || strings.Contains(string(data), "| beta |") {
+		t.Errorf("unexpected digest:\n%s", data)
+	}
+
+	// A rerun does not open a second issue
+	sweep = run()
+	if sweep.Notifications[0].Action != "unchanged" || len(github.issues["alpha/alpha"]) != 1 {
+		t.Errorf("expected the issue to be left alone on rerun, got %+v", sweep.Notifications[0])
+	}
+	if slackPosts != 2 {
+		t.Errorf("expected one Slack post per run, got %d", slackPosts)
+	}
+}
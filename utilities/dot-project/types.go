@@ -2,6 +2,7 @@ package projects
 
 import (
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -37,6 +38,9 @@ type Project struct {
 
 	// CNCF Landscape integration
 	Landscape *LandscapeConfig `json:"landscape,omitempty" yaml:"landscape,omitempty"`
+
+	// Validator settings
+	Validation *ValidationConfig `json:"validation,omitempty" yaml:"validation,omitempty"`
 }
 
 // LandscapeConfig maps the project to its CNCF Landscape location
@@ -145,13 +149,14 @@ type MaintainerLifecycle struct {
 
 // MaintainerValidationResult captures validation results for maintainers
 type MaintainerValidationResult struct {
-	ProjectID             string   `json:"project_id" yaml:"project_id"`
-	Org                   string   `json:"org,omitempty" yaml:"org,omitempty"`
-	Valid                 bool     `json:"valid" yaml:"valid"`
-	Errors                []string `json:"errors,omitempty" yaml:"errors,omitempty"`
-	VerificationAttempted bool     `json:"verification_attempted" yaml:"verification_attempted"`
-	VerificationPassed    bool     `json:"verification_passed" yaml:"verification_passed"`
-	VerifiedHandles       []string `json:"verified_handles,omitempty" yaml:"verified_handles,omitempty"`
+	ProjectID             string        `json:"project_id" yaml:"project_id"`
+	Org                   string        `json:"org,omitempty" yaml:"org,omitempty"`
+	Valid                 bool          `json:"valid" yaml:"valid"`
+	Errors                []string      `json:"errors,omitempty" yaml:"errors,omitempty"`
+	VerificationAttempted bool          `json:"verification_attempted" yaml:"verification_attempted"`
+	VerificationPassed    bool          `json:"verification_passed" yaml:"verification_passed"`
+	VerifiedHandles       []string      `json:"verified_handles,omitempty" yaml:"verified_handles,omitempty"`
+	Handles               []HandleCheck `json:"handles,omitempty" yaml:"handles,omitempty"` // Verifier answers, including renamed accounts
 }
 
 // Config represents the validator configuration
@@ -163,41 +168,72 @@ type Config struct {
 
 // ValidationResult represents the result of validating a project
 type ValidationResult struct {
-	URL          string    `json:"url"`
-	ProjectName  string    `json:"project_name,omitempty"`
-	Valid        bool      `json:"valid"`
-	Errors       []string  `json:"errors,omitempty"`
-	Changed      bool      `json:"changed"`
-	LastChecked  time.Time `json:"last_checked"`
-	PreviousHash string    `json:"previous_hash,omitempty"`
-	CurrentHash  string    `json:"current_hash"`
+	URL          string        `json:"url"`
+	ProjectName  string        `json:"project_name,omitempty"`
+	Valid        bool          `json:"valid"`
+	Errors       []string      `json:"errors,omitempty"`      // Messages of error-severity diagnostics
+	Diagnostics  []Diagnostic  `json:"diagnostics,omitempty"` // All findings with path, rule, severity and source position
+	Changed      bool          `json:"changed"`
+	LastChecked  time.Time     `json:"last_checked"`
+	PreviousHash string        `json:"previous_hash,omitempty"`
+	CurrentHash  string        `json:"current_hash"`
+	NotModified  bool          `json:"not_modified,omitempty"`  // Server answered 304; the cached copy was validated
+	FieldChanges []FieldChange `json:"field_changes,omitempty"` // Field-level changes since the cached copy
+
+	parsed *parsedProject // Set when the project parsed; used by CheckConsistency
 }
 
 // CacheEntry represents cached project data
 type CacheEntry struct {
-	URL         string    `json:"url"`
-	Hash        string    `json:"hash"`
-	LastChecked time.Time `json:"last_checked"`
-	Content     string    `json:"content"`
+	URL          string    `json:"url"`
+	Hash         string    `json:"hash"`
+	LastChecked  time.Time `json:"last_checked"`
+	Content      string    `json:"content"`
+	ETag         string    `json:"etag,omitempty"`          // Validator for conditional GETs (If-None-Match)
+	LastModified string    `json:"last_modified,omitempty"` // Validator for conditional GETs (If-Modified-Since)
 }
 
 // Cache manages cached project data
 type Cache struct {
 	Entries map[string]CacheEntry `json:"entries"`
 	dir     string
+	mu      sync.Mutex // Guards Entries while projects are validated concurrently
 }
 
 // ProjectValidator validates remote project YAML files
 type ProjectValidator struct {
-	config *Config
-	cache  *Cache
-	client *http.Client
+	config       *Config
+	cache        *Cache
+	client       *http.Client
+	profiles     *RequirementProfiles // Due Diligence profiles; nil disables requirement checks
+	targetPhase  string               // Phase to evaluate profiles for; "" uses each project's current phase
+	rules        *RuleRegistry        // Rules run against each project
+	fetchOptions FetchOptions         // Concurrency, rate limiting and retry settings
+	limiter      *hostLimiter         // Spaces out requests per host
+	verifier     HandleVerifier       // Checks maintainer handles; nil selects one from the environment
+	// advisoryForges are accepted in security.contact.advisory_url besides
+	// GitHub and gitlab.com
+	advisoryForges []Forge
+}
+
+// ValidationConfig holds per-project validator settings
+type ValidationConfig struct {
+	Ignore []RuleSuppression `json:"ignore,omitempty" yaml:"ignore,omitempty"` // Rules waived for this project
+}
+
+// RuleSuppression waives a validation rule for a project. In YAML it may be
+// written as a bare rule ID or as a mapping with a path and reason.
+type RuleSuppression struct {
+	Rule   string `json:"rule" yaml:"rule"`                         // Rule ID (e.g., "maturity-log-issue")
+	Path   string `json:"path,omitempty" yaml:"path,omitempty"`     // Only waive findings at or below this field path
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"` // Justification for the suppression
 }
 
 // ProjectListEntry represents a single entry in the project list
 type ProjectListEntry struct {
-	URL string `json:"url" yaml:"url"`
-	ID  string `json:"id,omitempty" yaml:"id,omitempty"`
+	URL        string            `json:"url" yaml:"url"`
+	ID         string            `json:"id,omitempty" yaml:"id,omitempty"`
+	Validation *ValidationConfig `json:"validation,omitempty" yaml:"validation,omitempty"` // Suppressions applied on top of the project's own
 }
 
 // ProjectListConfig represents the structure of the project list file
@@ -5,24 +5,17 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
-	"net/mail"
 	"net/url"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
-// githubAdvisoryURLPattern matches GitHub Security Advisory URLs of the form:
-// https://github.com/{org}/{repo}/security/advisories/new
-var githubAdvisoryURLPattern = regexp.MustCompile(`^https://github\.com/[^/]+/[^/]+/security/advisories/new$`)
-
 // NewProjectValidator creates a new project validator
 func NewProjectValidator(configPath string) (*ProjectValidator, error) {
 	config, err := loadConfig(configPath)
@@ -35,35 +28,61 @@ func NewProjectValidator(configPath string) (*ProjectValidator, error) {
 		return nil, fmt.Errorf("failed to load cache: %v", err)
 	}
 
-	return &ProjectValidator{
-		config: config,
-		cache:  cache,
-		client: &http.Client{Timeout: 30 * time.Second},
-	}, nil
+	profiles, err := DefaultRequirementProfiles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load requirement profiles: %v", err)
+	}
+
+	pv := &ProjectValidator{
+		config:   config,
+		cache:    cache,
+		client:   &http.Client{Timeout: 30 * time.Second},
+		profiles: profiles,
+	}
+	pv.rules = newValidatorRules(pv)
+	pv.SetFetchOptions(DefaultFetchOptions())
+	return pv, nil
+}
+
+// newValidatorRules returns the built-in rules plus the rules that need the
+// validator's state: requirement profiles (profiles and target phase), local
+// PathRef resolution (the checkout passed by ValidateLocal) and the cross-project
+// rules run by CheckConsistency
+func newValidatorRules(pv *ProjectValidator) *RuleRegistry {
+	rules := DefaultRuleRegistry()
+	_ = rules.Register(requirementProfileRule{pv: pv})
+	_ = rules.Register(pathRefExistsRule{})
+	for _, rule := range consistencyRules {
+		_ = rules.Register(rule)
+	}
+	return rules
+}
+
+// isValidatorRule reports whether id names a rule that is only registered on
+// a ProjectValidator, not in DefaultRuleRegistry
+func isValidatorRule(id string) bool {
+	return id == requirementProfileRuleID || id == pathRefExistsRuleID || isConsistencyRule(id)
+}
+
+// Rules returns the validator's rule registry so rules can be listed,
+// enabled or disabled before validating
+func (pv *ProjectValidator) Rules() *RuleRegistry {
+	return pv.rules
 }
 
 // ValidateProjects validates all projects in the project list
 func (pv *ProjectValidator) ValidateProjects() ([]ValidationResult, error) {
 	// Load project list
-	projectURLs, err := pv.loadProjectList()
+	entries, err := pv.loadProjectListEntries()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load project list: %v", err)
 	}
 
-	var results []ValidationResult
-	for _, url := range projectURLs {
-		result, err := pv.validateProject(url)
-		if err != nil {
-			log.Printf("Error validating project %s: %v", url, err)
-			result = ValidationResult{
-				URL:         url,
-				Valid:       false,
-				Errors:      []string{err.Error()},
-				LastChecked: time.Now(),
-			}
-		}
-		results = append(results, result)
-	}
+	// Validate with a bounded pool of workers; results keep project list order
+	results := make([]ValidationResult, len(entries))
+	forEachConcurrently(len(entries), pv.fetchOptions.Concurrency, func(i int) {
+		results[i] = pv.validateListedProject(entries[i])
+	})
 
 	// Save cache
 	if err := pv.cache.save(); err != nil {
@@ -73,26 +92,60 @@ func (pv *ProjectValidator) ValidateProjects() ([]ValidationResult, error) {
 	return results, nil
 }
 
+// validateListedProject validates a project list entry, turning a
+// validation error into a failed result
+func (pv *ProjectValidator) validateListedProject(entry ProjectListEntry) ValidationResult {
+	result, err := pv.validateProjectEntry(entry)
+	if err != nil {
+		log.Printf("Error validating project %s: %v", entry.URL, err)
+		result = ValidationResult{
+			URL:         entry.URL,
+			LastChecked: time.Now(),
+		}
+		result.addDiagnostics(Diagnostic{Rule: "fetch", Severity: SeverityError, Message: err.Error()})
+	}
+	return result
+}
+
 // validateProject validates a single project YAML file
 func (pv *ProjectValidator) validateProject(url string) (ValidationResult, error) {
+	return pv.validateProjectEntry(ProjectListEntry{URL: url})
+}
+
+// validateProjectEntry validates the project YAML file of a project list
+// entry, applying the entry's suppressions on top of the project's own
+func (pv *ProjectValidator) validateProjectEntry(entry ProjectListEntry) (ValidationResult, error) {
+	url := entry.URL
 	result := ValidationResult{
 		URL:         url,
 		LastChecked: time.Now(),
 	}
 
-	// Fetch content
-	content, err := pv.fetchContent(url)
+	// Fetch content, conditionally when the cache holds validators for it
+	cached, exists := pv.cache.get(url)
+	var cachedEntry *CacheEntry
+	if exists {
+		cachedEntry = &cached
+	}
+	fetched, err := pv.fetch(url, cachedEntry)
 	if err != nil {
-		result.Errors = append(result.Errors, fmt.Sprintf("Failed to fetch content: %v", err))
+		result.addDiagnostics(Diagnostic{
+			Rule:     "fetch",
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("Failed to fetch content: %v", err),
+		})
 		return result, nil
 	}
 
+	content := fetched.Content
+	result.NotModified = fetched.NotModified
+
 	// Calculate hash
 	hash := calculateHash(content)
 	result.CurrentHash = hash
 
 	// Check if changed
-	if cached, exists := pv.cache.Entries[url]; exists {
+	if exists {
 		result.PreviousHash = cached.Hash
 		result.Changed = cached.Hash != hash
 	} else {
@@ -100,37 +153,91 @@ func (pv *ProjectValidator) validateProject(url string) (ValidationResult, error
 	}
 
 	// Parse and validate YAML
+	pv.checkProjectContent(&result, content, entry, ruleContext{})
+	if result.parsed != nil && exists && result.Changed {
+		if changes, err := diffProjectContent(cached.Content, result.parsed.project); err == nil {
+			result.FieldChanges = changes
+		}
+	}
+
+	// Update cache
+	pv.cache.put(CacheEntry{
+		URL:          url,
+		Hash:         hash,
+		LastChecked:  time.Now(),
+		Content:      content,
+		ETag:         fetched.ETag,
+		LastModified: fetched.LastModified,
+	})
+
+	return result, nil
+}
+
+// checkProjectContent parses project.yaml content and runs the validator's
+// rules against it with ctx, recording the findings on result. result.parsed
+// is only set when the content parses.
+func (pv *ProjectValidator) checkProjectContent(result *ValidationResult, content string, entry ProjectListEntry, ctx ruleContext) {
 	var project Project
 	decoder := yaml.NewDecoder(strings.NewReader(content))
 	decoder.KnownFields(true)
 	if err := decoder.Decode(&project); err != nil {
-		result.Errors = append(result.Errors, fmt.Sprintf("YAML parsing error: %v", err))
-		result.Valid = false
-	} else {
-		result.ProjectName = project.Name
-		// Validate project structure
-		validationErrors := validateProjectStruct(project)
-		if len(validationErrors) > 0 {
-			result.Errors = append(result.Errors, validationErrors...)
-			result.Valid = false
-		} else {
-			result.Valid = true
+		result.addDiagnostics(Diagnostic{
+			Rule:     "yaml-parse",
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("YAML parsing error: %v", err),
+			Line:     yamlErrorLine(err),
+		})
+		return
+	}
+	result.ProjectName = project.Name
+
+	// Validate project structure, then map each finding back to its source position
+	var suppressions []RuleSuppression
+	if entry.Validation != nil {
+		suppressions = entry.Validation.Ignore
+	}
+	ctx.advisoryForges = pv.advisoryForges
+	diags := pv.rules.run(project, ctx, suppressions)
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &root); err == nil {
+		locateDiagnostics(&root, diags)
+	}
+	result.addDiagnostics(diags...)
+	result.parsed = &parsedProject{project: project, root: &root, listID: entry.ID}
+	if project.Validation != nil {
+		result.parsed.suppressions = append(result.parsed.suppressions, project.Validation.Ignore...)
+	}
+	result.parsed.suppressions = append(result.parsed.suppressions, suppressions...)
+}
+
+// addDiagnostics records diagnostics on the result, mirroring error-severity
+// messages into Errors and recomputing Valid from severities
+func (r *ValidationResult) addDiagnostics(diags ...Diagnostic) {
+	r.Diagnostics = append(r.Diagnostics, diags...)
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			r.Errors = append(r.Errors, d.Message)
 		}
 	}
+	r.Valid = !HasSeverity(r.Diagnostics, SeverityError)
+}
 
-	// Update cache
-	pv.cache.Entries[url] = CacheEntry{
-		URL:         url,
-		Hash:        hash,
-		LastChecked: time.Now(),
-		Content:     content,
+// loadProjectList loads the list of project URLs
+func (pv *ProjectValidator) loadProjectList() ([]string, error) {
+	entries, err := pv.loadProjectListEntries()
+	if err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	var urls []string
+	for _, entry := range entries {
+		urls = append(urls, entry.URL)
+	}
+	return urls, nil
 }
 
-// loadProjectList loads the list of project URLs
-func (pv *ProjectValidator) loadProjectList() ([]string, error) {
+// loadProjectListEntries loads the project list entries with their URLs expanded
+func (pv *ProjectValidator) loadProjectListEntries() ([]ProjectListEntry, error) {
 	// For compatibility, check if projectListURL is set, otherwise use a default projectlist.yaml
 	var projectListURL string
 	if pv.config.ProjectListURL != "" {
@@ -162,44 +269,16 @@ func (pv *ProjectValidator) loadProjectList() ([]string, error) {
 		return nil, fmt.Errorf("failed to parse project list YAML: %v", err)
 	}
 
-	var urls []string
-	for _, project := range projectList.Projects {
-		urls = append(urls, os.ExpandEnv(project.URL))
+	entries := projectList.Projects
+	for i := range entries {
+		entries[i].URL = os.ExpandEnv(entries[i].URL)
 	}
 
-	return urls, nil
+	return entries, nil
 }
 
-// fetchContent fetches content from a URL or local file
-func (pv *ProjectValidator) fetchContent(url string) (string, error) {
-	// Handle file:// URLs or local paths
-	if strings.HasPrefix(url, "file://") || (!strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://")) {
-		filePath := strings.TrimPrefix(url, "file://")
-		data, err := os.ReadFile(filePath)
-		if err != nil {
-			return "", err
-		}
-		return string(data), nil
-	}
-
-	// Handle HTTP/HTTPS URLs
-	resp, err := pv.client.Get(url)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
-	}
-
-	content, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
-
-	return string(content), nil
-}
+// MaturityPhases lists the maturity phases in lifecycle order
+var MaturityPhases = []string{"sandbox", "incubating", "graduated", "archived"}
 
 // ValidMaturityPhases lists the allowed maturity phase values
 var ValidMaturityPhases = map[string]bool{
@@ -217,279 +296,21 @@ func ValidateProjectStruct(project Project) []string {
 	return validateProjectStruct(project)
 }
 
-// validateProjectStruct validates the project structure
-func validateProjectStruct(project Project) []string {
-	var errors []string
-
-	// Required fields
-	if project.Name == "" {
-		errors = append(errors, "name is required")
-	}
-	if project.Description == "" {
-		errors = append(errors, "description is required")
-	}
-
-	// Validate slug
-	if project.Slug == "" {
-		errors = append(errors, "slug is required")
-	} else if !isValidSlug(project.Slug) {
-		errors = append(errors, fmt.Sprintf("slug must be lowercase alphanumeric with hyphens, got: %s", project.Slug))
-	}
-
-	// Validate project_lead (optional but must be non-empty if present)
-	// Accepts either a GitHub handle (e.g., "jdoe") or a GitHub team (e.g., "org/team-name")
-	if project.ProjectLead != "" {
-		lead := strings.TrimSpace(project.ProjectLead)
-		lead = strings.TrimPrefix(lead, "@")
-		if lead == "" {
-			errors = append(errors, "project_lead cannot be empty or just '@'")
-		} else if strings.Contains(lead, "/") {
-			parts := strings.Split(lead, "/")
-			if len(parts) != 2 {
-				errors = append(errors, fmt.Sprintf("project_lead team format must be org/team-name (got too many segments): %s", project.ProjectLead))
-			} else if parts[0] == "" {
-				errors = append(errors, fmt.Sprintf("project_lead team format requires a non-empty org (expected org/team-name): %s", project.ProjectLead))
-			} else if parts[1] == "" {
-				errors = append(errors, fmt.Sprintf("project_lead team format requires a non-empty team name (expected org/team-name): %s", project.ProjectLead))
-			}
-		}
-	}
-
-	// Validate cncf_slack_channel (optional but must start with # if present)
-	if project.CNCFSlackChannel != "" {
-		if !strings.HasPrefix(project.CNCFSlackChannel, "#") {
-			errors = append(errors, fmt.Sprintf("cncf_slack_channel must start with '#', got: %s", project.CNCFSlackChannel))
-		}
-	}
-
-	// Validate schema version
-	if project.SchemaVersion == "" {
-		errors = append(errors, "schema_version is required")
-	} else {
-		supported := false
-		for _, v := range SupportedSchemaVersions {
-			if project.SchemaVersion == v {
-				supported = true
-				break
-			}
-		}
-		if !supported {
-			errors = append(errors, fmt.Sprintf("unsupported schema_version: %s (supported: %v)", project.SchemaVersion, SupportedSchemaVersions))
-		}
-	}
-
-	// Validate maturity log
-	if len(project.MaturityLog) == 0 {
-		errors = append(errors, "maturity_log is required and cannot be empty")
-	} else {
-		for i, entry := range project.MaturityLog {
-			if entry.Phase == "" {
-				errors = append(errors, fmt.Sprintf("maturity_log[%d].phase is required", i))
-			}
-			if entry.Phase != "" && !ValidMaturityPhases[entry.Phase] {
-				errors = append(errors, fmt.Sprintf("maturity_log[%d].phase has invalid value %q (allowed: sandbox, incubating, graduated, archived)", i, entry.Phase))
-			}
-			if entry.Date.IsZero() {
-				errors = append(errors, fmt.Sprintf("maturity_log[%d].date is required", i))
-			}
-			if entry.Issue == "" {
-				errors = append(errors, fmt.Sprintf("maturity_log[%d].issue is required", i))
-			}
-		}
-
-		// Check chronological ordering
-		for i := 1; i < len(project.MaturityLog); i++ {
-			if !project.MaturityLog[i-1].Date.IsZero() && !project.MaturityLog[i].Date.IsZero() {
-				if project.MaturityLog[i].Date.Before(project.MaturityLog[i-1].Date) {
-					errors = append(errors, fmt.Sprintf("maturity_log[%d].date (%s) is before maturity_log[%d].date (%s); entries must be in chronological order",
-						i, project.MaturityLog[i].Date.Format("2006-01-02"),
-						i-1, project.MaturityLog[i-1].Date.Format("2006-01-02")))
-				}
-			}
-		}
-	}
-
-	// Validate repositories
-	if len(project.Repositories) == 0 {
-		errors = append(errors, "repositories is required and cannot be empty")
-	} else {
-		for i, repo := range project.Repositories {
-			if !isValidURL(repo) {
-				errors = append(errors, fmt.Sprintf("repositories[%d] is not a valid URL: %s", i, repo))
-			}
-		}
-	}
-
-	// Validate URLs
-	if project.Website != "" && !isValidURL(project.Website) {
-		errors = append(errors, fmt.Sprintf("website is not a valid URL: %s", project.Website))
-	}
-	if project.Artwork != "" && !isValidURL(project.Artwork) {
-		errors = append(errors, fmt.Sprintf("artwork is not a valid URL: %s", project.Artwork))
-	}
-
-	// Validate social links
-	for platform, url := range project.Social {
-		if !isValidURL(url) {
-			errors = append(errors, fmt.Sprintf("social.%s is not a valid URL: %s", platform, url))
-		}
-	}
-
-	// Validate audits
-	for i, audit := range project.Audits {
-		if audit.Date.IsZero() {
-			errors = append(errors, fmt.Sprintf("audits[%d].date is required", i))
-		}
-		if audit.Type == "" {
-			errors = append(errors, fmt.Sprintf("audits[%d].type is required", i))
-		}
-		if audit.URL == "" {
-			errors = append(errors, fmt.Sprintf("audits[%d].url is required", i))
-		} else if !isValidURL(audit.URL) {
-			errors = append(errors, fmt.Sprintf("audits[%d].url is not a valid URL: %s", i, audit.URL))
-		}
-	}
-
-	// Validate adopters
-	if project.Adopters != nil && project.Adopters.Path == "" {
-		errors = append(errors, "adopters.path is required")
-	}
-
-	// Validate new fields
-	if project.Security != nil {
-		if project.Security.Policy != nil && project.Security.Policy.Path == "" {
-			errors = append(errors, "security.policy.path is required")
-		}
-		if project.Security.ThreatModel != nil && project.Security.ThreatModel.Path == "" {
-			errors = append(errors, "security.threat_model.path is required")
-		}
-		if project.Security.Contact != nil {
-			if project.Security.Contact.Email == "" && project.Security.Contact.AdvisoryURL == "" {
-				errors = append(errors, "security.contact must have at least one of email or advisory_url")
-			}
-			if project.Security.Contact.Email != "" {
-				if _, err := mail.ParseAddress(project.Security.Contact.Email); err != nil {
-					errors = append(errors, fmt.Sprintf("security.contact.email is not a valid email: %s", project.Security.Contact.Email))
-				}
-			}
-			if project.Security.Contact.AdvisoryURL != "" {
-				if !githubAdvisoryURLPattern.MatchString(project.Security.Contact.AdvisoryURL) {
-					errors = append(errors, fmt.Sprintf("security.contact.advisory_url must be a valid GitHub Security Advisory URL (https://github.com/{org}/{repo}/security/advisories/new), got: %s", project.Security.Contact.AdvisoryURL))
-				}
-			}
-		}
-	}
-
-	if project.Governance != nil {
-		if project.Governance.Contributing != nil && project.Governance.Contributing.Path == "" {
-			errors = append(errors, "governance.contributing.path is required")
-		}
-		if project.Governance.Codeowners != nil && project.Governance.Codeowners.Path == "" {
-			errors = append(errors, "governance.codeowners.path is required")
-		}
-		if project.Governance.GovernanceDoc != nil && project.Governance.GovernanceDoc.Path == "" {
-			errors = append(errors, "governance.governance_doc.path is required")
-		}
-
-		// Validate governance DD PathRef fields
-		if project.Governance.VendorNeutralityStatement != nil && project.Governance.VendorNeutralityStatement.Path == "" {
-			errors = append(errors, "governance.vendor_neutrality_statement.path is required")
-		}
-		if project.Governance.DecisionMakingProcess != nil && project.Governance.DecisionMakingProcess.Path == "" {
-			errors = append(errors, "governance.decision_making_process.path is required")
-		}
-		if project.Governance.RolesAndTeams != nil && project.Governance.RolesAndTeams.Path == "" {
-			errors = append(errors, "governance.roles_and_teams.path is required")
-		}
-		if project.Governance.CodeOfConduct != nil && project.Governance.CodeOfConduct.Path == "" {
-			errors = append(errors, "governance.code_of_conduct.path is required")
-		}
-		if project.Governance.SubProjectList != nil && project.Governance.SubProjectList.Path == "" {
-			errors = append(errors, "governance.sub_project_list.path is required")
-		}
-		if project.Governance.SubProjectDocs != nil && project.Governance.SubProjectDocs.Path == "" {
-			errors = append(errors, "governance.sub_project_docs.path is required")
-		}
-		if project.Governance.ContributorLadder != nil && project.Governance.ContributorLadder.Path == "" {
-			errors = append(errors, "governance.contributor_ladder.path is required")
-		}
-		if project.Governance.ChangeProcess != nil && project.Governance.ChangeProcess.Path == "" {
-			errors = append(errors, "governance.change_process.path is required")
-		}
-		if project.Governance.CommsChannels != nil && project.Governance.CommsChannels.Path == "" {
-			errors = append(errors, "governance.comms_channels.path is required")
-		}
-		if project.Governance.CommunityCalendar != nil && project.Governance.CommunityCalendar.Path == "" {
-			errors = append(errors, "governance.community_calendar.path is required")
-		}
-		if project.Governance.ContributorGuide != nil && project.Governance.ContributorGuide.Path == "" {
-			errors = append(errors, "governance.contributor_guide.path is required")
-		}
-
-		// Validate maintainer_lifecycle
-		ml := project.Governance.MaintainerLifecycle
-		if ml.OnboardingDoc != nil && ml.OnboardingDoc.Path == "" {
-			errors = append(errors, "governance.maintainer_lifecycle.onboarding_doc.path is required")
-		}
-		if ml.ProgressionLadder != nil && ml.ProgressionLadder.Path == "" {
-			errors = append(errors, "governance.maintainer_lifecycle.progression_ladder.path is required")
-		}
-		if ml.OffboardingPolicy != nil && ml.OffboardingPolicy.Path == "" {
-			errors = append(errors, "governance.maintainer_lifecycle.offboarding_policy.path is required")
-		}
-		for i, u := range ml.MentoringProgram {
-			if !isValidURL(u) {
-				errors = append(errors, fmt.Sprintf("governance.maintainer_lifecycle.mentoring_program[%d] is not a valid URL: %s", i, u))
-			}
-		}
-	}
-
-	if project.Legal != nil {
-		if project.Legal.License != nil && project.Legal.License.Path == "" {
-			errors = append(errors, "legal.license.path is required")
-		}
-		if project.Legal.IdentityType != nil {
-			if project.Legal.IdentityType.HasCLA && !project.Legal.IdentityType.HasDCO && !project.Legal.IdentityType.CLAOnly {
-				errors = append(errors, "legal.identity_type: has_cla requires has_dco (CLA cannot be used without DCO; set cla_only: true if this project has an exception)")
-			}
-			if project.Legal.IdentityType.CLAOnly && !project.Legal.IdentityType.HasCLA {
-				errors = append(errors, "legal.identity_type: cla_only requires has_cla to be true")
-			}
-			if project.Legal.IdentityType.DCOURL != nil && project.Legal.IdentityType.DCOURL.Path == "" {
-				errors = append(errors, "legal.identity_type.dco_url.path is required")
-			}
-			if project.Legal.IdentityType.CLAURL != nil && project.Legal.IdentityType.CLAURL.Path == "" {
-				errors = append(errors, "legal.identity_type.cla_url.path is required")
-			}
-		}
-	}
-
-	// Validate landscape
-	if project.Landscape != nil {
-		if project.Landscape.Category == "" {
-			errors = append(errors, "landscape.category is required when landscape section is present")
-		}
-		if project.Landscape.Subcategory == "" {
-			errors = append(errors, "landscape.subcategory is required when landscape section is present")
-		}
-	}
+// ValidateProjectDiagnostics is the exported wrapper for structured project validation
+func ValidateProjectDiagnostics(project Project) []Diagnostic {
+	return validateProjectDiagnostics(project)
+}
 
-	if project.Documentation != nil {
-		if project.Documentation.Readme != nil && project.Documentation.Readme.Path == "" {
-			errors = append(errors, "documentation.readme.path is required")
-		}
-		if project.Documentation.Support != nil && project.Documentation.Support.Path == "" {
-			errors = append(errors, "documentation.support.path is required")
-		}
-		if project.Documentation.Architecture != nil && project.Documentation.Architecture.Path == "" {
-			errors = append(errors, "documentation.architecture.path is required")
-		}
-		if project.Documentation.API != nil && project.Documentation.API.Path == "" {
-			errors = append(errors, "documentation.api.path is required")
-		}
-	}
+// validateProjectStruct validates the project structure and returns the
+// message of every error
+func validateProjectStruct(project Project) []string {
+	return diagnosticMessages(FilterDiagnostics(validateProjectDiagnostics(project), SeverityError))
+}
 
-	return errors
+// validateProjectDiagnostics validates the project structure against the
+// built-in rules and returns path-addressed diagnostics
+func validateProjectDiagnostics(project Project) []Diagnostic {
+	return DefaultRuleRegistry().Run(project)
 }
 
 // isValidSlug checks if a string is a valid project slug (lowercase alphanumeric + hyphens)
@@ -569,6 +390,11 @@ func loadCache(dir string) (*Cache, error) {
 		dir:     dir,
 	}
 
+	// An empty directory keeps the cache in memory only
+	if dir == "" {
+		return cache, nil
+	}
+
 	// Ensure cache directory exists
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return nil, err
@@ -593,7 +419,12 @@ func loadCache(dir string) (*Cache, error) {
 
 // save saves cache to disk
 func (c *Cache) save() error {
+	if c.dir == "" {
+		return nil
+	}
+	c.mu.Lock()
 	data, err := json.MarshalIndent(c.Entries, "", "  ")
+	c.mu.Unlock()
 	if err != nil {
 		return err
 	}
@@ -602,6 +433,21 @@ func (c *Cache) save() error {
 	return os.WriteFile(cachePath, data, 0644)
 }
 
+// get returns the cached entry for a URL
+func (c *Cache) get(url string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.Entries[url]
+	return entry, ok
+}
+
+// put stores an entry, replacing any previous entry for its URL
+func (c *Cache) put(entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Entries[entry.URL] = entry
+}
+
 // GenerateDiff generates a diff report for changed projects
 func (pv *ProjectValidator) GenerateDiff(results []ValidationResult) string {
 	var diff strings.Builder
@@ -610,32 +456,105 @@ func (pv *ProjectValidator) GenerateDiff(results []ValidationResult) string {
 
 	changedCount := 0
 	errorCount := 0
+	warningCount := 0
 
 	for _, result := range results {
-		if result.Changed || !result.Valid {
+		warnings := FilterDiagnostics(result.Diagnostics, SeverityWarning)
+		if result.Changed || !result.Valid || len(warnings) > 0 {
 			if result.Changed {
 				changedCount++
 				diff.WriteString(fmt.Sprintf("CHANGED: %s (%s)\n", result.ProjectName, result.URL))
 				diff.WriteString(fmt.Sprintf("  Previous Hash: %s\n", result.PreviousHash))
 				diff.WriteString(fmt.Sprintf("  Current Hash:  %s\n", result.CurrentHash))
+				for _, c := range result.FieldChanges {
+					diff.WriteString(fmt.Sprintf("  %s\n", c))
+				}
 			}
 			if !result.Valid {
 				errorCount++
 				diff.WriteString(fmt.Sprintf("INVALID: %s (%s)\n", result.ProjectName, result.URL))
-				for _, err := range result.Errors {
-					diff.WriteString(fmt.Sprintf("  - %s\n", err))
+				if len(result.Diagnostics) == 0 {
+					for _, err := range result.Errors {
+						diff.WriteString(fmt.Sprintf("  - %s\n", err))
+					}
+				}
+				for _, d := range FilterDiagnostics(result.Diagnostics, SeverityError) {
+					diff.WriteString(fmt.Sprintf("  - %s\n", d))
+				}
+			}
+			if len(warnings) > 0 {
+				warningCount++
+				diff.WriteString(fmt.Sprintf("WARNINGS: %s (%s)\n", result.ProjectName, result.URL))
+				for _, d := range warnings {
+					diff.WriteString(fmt.Sprintf("  - %s\n", d))
 				}
 			}
 			diff.WriteString("\n")
 		}
 	}
 
-	diff.WriteString(fmt.Sprintf("Summary: %d projects validated, %d changed, %d with errors\n",
-		len(results), changedCount, errorCount))
+	diff.WriteString(fmt.Sprintf("Summary: %d projects validated, %d changed, %d with errors, %d with warnings\n",
+		len(results), changedCount, errorCount, warningCount))
 
 	return diff.String()
 }
 
+// GenerateMarkdownReport renders the validation report as Markdown, with a
+// table of field changes for each changed project, for PR comments and issues
+func (pv *ProjectValidator) GenerateMarkdownReport(results []ValidationResult) string {
+	var b strings.Builder
+	b.WriteString("# Project Validation Report\n\n")
+
+	changedCount, errorCount, warningCount := 0, 0, 0
+	for _, result := range results {
+		warnings := FilterDiagnostics(result.Diagnostics, SeverityWarning)
+		if !result.Changed && result.Valid && len(warnings) == 0 {
+			continue
+		}
+		name := result.ProjectName
+		if name == "" {
+			name = result.URL
+		}
+		b.WriteString(fmt.Sprintf("## %s\n\n%s\n\n", name, result.URL))
+
+		if result.Changed {
+			changedCount++
+			if result.PreviousHash == "" {
+				b.WriteString("**New project**\n\n")
+			} else {
+				b.WriteString("**Changed**\n\n")
+				table, _ := FormatFieldChanges(result.FieldChanges, "markdown")
+				b.WriteString(table + "\n")
+			}
+		}
+		if !result.Valid {
+			errorCount++
+			b.WriteString("**Errors**\n\n")
+			if len(result.Diagnostics) == 0 {
+				for _, err := range result.Errors {
+					b.WriteString(fmt.Sprintf("- %s\n", err))
+				}
+			}
+			for _, d := range FilterDiagnostics(result.Diagnostics, SeverityError) {
+				b.WriteString(fmt.Sprintf("- %s\n", d))
+			}
+			b.WriteString("\n")
+		}
+		if len(warnings) > 0 {
+			warningCount++
+			b.WriteString("**Warnings**\n\n")
+			for _, d := range warnings {
+				b.WriteString(fmt.Sprintf("- %s\n", d))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString(fmt.Sprintf("**Summary:** %d projects validated, %d changed, %d with errors, %d with warnings\n",
+		len(results), changedCount, errorCount, warningCount))
+	return b.String()
+}
+
 // NewValidator creates a new validator instance - compatibility alias
 func NewValidator(cacheDir string) *ProjectValidator {
 	config := &Config{
@@ -645,12 +564,17 @@ func NewValidator(cacheDir string) *ProjectValidator {
 	}
 
 	cache, _ := loadCache(cacheDir)
+	profiles, _ := DefaultRequirementProfiles()
 
-	return &ProjectValidator{
-		config: config,
-		cache:  cache,
-		client: &http.Client{Timeout: 30 * time.Second},
+	pv := &ProjectValidator{
+		config:   config,
+		cache:    cache,
+		client:   &http.Client{Timeout: 30 * time.Second},
+		profiles: profiles,
 	}
+	pv.rules = newValidatorRules(pv)
+	pv.SetFetchOptions(DefaultFetchOptions())
+	return pv
 }
 
 // ValidateAll validates all projects from a project list file - compatibility method
@@ -661,6 +585,42 @@ func (pv *ProjectValidator) ValidateAll(projectListPath string) ([]ValidationRes
 	return pv.ValidateProjects()
 }
 
+// LoadProjects fetches and parses the project.yaml of every entry in a
+// project list, with the validator's concurrency, rate limiting and retry
+// settings. Projects that cannot be fetched or parsed are logged and left out.
+func (pv *ProjectValidator) LoadProjects(projectListPath string) ([]Project, error) {
+	if projectListPath != "" {
+		pv.config.ProjectListURL = projectListPath
+	}
+	entries, err := pv.loadProjectListEntries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project list: %v", err)
+	}
+
+	loaded := make([]*Project, len(entries))
+	forEachConcurrently(len(entries), pv.fetchOptions.Concurrency, func(i int) {
+		content, err := pv.fetchContent(entries[i].URL)
+		if err != nil {
+			log.Printf("Error fetching project %s: %v", entries[i].URL, err)
+			return
+		}
+		var project Project
+		if err := yaml.Unmarshal([]byte(content), &project); err != nil {
+			log.Printf("Error parsing project %s: %v", entries[i].URL, err)
+			return
+		}
+		loaded[i] = &project
+	})
+
+	var result []Project
+	for _, project := range loaded {
+		if project != nil {
+			result = append(result, *project)
+		}
+	}
+	return result, nil
+}
+
 // FormatResults formats validation results in the specified format
 func (pv *ProjectValidator) FormatResults(results []ValidationResult, format string) (string, error) {
 	switch format {
@@ -676,6 +636,8 @@ func (pv *ProjectValidator) FormatResults(results []ValidationResult, format str
 			return "", err
 		}
 		return string(data), nil
+	case "markdown":
+		return pv.GenerateMarkdownReport(results), nil
 	case "text":
 		return pv.GenerateDiff(results), nil
 	default:
@@ -21,7 +21,14 @@ import (
 func newTestValidator(t *testing.T) *ProjectValidator {
 	t.Helper()
 	cacheDir := filepath.Join(t.TempDir(), "cache")
-	return NewValidator(cacheDir)
+	pv := NewValidator(cacheDir)
+	pv.SetFetchOptions(testFetchOptions())
+	return pv
+}
+
+// testFetchOptions keeps retries enabled but without real delays.
+func testFetchOptions() FetchOptions {
+	return FetchOptions{Concurrency: 4, MaxRetries: 3, RetryBackoff: time.Millisecond}
 }
 
 // writeFile is a short helper that writes content to path, failing the test on error.
@@ -924,6 +931,7 @@ func TestValidateProjectsHTTP(t *testing.T) {
 	writeFile(t, listPath, "projects:\n  - url: \""+srv.URL+"/project.yaml\"\n")
 
 	pv := NewValidator(cacheDir)
+	pv.SetFetchOptions(testFetchOptions())
 	results, err := pv.ValidateAll(listPath)
 	if err != nil {
 		t.Fatalf("ValidateAll: %v", err)
@@ -954,6 +962,7 @@ func TestValidateProjectsErrorCaptured(t *testing.T) {
 	writeFile(t, listPath, "projects:\n  - url: \""+srv.URL+"/project.yaml\"\n")
 
 	pv := NewValidator(cacheDir)
+	pv.SetFetchOptions(testFetchOptions())
 	results, err := pv.ValidateAll(listPath)
 	if err != nil {
 		t.Fatalf("ValidateAll: %v", err)
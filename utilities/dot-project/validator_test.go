@@ -291,10 +291,15 @@ func TestValidateMaintainersFile_Verification(t *testing.T) {
 		t.Fatalf("failed to write maintainers file: %v", err)
 	}
 
-	t.Setenv("MAINTAINER_API_ENDPOINT", "https://api-gw.platform.linuxfoundation.org/")
-	t.Setenv("MAINTAINER_API_STUB", "")
-	t.Setenv("LFX_AUTH_TOKEN", "") // Ensure LFX token is unset for stub test
+	t.Setenv("MAINTAINER_API_ENDPOINT", "")
+	t.Setenv("LFX_AUTH_TOKEN", "") // Ensure LFX is not selected from the environment
 
+	// Handles nobody checked must not be reported as verified
+	if _, err := validator.ValidateMaintainersFile(maintainersPath, true); err != errNoHandleVerifier {
+		t.Fatalf("expected errNoHandleVerifier without a verifier, got %v", err)
+	}
+
+	validator.SetHandleVerifier(newTestAllowList(t, "alice", "bob"))
 	results, err := validator.ValidateMaintainersFile(maintainersPath, true)
 	if err != nil {
 		t.Fatalf("unexpected error validating maintainers: %v", err)